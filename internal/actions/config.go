@@ -0,0 +1,10 @@
+package actions
+
+import "time"
+
+type Config struct {
+	// Retention is how long actions are kept in the log. Zero keeps them forever.
+	Retention time.Duration
+	// Archive copies pruned actions to the archive table instead of dropping them.
+	Archive bool
+}
@@ -0,0 +1,75 @@
+package actions
+
+import "time"
+
+// Kind is the type of a logged action.
+type Kind string
+
+const (
+	KindUserRegistered Kind = "user.registered"
+	KindUserReferred   Kind = "user.referred"
+
+	KindGroupEnabled    Kind = "group.enabled"
+	KindGroupDisabled   Kind = "group.disabled"
+	KindSettingsUpdated Kind = "group.setting_updated"
+	KindRoleGranted     Kind = "group.role_granted"
+	KindRoleRevoked     Kind = "group.role_revoked"
+
+	KindGiveawayCreated          Kind = "giveaway.created"
+	KindGiveawaySubmitted        Kind = "giveaway.submitted"
+	KindGiveawayApproved         Kind = "giveaway.approved"
+	KindGiveawayRejected         Kind = "giveaway.rejected"
+	KindGiveawayChangesRequested Kind = "giveaway.changes_requested"
+	KindGiveawayPublished        Kind = "giveaway.published"
+	KindGiveawayClosed           Kind = "giveaway.closed"
+	KindGiveawayFinished         Kind = "giveaway.finished"
+	KindGiveawayCancelled        Kind = "giveaway.cancelled"
+	KindGiveawayParticipated     Kind = "giveaway.participated"
+
+	KindDiscussionStarted Kind = "discussion.started"
+
+	KindFraudConfirmed Kind = "fraud.confirmed"
+	KindFraudDismissed Kind = "fraud.dismissed"
+)
+
+// Payload holds structured details of an action. Only fields relevant to the kind are set.
+type Payload struct {
+	OldStatus string `json:"old_status,omitempty"`
+	NewStatus string `json:"new_status,omitempty"`
+
+	MessageID int64 `json:"message_id,omitempty"`
+
+	SettingKey string  `json:"setting_key,omitempty"`
+	OldValue   *string `json:"old_value,omitempty"`
+	NewValue   *string `json:"new_value,omitempty"`
+
+	TargetUserID int64 `json:"target_user_id,omitempty"`
+	Tickets      int   `json:"tickets,omitempty"`
+	Score        int   `json:"score,omitempty"`
+}
+
+// Action describes an action to be logged. Zero IDs are stored as NULL.
+type Action struct {
+	Kind Kind
+
+	UserID     int64
+	GroupID    int64
+	GiveawayID int64
+
+	Description string
+	Payload     *Payload
+}
+
+// Filter selects logged actions. Zero fields are not applied.
+type Filter struct {
+	GroupID    int64
+	GiveawayID int64
+	UserID     int64
+	Kinds      []Kind
+
+	From time.Time
+	To   time.Time
+
+	Limit  int
+	Offset int
+}
@@ -10,24 +10,30 @@ type Entry struct {
 	bun.BaseModel `bun:"table:action_logs,alias:al"`
 
 	ID          uint64    `bun:"id,pk,autoincrement"`
+	GroupID     *int64    `bun:"group_id,nullzero"`
 	GiveawayID  *int64    `bun:"giveaway_id,nullzero"`
 	UserID      *int64    `bun:"user_id,nullzero"`
-	ActionType  string    `bun:"action_type,notnull"`
+	ActionType  Kind      `bun:"action_type,notnull"`
 	Description string    `bun:"description,notnull"`
+	Payload     *Payload  `bun:"payload,type:json,nullzero"`
 	CreatedAt   time.Time `bun:"created_at,scanonly"`
 }
 
-func NewEntry(
-	giveawayID *int64,
-	userID *int64,
-	actionType string,
-	description string,
-) *Entry {
+func NewEntry(action Action) *Entry {
 	//nolint:exhaustruct // partial constructor
 	return &Entry{
-		GiveawayID:  giveawayID,
-		UserID:      userID,
-		ActionType:  actionType,
-		Description: description,
+		GroupID:     ptrOrNil(action.GroupID),
+		GiveawayID:  ptrOrNil(action.GiveawayID),
+		UserID:      ptrOrNil(action.UserID),
+		ActionType:  action.Kind,
+		Description: action.Description,
+		Payload:     action.Payload,
 	}
 }
+
+func ptrOrNil(i int64) *int64 {
+	if i == 0 {
+		return nil
+	}
+	return &i
+}
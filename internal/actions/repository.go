@@ -2,11 +2,19 @@ package actions
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/uptrace/bun"
 )
 
+const (
+	archiveTable   = "action_logs_archive"
+	archiveColumns = "`id`, `group_id`, `giveaway_id`, `user_id`, `action_type`, `description`, `payload`, `created_at`"
+)
+
 type Repository struct {
 	db *bun.DB
 }
@@ -18,9 +26,15 @@ func NewRepository(db *bun.DB) *Repository {
 }
 
 func (r *Repository) LogAction(ctx context.Context, log *Entry) error {
-	_, err := r.db.NewInsert().
-		Model(log).
-		Exec(ctx)
+	query := r.db.NewInsert().
+		Model(log)
+
+	// Actions of a giveaway belong to its group, so group filters cover them too
+	if log.GroupID == nil && log.GiveawayID != nil {
+		query = query.Value("group_id", "(SELECT group_id FROM giveaways WHERE id = ?)", *log.GiveawayID)
+	}
+
+	_, err := query.Exec(ctx)
 
 	if err != nil {
 		return fmt.Errorf("failed to log action: %w", err)
@@ -28,3 +42,120 @@ func (r *Repository) LogAction(ctx context.Context, log *Entry) error {
 
 	return nil
 }
+
+// Select returns logged actions matching the filter, newest first.
+func (r *Repository) Select(ctx context.Context, filter Filter) ([]Entry, error) {
+	entries := make([]Entry, 0)
+
+	query := r.db.NewSelect().
+		Model(&entries).
+		Order("al.created_at DESC", "al.id DESC")
+
+	if filter.GroupID != 0 {
+		query = query.Where("al.group_id = ?", filter.GroupID)
+	}
+	if filter.GiveawayID != 0 {
+		query = query.Where("al.giveaway_id = ?", filter.GiveawayID)
+	}
+	if filter.UserID != 0 {
+		query = query.Where("al.user_id = ?", filter.UserID)
+	}
+	if len(filter.Kinds) > 0 {
+		query = query.Where("al.action_type IN (?)", bun.In(filter.Kinds))
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("al.created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("al.created_at < ?", filter.To)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit).Offset(filter.Offset)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to select actions: %w", err)
+	}
+
+	return entries, nil
+}
+
+// CountByGroup returns the number of actions of each type related to the group since the given time.
+func (r *Repository) CountByGroup(ctx context.Context, groupID int64, since time.Time) (map[Kind]int, error) {
+	rows := make([]struct {
+		ActionType Kind `bun:"action_type"`
+		Count      int  `bun:"count"`
+	}, 0)
+
+	if err := r.db.NewSelect().
+		Model((*Entry)(nil)).
+		ColumnExpr("al.action_type, COUNT(*) AS count").
+		Where("al.group_id = ?", groupID).
+		Where("al.created_at >= ?", since).
+		Group("al.action_type").
+		Scan(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to count actions: %w", err)
+	}
+
+	counts := make(map[Kind]int, len(rows))
+	for _, row := range rows {
+		counts[row.ActionType] = row.Count
+	}
+
+	return counts, nil
+}
+
+// Prune removes actions logged before the given time, copying them to the archive table first if requested.
+// It returns the number of removed rows.
+func (r *Repository) Prune(ctx context.Context, before time.Time, archive bool) (int64, error) {
+	var removed int64
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var lastID sql.NullInt64
+		if err := tx.NewSelect().
+			Model((*Entry)(nil)).
+			ColumnExpr("MAX(al.id)").
+			Where("al.created_at < ?", before).
+			Scan(ctx, &lastID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to find last expired action: %w", err)
+		}
+
+		if !lastID.Valid {
+			return nil
+		}
+
+		// The columns are listed, so a column added to one of the tables only breaks the archive loudly
+		if archive {
+			if _, err := tx.NewRaw(
+				"INSERT IGNORE INTO ? (?) SELECT ? FROM action_logs WHERE id <= ?",
+				bun.Ident(archiveTable),
+				bun.Safe(archiveColumns),
+				bun.Safe(archiveColumns),
+				lastID.Int64,
+			).Exec(ctx); err != nil {
+				return fmt.Errorf("failed to archive actions: %w", err)
+			}
+		}
+
+		res, err := tx.NewDelete().
+			Model((*Entry)(nil)).
+			Where("id <= ?", lastID.Int64).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to delete actions: %w", err)
+		}
+
+		removed, err = res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune actions: %w", err)
+	}
+
+	return removed, nil
+}
@@ -2,6 +2,7 @@ package actions
 
 import (
 	"context"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -9,33 +10,24 @@ import (
 type Service struct {
 	actions *Repository
 
+	config Config
+
 	logger *zap.Logger
 }
 
-func NewService(actions *Repository, logger *zap.Logger) *Service {
+func NewService(actions *Repository, config Config, logger *zap.Logger) *Service {
 	return &Service{
 		actions: actions,
 
+		config: config,
+
 		logger: logger,
 	}
 }
 
-func (s *Service) LogAction(
-	ctx context.Context,
-	actionType string,
-	userID int64,
-	giveawayID int64,
-	description string,
-) {
-	giveawayIDptr := ptrOrNil(giveawayID)
-	userIDptr := ptrOrNil(userID)
-
-	entry := NewEntry(
-		giveawayIDptr,
-		userIDptr,
-		actionType,
-		description,
-	)
+// Log stores the action. Failures are logged and don't affect the caller.
+func (s *Service) Log(ctx context.Context, action Action) {
+	entry := NewEntry(action)
 
 	if err := s.actions.LogAction(ctx, entry); err != nil {
 		s.logger.Error("failed to log action", zap.Any("entry", entry), zap.Error(err))
@@ -43,14 +35,36 @@ func (s *Service) LogAction(
 	}
 
 	s.logger.Debug("action logged",
-		zap.String("action_type", actionType),
-		zap.String("description", description),
+		zap.String("action_type", string(action.Kind)),
+		zap.String("description", action.Description),
 	)
 }
 
-func ptrOrNil(i int64) *int64 {
-	if i == 0 {
-		return nil
+// Select returns logged actions matching the filter, newest first.
+func (s *Service) Select(ctx context.Context, filter Filter) ([]Entry, error) {
+	return s.actions.Select(ctx, filter)
+}
+
+// CountByGroup returns the number of actions of each type related to the group since the given time.
+func (s *Service) CountByGroup(ctx context.Context, groupID int64, since time.Time) (map[Kind]int, error) {
+	return s.actions.CountByGroup(ctx, groupID, since)
+}
+
+// Prune removes actions older than the configured retention period, archiving them if enabled.
+// It does nothing when retention is disabled.
+func (s *Service) Prune(ctx context.Context) (int64, error) {
+	if s.config.Retention <= 0 {
+		return 0, nil
+	}
+
+	removed, err := s.actions.Prune(ctx, time.Now().Add(-s.config.Retention), s.config.Archive)
+	if err != nil {
+		return 0, err
 	}
-	return &i
+
+	if removed > 0 {
+		s.logger.Info("old actions pruned", zap.Int64("count", removed), zap.Bool("archived", s.config.Archive))
+	}
+
+	return removed, nil
 }
@@ -8,13 +8,21 @@ import (
 	"github.com/capcom6/lucky-pick-tg-bot/internal/config"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/db"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/discussions"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/events"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/events/subscribers"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/fraud"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/fsm"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/groups"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/health"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/roles"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/scheduler"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/server"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/settings"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/stats"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/tracing"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/users"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/webhooks"
 	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
 	"github.com/go-core-fx/bunfx"
 	"github.com/go-core-fx/cachefx"
@@ -41,17 +49,25 @@ func Run() {
 		openrouterfx.Module(),
 		//
 		db.Module(),
+		tracing.Module(),
 		server.Module(),
 		bot.Module(),
 		scheduler.Module(),
+		health.Module(),
 		fsm.Module(),
+		events.Module(),
+		subscribers.Module(),
 		//
 		users.Module(),
 		giveaways.Module(),
 		groups.Module(),
+		roles.Module(),
 		settings.Module(),
 		actions.Module(),
 		discussions.Module(),
+		fraud.Module(),
+		stats.Module(),
+		webhooks.Module(),
 		//
 		fx.Invoke(func(lc fx.Lifecycle, logger *zap.Logger) {
 			lc.Append(fx.Hook{
@@ -0,0 +1,12 @@
+package access
+
+import (
+	"go.uber.org/fx"
+)
+
+func Module() fx.Option {
+	return fx.Module(
+		"access",
+		fx.Provide(NewService),
+	)
+}
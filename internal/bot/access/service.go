@@ -0,0 +1,53 @@
+package access
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/admins"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/groups"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/roles"
+)
+
+// Service checks permissions of users in groups by their bot roles.
+type Service struct {
+	adminsSvc *admins.Service
+	rolesSvc  *roles.Service
+}
+
+func NewService(adminsSvc *admins.Service, rolesSvc *roles.Service) *Service {
+	return &Service{
+		adminsSvc: adminsSvc,
+		rolesSvc:  rolesSvc,
+	}
+}
+
+// Can reports whether the user has the permission in the group by the stored admins and roles.
+func (s *Service) Can(ctx context.Context, groupID, userID int64, perm roles.Permission) (bool, error) {
+	ok, err := s.rolesSvc.Can(ctx, groupID, userID, perm)
+	if err != nil {
+		return false, fmt.Errorf("failed to check permission: %w", err)
+	}
+
+	return ok, nil
+}
+
+// Verify is Can with the admin status re-checked with Telegram first. Use it before sensitive actions:
+// errors of Telegram deny access.
+func (s *Service) Verify(ctx context.Context, groupID, userID int64, perm roles.Permission) (bool, error) {
+	if err := s.adminsSvc.Refresh(ctx, groupID, userID); err != nil {
+		return false, fmt.Errorf("failed to refresh admin status: %w", err)
+	}
+
+	return s.Can(ctx, groupID, userID, perm)
+}
+
+// Groups returns the active groups where the user has the permission.
+func (s *Service) Groups(ctx context.Context, userID int64, perm roles.Permission) ([]groups.GroupWithSettings, error) {
+	grps, err := s.rolesSvc.Groups(ctx, userID, perm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get groups: %w", err)
+	}
+
+	return grps, nil
+}
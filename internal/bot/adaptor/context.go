@@ -3,10 +3,15 @@ package adaptor
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
 
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/middlewares/state"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/middlewares/user"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/fsm"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/metrics"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/users"
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
@@ -35,7 +40,26 @@ func (c *Context) State() (*fsm.State, error) {
 }
 
 func New(h func(*Context, *models.Update)) bot.HandlerFunc {
+	name := handlerName(h)
+
 	return func(ctx context.Context, _ *bot.Bot, update *models.Update) {
+		started := time.Now()
 		h(&Context{Context: ctx}, update)
+		metrics.ObserveUpdate(name, started)
+	}
+}
+
+// handlerName returns a short name of the handler method, e.g. "settings.(*Settings).handleTextInput".
+func handlerName(h any) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(h).Pointer())
+	if fn == nil {
+		return "unknown"
 	}
+
+	name := fn.Name()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	return strings.TrimSuffix(name, "-fm")
 }
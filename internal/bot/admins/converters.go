@@ -0,0 +1,80 @@
+package admins
+
+import (
+	"github.com/capcom6/lucky-pick-tg-bot/internal/groups"
+	"github.com/go-telegram/bot/models"
+)
+
+// toAdmin converts a chat member to a group admin. It reports false for non-admins.
+// The user ID is left for the caller to resolve.
+func toAdmin(member models.ChatMember) (groups.Admin, bool) {
+	switch {
+	case member.Owner != nil:
+		return groups.Admin{
+			UserID: 0,
+			Role:   groups.RoleOwner,
+			Rights: groups.Rights{
+				CanManageChat:      true,
+				CanDeleteMessages:  true,
+				CanRestrictMembers: true,
+				CanPinMessages:     true,
+				CanInviteUsers:     true,
+				CanPromoteMembers:  true,
+			},
+		}, true
+	case member.Administrator != nil:
+		a := member.Administrator
+		return groups.Admin{
+			UserID: 0,
+			Role:   groups.RoleAdmin,
+			Rights: groups.Rights{
+				CanManageChat:      a.CanManageChat,
+				CanDeleteMessages:  a.CanDeleteMessages,
+				CanRestrictMembers: a.CanRestrictMembers,
+				CanPinMessages:     a.CanPinMessages,
+				CanInviteUsers:     a.CanInviteUsers,
+				CanPromoteMembers:  a.CanPromoteMembers,
+			},
+		}, true
+	default:
+		return groups.Admin{}, false //nolint:exhaustruct // not an admin
+	}
+}
+
+// memberUser returns the Telegram user of a chat member.
+func memberUser(member models.ChatMember) *models.User {
+	switch {
+	case member.Owner != nil:
+		return member.Owner.User
+	case member.Administrator != nil:
+		return &member.Administrator.User
+	case member.Member != nil:
+		return member.Member.User
+	case member.Restricted != nil:
+		return member.Restricted.User
+	case member.Left != nil:
+		return member.Left.User
+	case member.Banned != nil:
+		return member.Banned.User
+	default:
+		return nil
+	}
+}
+
+// isAdmin reports whether the member is the owner or an administrator of the chat.
+func isAdmin(member models.ChatMember) bool {
+	return member.Type == models.ChatMemberTypeOwner || member.Type == models.ChatMemberTypeAdministrator
+}
+
+// isMember reports whether the user is in the chat, whatever their status.
+func isMember(member models.ChatMember) bool {
+	switch member.Type {
+	case models.ChatMemberTypeOwner, models.ChatMemberTypeAdministrator, models.ChatMemberTypeMember:
+		return true
+	case models.ChatMemberTypeRestricted:
+		return member.Restricted != nil && member.Restricted.IsMember
+	case models.ChatMemberTypeLeft, models.ChatMemberTypeBanned:
+	}
+
+	return false
+}
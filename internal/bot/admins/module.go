@@ -0,0 +1,14 @@
+package admins
+
+import (
+	"github.com/go-core-fx/logger"
+	"go.uber.org/fx"
+)
+
+func Module() fx.Option {
+	return fx.Module(
+		"admins",
+		logger.WithNamedLogger("admins"),
+		fx.Provide(NewService),
+	)
+}
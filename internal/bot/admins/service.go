@@ -0,0 +1,188 @@
+package admins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/middlewares/user"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/groups"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/users"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"go.uber.org/zap"
+)
+
+// Service keeps group admins and the join times of members in sync with Telegram.
+type Service struct {
+	bot *gotelegrambotfx.Bot
+
+	usersSvc  *users.Service
+	groupsSvc *groups.Service
+
+	logger *zap.Logger
+}
+
+func NewService(
+	bot *gotelegrambotfx.Bot,
+	usersSvc *users.Service,
+	groupsSvc *groups.Service,
+	logger *zap.Logger,
+) *Service {
+	return &Service{
+		bot: bot,
+
+		usersSvc:  usersSvc,
+		groupsSvc: groupsSvc,
+
+		logger: logger,
+	}
+}
+
+// Sync replaces the admins of the group with the list from Telegram.
+func (s *Service) Sync(ctx context.Context, group groups.Group) error {
+	members, err := s.bot.GetChatAdministrators(ctx, &bot.GetChatAdministratorsParams{ChatID: group.TelegramID})
+	if err != nil {
+		return fmt.Errorf("failed to get chat administrators: %w", err)
+	}
+
+	admins := make([]groups.Admin, 0, len(members))
+	for _, member := range members {
+		admin, ok := toAdmin(member)
+		tgUser := memberUser(member)
+		if !ok || tgUser == nil || tgUser.IsBot {
+			continue
+		}
+
+		u, regErr := s.usersSvc.RegisterUser(ctx, user.ToDomain(tgUser))
+		if regErr != nil {
+			return fmt.Errorf("failed to register admin: %w", regErr)
+		}
+
+		admin.UserID = u.ID
+		admins = append(admins, admin)
+	}
+
+	if syncErr := s.groupsSvc.SyncAdmins(ctx, group.ID, admins); syncErr != nil {
+		return fmt.Errorf("failed to sync admins of group %d: %w", group.ID, syncErr)
+	}
+
+	s.logger.Debug("group admins synced", zap.Int64("group_id", group.ID), zap.Int("admins", len(admins)))
+
+	return nil
+}
+
+// SyncAll syncs the admins of all active groups.
+func (s *Service) SyncAll(ctx context.Context) error {
+	grps, err := s.groupsSvc.SelectActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to select active groups: %w", err)
+	}
+
+	var errs []error
+	for _, group := range grps {
+		if syncErr := s.Sync(ctx, group); syncErr != nil {
+			errs = append(errs, syncErr)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Apply stores the membership and admin status changes of a chat member.
+// Changes between non-admin statuses other than joining or leaving the group are ignored.
+func (s *Service) Apply(ctx context.Context, update *models.ChatMemberUpdated) error {
+	adminChanged := isAdmin(update.OldChatMember) || isAdmin(update.NewChatMember)
+	membershipChanged := isMember(update.OldChatMember) != isMember(update.NewChatMember)
+	if !adminChanged && !membershipChanged {
+		return nil
+	}
+
+	tgUser := memberUser(update.NewChatMember)
+	if tgUser == nil || tgUser.IsBot {
+		return nil
+	}
+
+	group, err := s.groupsSvc.GetByTelegramID(ctx, update.Chat.ID)
+	if errors.Is(err, groups.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get group: %w", err)
+	}
+
+	u, err := s.usersSvc.RegisterUser(ctx, user.ToDomain(tgUser))
+	if err != nil {
+		return fmt.Errorf("failed to register user: %w", err)
+	}
+
+	if membershipChanged {
+		if memberErr := s.storeMembership(ctx, group.ID, u.ID, update); memberErr != nil {
+			return memberErr
+		}
+	}
+
+	if !adminChanged {
+		return nil
+	}
+
+	return s.store(ctx, group.ID, u.ID, update.NewChatMember)
+}
+
+// storeMembership records the join time of the user or forgets it when they leave.
+func (s *Service) storeMembership(ctx context.Context, groupID, userID int64, update *models.ChatMemberUpdated) error {
+	if !isMember(update.NewChatMember) {
+		if err := s.groupsSvc.RemoveMember(ctx, groupID, userID); err != nil {
+			return fmt.Errorf("failed to remove member: %w", err)
+		}
+		return nil
+	}
+
+	if err := s.groupsSvc.AddMember(ctx, groupID, userID, time.Unix(int64(update.Date), 0)); err != nil {
+		return fmt.Errorf("failed to add member: %w", err)
+	}
+
+	return nil
+}
+
+// Refresh re-checks with Telegram whether the user is an admin of the group and updates the local record.
+func (s *Service) Refresh(ctx context.Context, groupID, userID int64) error {
+	group, err := s.groupsSvc.GetByID(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("failed to get group: %w", err)
+	}
+
+	u, err := s.usersSvc.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	member, err := s.bot.GetChatMember(ctx, &bot.GetChatMemberParams{
+		ChatID: group.TelegramID,
+		UserID: u.TelegramUserID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get chat member: %w", err)
+	}
+
+	return s.store(ctx, groupID, userID, *member)
+}
+
+func (s *Service) store(ctx context.Context, groupID, userID int64, member models.ChatMember) error {
+	admin, ok := toAdmin(member)
+	if !ok {
+		if err := s.groupsSvc.RemoveAdmin(ctx, groupID, userID); err != nil {
+			return fmt.Errorf("failed to remove admin: %w", err)
+		}
+		return nil
+	}
+
+	admin.UserID = userID
+	if err := s.groupsSvc.SetAdmin(ctx, groupID, admin); err != nil {
+		return fmt.Errorf("failed to set admin: %w", err)
+	}
+
+	return nil
+}
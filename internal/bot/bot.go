@@ -15,7 +15,10 @@ func RegisterCommands(ctx context.Context, b *gotelegrambotfx.Bot, logger *zap.L
 		{Command: "start", Description: "Start the bot"},
 		{Command: "giveaway", Description: "Create a new giveaway"},
 		{Command: "cancel", Description: "Cancel current operation"},
+		{Command: "drafts", Description: "List saved drafts"},
+		{Command: "giveaways", Description: "List and cancel giveaways"},
 		{Command: "groups", Description: "List your groups"},
+		{Command: "stats", Description: "Show group statistics"},
 	}
 	_, err := b.SetMyCommands(ctx, &bot.SetMyCommandsParams{Commands: commands})
 	if err != nil {
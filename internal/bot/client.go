@@ -0,0 +1,54 @@
+package bot
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/metrics"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/tracing"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// pollTimeout matches the default timeout of the Telegram client.
+const pollTimeout = time.Minute
+
+// instrumentedClient records metrics and spans of Telegram Bot API calls.
+type instrumentedClient struct {
+	client *http.Client
+}
+
+func newInstrumentedClient(timeout time.Duration) *instrumentedClient {
+	return &instrumentedClient{
+		client: &http.Client{Timeout: timeout}, //nolint:exhaustruct // defaults are fine
+	}
+}
+
+// Do implements bot.HttpClient.
+func (c *instrumentedClient) Do(req *http.Request) (resp *http.Response, err error) {
+	// The path is /bot<token>/<method>, so only the last segment is safe to use as a label
+	method := path.Base(req.URL.Path)
+
+	// Calls outside of an update or a task, e.g. long polling, are not traced
+	if tracing.InTrace(req.Context()) {
+		ctx, span := tracing.Start(req.Context(), "telegram "+method, semconv.RPCMethod(method))
+		req = req.WithContext(ctx)
+		defer func() {
+			if resp != nil {
+				span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+			}
+			tracing.End(span, err)
+		}()
+	}
+
+	started := time.Now()
+	resp, err = c.client.Do(req)
+	metrics.ObserveTelegramRequest(method, started, resp, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", method, err)
+	}
+
+	return resp, nil
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 
+	"github.com/capcom6/lucky-pick-tg-bot/internal/tracing"
 	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
@@ -28,8 +29,11 @@ func (h *BaseHandler) SendMessage(ctx context.Context, params *bot.SendMessagePa
 	if _, err := h.Bot.SendMessage(ctx, params); err != nil {
 		h.Logger.Error(
 			"failed to send message",
-			zap.Any("params", params),
-			zap.Error(err),
+			append(
+				tracing.Fields(ctx),
+				zap.Any("params", params),
+				zap.Error(err),
+			)...,
 		)
 	}
 }
@@ -43,17 +47,21 @@ func (h *BaseHandler) SendReply(ctx context.Context, update *models.Update, para
 	if err != nil {
 		h.Logger.Error(
 			"failed to send reply",
-			zap.Any("params", params),
-			zap.Error(err),
+			append(
+				tracing.Fields(ctx),
+				zap.Any("params", params),
+				zap.Error(err),
+			)...,
 		)
 	}
 }
 
-func (h *BaseHandler) WithContext(update *models.Update) *zap.Logger {
-	logger := h.Logger
+// WithContext returns the logger with the trace of the context and the details of the update.
+func (h *BaseHandler) WithContext(ctx context.Context, update *models.Update) *zap.Logger {
+	logger := h.Logger.With(tracing.Fields(ctx)...)
 
 	if update == nil {
-		return h.Logger
+		return logger
 	}
 
 	switch {
@@ -103,7 +111,7 @@ func (h *BaseHandler) WithContext(update *models.Update) *zap.Logger {
 }
 
 func (h *BaseHandler) HandleError(ctx context.Context, update *models.Update, err error) {
-	h.WithContext(update).Error("handling error", zap.Error(err))
+	h.WithContext(ctx, update).Error("handling error", zap.Error(err))
 	h.SendReply(
 		ctx,
 		update,
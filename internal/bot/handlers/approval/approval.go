@@ -0,0 +1,224 @@
+package approval
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/access"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/adaptor"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/filter"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handler"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/keyboards"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/middlewares/state"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/roles"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// stateWaitComment waits for the comment of requested changes.
+	stateWaitComment = "approval:wait_comment"
+
+	// dataGiveawayID keeps the giveaway sent back for changes.
+	dataGiveawayID = "approval:giveaway_id"
+)
+
+// Handler lets owners and managers review giveaways submitted by editors.
+type Handler struct {
+	handler.BaseHandler
+
+	accessSvc    *access.Service
+	giveawaysSvc *giveaways.Service
+}
+
+func NewHandler(
+	bot *gotelegrambotfx.Bot,
+	accessSvc *access.Service,
+	giveawaysSvc *giveaways.Service,
+	logger *zap.Logger,
+) handler.Handler {
+	return &Handler{
+		BaseHandler: handler.BaseHandler{
+			Bot:    bot,
+			Logger: logger,
+		},
+
+		accessSvc:    accessSvc,
+		giveawaysSvc: giveawaysSvc,
+	}
+}
+
+// Register implements handler.Handler.
+func (h *Handler) Register(b *gotelegrambotfx.Bot) {
+	b.RegisterHandlerMatchFunc(h.callbackFilter(keyboards.ApprovePrefix), adaptor.New(h.handleApprove))
+	b.RegisterHandlerMatchFunc(h.callbackFilter(keyboards.RejectPrefix), adaptor.New(h.handleReject))
+	b.RegisterHandlerMatchFunc(h.callbackFilter(keyboards.RequestChangesPrefix), adaptor.New(h.handleRequestChanges))
+
+	b.RegisterHandlerMatchFunc(
+		filter.And(
+			func(update *models.Update) bool {
+				return update.Message != nil && update.Message.Chat.Type == models.ChatTypePrivate
+			},
+			state.NewStateFilter(stateWaitComment),
+		),
+		adaptor.New(h.handleComment),
+	)
+}
+
+func (h *Handler) callbackFilter(prefix string) bot.MatchFunc {
+	return func(update *models.Update) bool {
+		return update.CallbackQuery != nil && strings.HasPrefix(update.CallbackQuery.Data, prefix)
+	}
+}
+
+func (h *Handler) handleApprove(ctx *adaptor.Context, update *models.Update) {
+	giveaway, ok := h.giveaway(ctx, update, strings.TrimPrefix(update.CallbackQuery.Data, keyboards.ApprovePrefix))
+	if !ok {
+		return
+	}
+
+	if !h.review(ctx, update, giveaway, giveaways.DecisionApproved, "") {
+		return
+	}
+
+	h.SendReply(ctx, update, &bot.SendMessageParams{
+		Text: fmt.Sprintf(
+			"✅ Giveaway approved. It will be published at %s.",
+			giveaway.PublishDate.Format("02.01.2006 15:04"),
+		),
+	})
+}
+
+func (h *Handler) handleReject(ctx *adaptor.Context, update *models.Update) {
+	giveaway, ok := h.giveaway(ctx, update, strings.TrimPrefix(update.CallbackQuery.Data, keyboards.RejectPrefix))
+	if !ok {
+		return
+	}
+
+	if !h.review(ctx, update, giveaway, giveaways.DecisionRejected, "") {
+		return
+	}
+
+	h.SendReply(ctx, update, &bot.SendMessageParams{Text: "❌ Giveaway rejected. The author is notified."})
+}
+
+func (h *Handler) handleRequestChanges(ctx *adaptor.Context, update *models.Update) {
+	giveaway, ok := h.giveaway(
+		ctx,
+		update,
+		strings.TrimPrefix(update.CallbackQuery.Data, keyboards.RequestChangesPrefix),
+	)
+	if !ok {
+		return
+	}
+
+	if giveaway.Status != giveaways.StatusPendingApproval {
+		h.SendReply(ctx, update, &bot.SendMessageParams{Text: "ℹ️ This giveaway is already reviewed."})
+		return
+	}
+
+	st, err := ctx.State()
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	st.SetName(stateWaitComment)
+	st.AddData(dataGiveawayID, strconv.FormatInt(giveaway.ID, 10))
+
+	h.SendReply(ctx, update, &bot.SendMessageParams{
+		Text: "✏️ Send the changes you want to the author.\n\nUse /cancel to abort.",
+	})
+}
+
+func (h *Handler) handleComment(ctx *adaptor.Context, update *models.Update) {
+	st, err := ctx.State()
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	comment := strings.TrimSpace(update.Message.Text)
+	if comment == "" {
+		h.SendReply(ctx, update, &bot.SendMessageParams{Text: "❌ Please send a text comment."})
+		return
+	}
+
+	giveaway, ok := h.giveaway(ctx, update, st.GetData(dataGiveawayID))
+	st.Clear()
+	if !ok {
+		return
+	}
+
+	if !h.review(ctx, update, giveaway, giveaways.DecisionChangesRequested, comment) {
+		return
+	}
+
+	h.SendReply(ctx, update, &bot.SendMessageParams{Text: "✏️ The giveaway is sent back to the author."})
+}
+
+// giveaway loads the giveaway by its ID and checks that the user may approve giveaways of its group.
+func (h *Handler) giveaway(ctx *adaptor.Context, update *models.Update, idStr string) (*giveaways.Giveaway, bool) {
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to parse giveaway ID: %w", err))
+		return nil, false
+	}
+
+	giveaway, err := h.giveawaysSvc.GetByID(ctx, id)
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return nil, false
+	}
+
+	user, err := ctx.User()
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return nil, false
+	}
+
+	ok, err := h.accessSvc.Verify(ctx, giveaway.GroupID, user.ID, roles.PermScheduleGiveaway)
+	if err != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to verify permission: %w", err))
+		return nil, false
+	}
+
+	if !ok {
+		h.SendReply(ctx, update, &bot.SendMessageParams{
+			Text: "❌ Only group owners and managers can review giveaways.",
+		})
+	}
+
+	return giveaway, ok
+}
+
+func (h *Handler) review(
+	ctx *adaptor.Context,
+	update *models.Update,
+	giveaway *giveaways.Giveaway,
+	decision giveaways.Decision,
+	comment string,
+) bool {
+	user, err := ctx.User()
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return false
+	}
+
+	_, err = h.giveawaysSvc.Review(ctx, giveaway.ID, user.ID, decision, comment)
+	if errors.Is(err, giveaways.ErrNotPendingApproval) || errors.Is(err, giveaways.ErrInvalidTransition) {
+		h.SendReply(ctx, update, &bot.SendMessageParams{Text: "ℹ️ This giveaway is already reviewed."})
+		return false
+	}
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/adaptor"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/users"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx/extractors"
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// challengePrefix is the callback data prefix of challenge answers: challenge:<giveaway ID>:<option>.
+	challengePrefix = "challenge:"
+
+	challengeOptionsCount = 4
+)
+
+type challengeOption struct {
+	Emoji string
+	Name  string
+}
+
+//nolint:gochecknoglobals // constant list
+var challengeOptions = []challengeOption{
+	{Emoji: "🍎", Name: "яблоко"},
+	{Emoji: "🚗", Name: "машину"},
+	{Emoji: "🐱", Name: "кошку"},
+	{Emoji: "⚽", Name: "мяч"},
+	{Emoji: "🌲", Name: "ёлку"},
+	{Emoji: "🏠", Name: "дом"},
+	{Emoji: "🎸", Name: "гитару"},
+	{Emoji: "🐟", Name: "рыбу"},
+}
+
+func (p *Participant) filterChallengeCallback(update *models.Update) bool {
+	return update.CallbackQuery != nil && strings.HasPrefix(update.CallbackQuery.Data, challengePrefix)
+}
+
+// requireChallenge sends a challenge to the user if the group requires one.
+// It returns false with the text to show to the user when the entry must not be recorded yet.
+func (p *Participant) requireChallenge(
+	ctx *adaptor.Context,
+	giveaway *giveaways.Giveaway,
+	user *users.User,
+) (string, bool) {
+	giveawayID := giveaway.ID
+	logger := p.Logger.With(zap.Int64("giveaway_id", giveawayID), zap.Int64("user_id", user.ID))
+
+	settings, err := giveaways.NewSettings(giveaway.Group.Settings)
+	if err != nil {
+		logger.Error("failed to parse settings", zap.Error(err))
+		return alertSomethingWrong, false
+	}
+
+	if !settings.Captcha {
+		return "", true
+	}
+
+	if ok, partErr := p.giveawaysSvc.IsParticipant(ctx, giveawayID, user.ID); partErr != nil {
+		logger.Error("failed to check participation", zap.Error(partErr))
+		return alertSomethingWrong, false
+	} else if ok {
+		return "", true
+	}
+
+	challenge, err := p.giveawaysSvc.GetChallenge(ctx, giveawayID, user.ID)
+	if err != nil {
+		logger.Error("failed to get challenge", zap.Error(err))
+		return alertSomethingWrong, false
+	}
+
+	if challenge.IsLocked(time.Now()) {
+		return fmt.Sprintf(
+			"Слишком много неверных ответов. Попробуйте снова через %d мин.",
+			int(math.Ceil(time.Until(challenge.LockedUntil).Minutes())),
+		), false
+	}
+
+	if sendErr := p.sendChallenge(ctx, challenge, user.TelegramUserID); sendErr != nil {
+		logger.Warn("failed to send challenge", zap.Error(sendErr))
+		return "Чтобы участвовать, откройте личный чат с ботом, нажмите «Старт» и попробуйте снова.", false
+	}
+
+	return "Чтобы подтвердить участие, ответьте на вопрос бота в личных сообщениях.", false
+}
+
+// sendChallenge asks a new question of the challenge and stores its answer.
+func (p *Participant) sendChallenge(ctx *adaptor.Context, challenge *giveaways.Challenge, chatID int64) error {
+	giveawayID := challenge.GiveawayID
+
+	options := make([]challengeOption, 0, challengeOptionsCount)
+	for _, idx := range rand.Perm(len(challengeOptions))[:challengeOptionsCount] {
+		options = append(options, challengeOptions[idx])
+	}
+	answer := rand.IntN(challengeOptionsCount) //nolint:gosec // not security sensitive
+
+	buttons := make([]models.InlineKeyboardButton, 0, challengeOptionsCount)
+	for i, option := range options {
+		buttons = append(buttons, models.InlineKeyboardButton{
+			Text:         option.Emoji,
+			CallbackData: challengePrefix + strconv.FormatInt(giveawayID, 10) + ":" + strconv.Itoa(i),
+		})
+	}
+
+	if _, err := p.Bot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   fmt.Sprintf("🧩 Чтобы подтвердить участие в розыгрыше, нажмите на %s.", options[answer].Name),
+		ReplyMarkup: &models.InlineKeyboardMarkup{
+			InlineKeyboard: [][]models.InlineKeyboardButton{buttons},
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+
+	challenge.Answer = answer
+	if err := p.giveawaysSvc.SetChallenge(ctx, *challenge); err != nil {
+		return fmt.Errorf("failed to store challenge: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Participant) handleChallengeAnswer(ctx *adaptor.Context, update *models.Update) {
+	logger := p.WithContext(ctx, update)
+
+	user, err := ctx.User()
+	if err != nil {
+		p.HandleError(ctx, update, err)
+		return
+	}
+
+	giveawayIDStr, choice, _ := strings.Cut(strings.TrimPrefix(update.CallbackQuery.Data, challengePrefix), ":")
+	giveawayID, err := strconv.ParseInt(giveawayIDStr, 10, 64)
+	if err != nil {
+		logger.Error("failed to parse giveaway ID", zap.Error(err))
+		return
+	}
+
+	logger = logger.With(zap.Int64("giveaway_id", giveawayID), zap.Int64("user_id", user.ID))
+	chatID := extractors.ChatID(update)
+
+	challenge, err := p.giveawaysSvc.GetChallenge(ctx, giveawayID, user.ID)
+	if err != nil {
+		p.HandleError(ctx, update, err)
+		return
+	}
+
+	if challenge.Answer == giveaways.NoChallengeAnswer {
+		p.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   "Вопрос устарел. Нажмите кнопку участия в розыгрыше ещё раз.",
+		})
+		return
+	}
+
+	if choice == strconv.Itoa(challenge.Answer) {
+		if delErr := p.giveawaysSvc.DeleteChallenge(ctx, giveawayID, user.ID); delErr != nil {
+			logger.Error("failed to delete challenge", zap.Error(delErr))
+		}
+		p.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   p.participate(ctx, logger, giveawayID, user.ID),
+		})
+		return
+	}
+
+	giveaway, err := p.giveawaysSvc.GetByID(ctx, giveawayID)
+	if err != nil {
+		p.HandleError(ctx, update, err)
+		return
+	}
+
+	settings, err := giveaways.NewSettings(giveaway.Group.Settings)
+	if err != nil {
+		p.HandleError(ctx, update, err)
+		return
+	}
+
+	challenge.Answer = giveaways.NoChallengeAnswer
+	challenge.Attempts++
+
+	if challenge.Attempts >= settings.CaptchaAttempts {
+		logger.Info("user locked out of challenge", zap.Int("attempts", challenge.Attempts))
+
+		challenge.Attempts = 0
+		challenge.LockedUntil = time.Now().Add(settings.CaptchaLockout)
+		if setErr := p.giveawaysSvc.SetChallenge(ctx, *challenge); setErr != nil {
+			p.HandleError(ctx, update, setErr)
+			return
+		}
+
+		p.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text: fmt.Sprintf(
+				"❌ Неверно. Попытки исчерпаны, попробуйте снова через %d мин.",
+				int(math.Ceil(settings.CaptchaLockout.Minutes())),
+			),
+		})
+		return
+	}
+
+	// The attempt is stored before the next question, so it counts even if the question can't be sent
+	if setErr := p.giveawaysSvc.SetChallenge(ctx, *challenge); setErr != nil {
+		p.HandleError(ctx, update, setErr)
+		return
+	}
+
+	p.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   fmt.Sprintf("❌ Неверно. Осталось попыток: %d.", settings.CaptchaAttempts-challenge.Attempts),
+	})
+
+	if sendErr := p.sendChallenge(ctx, challenge, chatID); sendErr != nil {
+		logger.Error("failed to send challenge", zap.Error(sendErr))
+	}
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/adaptor"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handler"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/discussions"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/go-telegram/bot/models"
+	"go.uber.org/zap"
+)
+
+// Discussion records the replies to the questions the bot asks in groups, see discussions.AnsweredStrategy.
+type Discussion struct {
+	handler.BaseHandler
+
+	discussionsSvc *discussions.Service
+}
+
+func NewDiscussion(
+	bot *gotelegrambotfx.Bot,
+	discussionsSvc *discussions.Service,
+	logger *zap.Logger,
+) handler.Handler {
+	return &Discussion{
+		BaseHandler: handler.BaseHandler{
+			Bot:    bot,
+			Logger: logger,
+		},
+
+		discussionsSvc: discussionsSvc,
+	}
+}
+
+func (d *Discussion) Register(b *gotelegrambotfx.Bot) {
+	b.RegisterHandlerMatchFunc(d.filterAnswer, adaptor.New(d.handleAnswer))
+}
+
+func (d *Discussion) filterAnswer(update *models.Update) bool {
+	msg := update.Message
+	if msg == nil || msg.Text == "" || msg.ReplyToMessage == nil || msg.ReplyToMessage.From == nil {
+		return false
+	}
+
+	if msg.Chat.Type != models.ChatTypeGroup && msg.Chat.Type != models.ChatTypeSupergroup {
+		return false
+	}
+
+	return msg.ReplyToMessage.From.IsBot
+}
+
+func (d *Discussion) handleAnswer(ctx *adaptor.Context, update *models.Update) {
+	logger := d.WithContext(ctx, update)
+
+	user, err := ctx.User()
+	if err != nil {
+		logger.Error("failed to get user", zap.Error(err))
+		return
+	}
+
+	msg := update.Message
+	err = d.discussionsSvc.Answer(ctx, msg.Chat.ID, int64(msg.ReplyToMessage.ID), discussions.Answer{
+		UserID:            user.ID,
+		TelegramMessageID: int64(msg.ID),
+		Text:              msg.Text,
+	})
+	if err != nil && !errors.Is(err, discussions.ErrNotFound) {
+		logger.Error("failed to record answer", zap.Error(err))
+	}
+}
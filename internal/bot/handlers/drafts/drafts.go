@@ -0,0 +1,152 @@
+package drafts
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/adaptor"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handler"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/keyboards"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/wizard"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/fsm"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"go.uber.org/zap"
+)
+
+// Handler lets users list, resume and discard drafts of expired conversations.
+type Handler struct {
+	handler.BaseHandler
+
+	fsmService *fsm.Service
+}
+
+func NewHandler(bot *gotelegrambotfx.Bot, fsmService *fsm.Service, logger *zap.Logger) handler.Handler {
+	return &Handler{
+		BaseHandler: handler.BaseHandler{
+			Bot:    bot,
+			Logger: logger,
+		},
+
+		fsmService: fsmService,
+	}
+}
+
+// Register implements handler.Handler.
+func (h *Handler) Register(b *gotelegrambotfx.Bot) {
+	b.RegisterHandler(
+		bot.HandlerTypeMessageText,
+		"drafts",
+		bot.MatchTypeCommandStartOnly,
+		adaptor.New(h.handleList),
+	)
+
+	b.RegisterHandlerMatchFunc(h.callbackFilter(keyboards.DraftResumePrefix), adaptor.New(h.handleResume))
+	b.RegisterHandlerMatchFunc(h.callbackFilter(keyboards.DraftDiscardPrefix), adaptor.New(h.handleDiscard))
+}
+
+func (h *Handler) callbackFilter(prefix string) bot.MatchFunc {
+	return func(update *models.Update) bool {
+		return update.CallbackQuery != nil && strings.HasPrefix(update.CallbackQuery.Data, prefix)
+	}
+}
+
+func (h *Handler) handleList(ctx *adaptor.Context, update *models.Update) {
+	if update.Message.Chat.Type != models.ChatTypePrivate {
+		h.SendReply(ctx, update, &bot.SendMessageParams{Text: "❌ Drafts are only available in private chats."})
+		return
+	}
+
+	drafts, err := h.fsmService.Drafts(ctx, update.Message.From.ID)
+	if err != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to list drafts: %w", err))
+		return
+	}
+
+	if len(drafts) == 0 {
+		h.SendReply(ctx, update, &bot.SendMessageParams{Text: "📭 You have no saved drafts."})
+		return
+	}
+
+	lines := make([]string, 0, len(drafts)+1)
+	lines = append(lines, "📝 Your drafts:\n")
+	rows := make([][]models.InlineKeyboardButton, 0, len(drafts))
+	for i, draft := range drafts {
+		lines = append(lines, fmt.Sprintf(
+			"%d. %s, saved %s",
+			i+1, draft.Flow.Title, draft.CreatedAt.Format("2006-01-02 15:04"),
+		))
+		rows = append(rows, keyboards.DraftRow(draft.ID, fmt.Sprintf("%d. %s", i+1, draft.Flow.Title)))
+	}
+
+	h.SendReply(ctx, update, &bot.SendMessageParams{
+		Text:        strings.Join(lines, "\n"),
+		ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: rows},
+	})
+}
+
+func (h *Handler) handleResume(ctx *adaptor.Context, update *models.Update) {
+	draftID, err := strconv.ParseInt(strings.TrimPrefix(update.CallbackQuery.Data, keyboards.DraftResumePrefix), 10, 64)
+	if err != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to parse draft ID: %w", err))
+		return
+	}
+
+	st, err := ctx.State()
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	if st.Name != "" {
+		h.SendReply(ctx, update, &bot.SendMessageParams{
+			Text: "❌ Finish or /cancel the current operation before resuming a draft.",
+		})
+		return
+	}
+
+	draft, err := h.fsmService.TakeDraft(ctx, update.CallbackQuery.From.ID, draftID)
+	if errors.Is(err, fsm.ErrDraftNotFound) {
+		h.SendReply(ctx, update, &bot.SendMessageParams{Text: "❌ The draft is no longer available."})
+		return
+	}
+	if err != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to take draft: %w", err))
+		return
+	}
+
+	// The state middleware stores the restored state after the handler
+	st.Restore(draft.State)
+
+	h.SendReply(ctx, update, &bot.SendMessageParams{
+		Text: fmt.Sprintf("▶️ Your %s draft is restored. Use /cancel to abort.", draft.Flow.Title),
+		ReplyMarkup: &models.InlineKeyboardMarkup{
+			InlineKeyboard: [][]models.InlineKeyboardButton{
+				{{Text: "▶️ Continue", CallbackData: wizard.ContinueData}},
+			},
+		},
+	})
+}
+
+func (h *Handler) handleDiscard(ctx *adaptor.Context, update *models.Update) {
+	draftID, err := strconv.ParseInt(strings.TrimPrefix(update.CallbackQuery.Data, keyboards.DraftDiscardPrefix), 10, 64)
+	if err != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to parse draft ID: %w", err))
+		return
+	}
+
+	err = h.fsmService.DiscardDraft(ctx, update.CallbackQuery.From.ID, draftID)
+	if errors.Is(err, fsm.ErrDraftNotFound) {
+		h.SendReply(ctx, update, &bot.SendMessageParams{Text: "❌ The draft is no longer available."})
+		return
+	}
+	if err != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to discard draft: %w", err))
+		return
+	}
+
+	h.SendReply(ctx, update, &bot.SendMessageParams{Text: "🗑 Draft discarded."})
+}
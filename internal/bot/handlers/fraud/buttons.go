@@ -0,0 +1,27 @@
+package fraud
+
+import (
+	"strconv"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// reviewKeyboard creates keyboard with review decisions for the flag.
+func reviewKeyboard(flagID int64) *models.InlineKeyboardMarkup {
+	id := strconv.FormatInt(flagID, 10)
+
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{
+					Text:         "🚫 Exclude",
+					CallbackData: fraudConfirmCallback + id,
+				},
+				{
+					Text:         "✅ Dismiss",
+					CallbackData: fraudDismissCallback + id,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,193 @@
+package fraud
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/access"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/adaptor"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handler"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/markdown"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/fraud"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/groups"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/roles"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx/extractors"
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+const (
+	// Fraud command constants.
+	fraudCommand = "/fraud"
+
+	// Fraud callback constants.
+	fraudConfirmCallback = "fraud:confirm:"
+	fraudDismissCallback = "fraud:dismiss:"
+)
+
+// Handler lets group owners and managers review participants flagged as suspicious.
+type Handler struct {
+	handler.BaseHandler
+
+	accessSvc    *access.Service
+	fraudSvc     *fraud.Service
+	giveawaysSvc *giveaways.Service
+}
+
+func NewHandler(
+	bot *gotelegrambotfx.Bot,
+	accessSvc *access.Service,
+	fraudSvc *fraud.Service,
+	giveawaysSvc *giveaways.Service,
+	logger *zap.Logger,
+) handler.Handler {
+	return &Handler{
+		BaseHandler: handler.BaseHandler{
+			Bot:    bot,
+			Logger: logger,
+		},
+
+		accessSvc:    accessSvc,
+		fraudSvc:     fraudSvc,
+		giveawaysSvc: giveawaysSvc,
+	}
+}
+
+// Register implements handler.Handler.
+func (h *Handler) Register(b *gotelegrambotfx.Bot) {
+	b.RegisterHandlerMatchFunc(
+		func(update *models.Update) bool {
+			return update.Message != nil &&
+				update.Message.Text == fraudCommand &&
+				update.Message.Chat.Type == models.ChatTypePrivate
+		},
+		adaptor.New(h.handleFraudCommand),
+	)
+
+	b.RegisterHandlerMatchFunc(
+		func(update *models.Update) bool {
+			return update.CallbackQuery != nil &&
+				(strings.HasPrefix(update.CallbackQuery.Data, fraudConfirmCallback) ||
+					strings.HasPrefix(update.CallbackQuery.Data, fraudDismissCallback))
+		},
+		adaptor.New(h.handleReview),
+	)
+}
+
+func (h *Handler) handleFraudCommand(ctx *adaptor.Context, update *models.Update) {
+	user, err := ctx.User()
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	h.showNextFlag(ctx, extractors.ChatID(update), user.ID)
+}
+
+func (h *Handler) handleReview(ctx *adaptor.Context, update *models.Update) {
+	user, err := ctx.User()
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	data := update.CallbackQuery.Data
+	confirm := strings.HasPrefix(data, fraudConfirmCallback)
+	flagIDStr := strings.TrimPrefix(strings.TrimPrefix(data, fraudConfirmCallback), fraudDismissCallback)
+	flagID, err := strconv.ParseInt(flagIDStr, 10, 64)
+	if err != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to parse flag ID: %w", err))
+		return
+	}
+
+	flag, err := h.fraudSvc.GetByID(ctx, flagID)
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	if ok, accessErr := h.accessSvc.Can(ctx, flag.GroupID, user.ID, roles.PermReviewFraud); accessErr != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to check permission: %w", accessErr))
+		return
+	} else if !ok {
+		h.SendReply(ctx, update, &bot.SendMessageParams{
+			Text: "❌ You are not allowed to review participants of this group.",
+		})
+		return
+	}
+
+	if reviewErr := h.fraudSvc.Review(ctx, flagID, user.ID, confirm); reviewErr != nil {
+		h.HandleError(ctx, update, reviewErr)
+		return
+	}
+
+	text := "✅ Participant dismissed from suspicion."
+	if confirm {
+		text = "🚫 Participant excluded from the draw."
+	}
+	h.SendReply(ctx, update, &bot.SendMessageParams{Text: text})
+
+	h.showNextFlag(ctx, extractors.ChatID(update), user.ID)
+}
+
+func (h *Handler) showNextFlag(ctx context.Context, chatID int64, userID int64) {
+	grps, err := h.accessSvc.Groups(ctx, userID, roles.PermReviewFraud)
+	if err != nil {
+		h.Logger.Error("failed to get user groups", zap.Error(err))
+		h.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   "❌ Failed to verify access. Please try again.",
+		})
+		return
+	}
+
+	groupsByID := lo.KeyBy(grps, func(item groups.GroupWithSettings) int64 { return item.ID })
+
+	flags, err := h.giveawaysSvc.ListPendingFlags(ctx, lo.Keys(groupsByID))
+	if err != nil {
+		h.Logger.Error("failed to list pending flags", zap.Error(err))
+		h.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   "❌ Failed to load suspicious participants. Please try again.",
+		})
+		return
+	}
+
+	if len(flags) == 0 {
+		h.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   "🛡 No suspicious participants to review.",
+		})
+		return
+	}
+
+	flag := flags[0]
+	h.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text: fmt.Sprintf(
+			"🛡 *Suspicious participant* \\(1 of %d\\)\n\n"+
+				"📱 Group: %s\n"+
+				"🎁 Giveaway: \\#%d\n"+
+				"👤 User: %s\n"+
+				"📊 Score: %d/100\n"+
+				"🔎 Reasons: %s",
+			len(flags),
+			bot.EscapeMarkdown(groupsByID[flag.GroupID].Title),
+			flag.GiveawayID,
+			markdown.UserMention(flag.UserTelegramID, flag.UserUsername, flag.UserFirstName),
+			flag.Score,
+			bot.EscapeMarkdown(strings.Join(
+				lo.Map(flag.Reasons, func(item fraud.Reason, _ int) string { return item.String() }),
+				", ",
+			)),
+		),
+		ParseMode:   models.ParseModeMarkdown,
+		ReplyMarkup: reviewKeyboard(flag.ID),
+	})
+}
@@ -9,14 +9,18 @@ import (
 	"strings"
 	"time"
 
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/access"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/adaptor"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/filter"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handler"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/keyboards"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/middlewares/state"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/posts"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/wizard"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/fsm"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/groups"
-	"github.com/capcom6/lucky-pick-tg-bot/internal/users"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/roles"
 	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
@@ -25,406 +29,518 @@ import (
 )
 
 const (
-	// Giveaway state constants.
-	giveawayStatePrefix           = "giveaway:"
-	giveawayStateWaitGroup        = giveawayStatePrefix + "wait_group"
-	giveawayStateWaitPhoto        = giveawayStatePrefix + "wait_photo"
-	giveawayStateWaitPublishDate  = giveawayStatePrefix + "wait_publish_date"
-	giveawayStateWaitConfirmation = giveawayStatePrefix + "wait_confirmation"
-
-	// Command constants.
-	giveawayCommand = "/giveaway"
-	cancelCommand   = "/cancel"
-
-	giveawayCallbackGroup   = "giveaway:group:"
-	giveawayCallbackConfirm = "giveaway:confirm"
-	giveawayCallbackCancel  = "giveaway:cancel"
-
-	// Giveaway data constants.
-	giveawayDataGroupID             = "groupID"
-	giveawayDataPhotoID             = "photoID"
-	giveawayDataDescription         = "description"
-	giveawayDataOriginalDescription = "original_description"
-	giveawayDataPublishDate         = "publishDate"
-	giveawayDataApplicationEndDate  = "applicationEndDate"
-	giveawayDataResultsDate         = "resultsDate"
+	giveawayWizard = "giveaway"
+
+	giveawayChoiceAnonymous = "anonymous"
+	giveawayChoiceConfirm   = "confirm"
+	giveawayChoiceNoQuiz    = "none"
+
+	giveawayApplicationDuration = 24 * time.Hour
+	giveawayResultsDuration     = 26 * time.Hour
+
+	dateTimeLayout = "2006-01-02 15:04"
+
+	giveawayPromptPhoto       = "📸 Please send a photo with description caption for the giveaway."
+	giveawayPromptPublishDate = "⏰ Please specify the start time in format: YYYY-MM-DD HH:MM (e.g., 2023-12-25 14:30)"
+	giveawayPromptQuestion    = "❓ Please send the question participants must answer, e.g. How many candies are in a jar?"
 )
 
+// giveawayData is the giveaway being scheduled.
+type giveawayData struct {
+	// GiveawayID is the giveaway sent back for changes being revised, zero for a new one.
+	GiveawayID          int64     `json:"giveaway_id,omitempty"`
+	GroupID             int64     `json:"group_id"`
+	PhotoID             string    `json:"photo_id"`
+	OriginalDescription string    `json:"original_description"`
+	Description         string    `json:"description"`
+	PublishDate         time.Time `json:"publish_date"`
+	IsAnonymous         bool      `json:"is_anonymous"`
+	DrawStrategy        string    `json:"draw_strategy"`
+	DrawParam           int       `json:"draw_param"`
+	QuizMode            string    `json:"quiz_mode"`
+	QuizQuestion        string    `json:"quiz_question"`
+	QuizAnswers         []string  `json:"quiz_answers"`
+}
+
+// quiz returns the quiz of the giveaway, nil for a regular one.
+func (d *giveawayData) quiz() *giveaways.Quiz {
+	if d.QuizMode == "" {
+		return nil
+	}
+
+	return &giveaways.Quiz{
+		Question: d.QuizQuestion,
+		Mode:     giveaways.QuizMode(d.QuizMode),
+		Answers:  d.QuizAnswers,
+	}
+}
+
+// drawRules returns the chosen draw strategy, the default one for drafts started before the choice existed.
+func (d *giveawayData) drawRules() giveaways.DrawRules {
+	if d.DrawStrategy == "" {
+		return giveaways.DrawRules{Strategy: giveaways.DefaultDrawStrategy, Param: 0}
+	}
+
+	return giveaways.DrawRules{Strategy: d.DrawStrategy, Param: d.DrawParam}
+}
+
+func (d *giveawayData) applicationEndDate() time.Time {
+	return d.PublishDate.Add(giveawayApplicationDuration)
+}
+
+func (d *giveawayData) resultsDate() time.Time {
+	return d.PublishDate.Add(giveawayResultsDuration)
+}
+
+// NewGiveawayFlow keeps unfinished giveaways as drafts when they expire.
+func NewGiveawayFlow() fsm.Flow {
+	return wizard.FSMFlow(giveawayWizard, "giveaway")
+}
+
 // GiveawayScheduler handles giveaway scheduling flow.
 type GiveawayScheduler struct {
-	handler.BaseHandler
+	*wizard.Wizard[giveawayData]
 
-	fsmService *fsm.Service
-
-	usersSvc     *users.Service
 	groupsSvc    *groups.Service
+	accessSvc    *access.Service
 	giveawaysSvc *giveaways.Service
 }
 
 func NewGiveawayScheduler(
 	bot *gotelegrambotfx.Bot,
-	fsmService *fsm.Service,
-	usersSvc *users.Service,
 	groupsSvc *groups.Service,
+	accessSvc *access.Service,
 	giveawaysSvc *giveaways.Service,
 	logger *zap.Logger,
 ) handler.Handler {
-	return &GiveawayScheduler{
-		BaseHandler: handler.BaseHandler{
-			Bot:    bot,
-			Logger: logger,
-		},
+	g := &GiveawayScheduler{
+		Wizard: nil,
 
-		fsmService: fsmService,
-
-		usersSvc:     usersSvc,
 		groupsSvc:    groupsSvc,
+		accessSvc:    accessSvc,
 		giveawaysSvc: giveawaysSvc,
 	}
-}
-
-func (g *GiveawayScheduler) Register(b *gotelegrambotfx.Bot) {
-	// Register command handler
-	isEmptyState := state.NewStateFilter("", g.fsmService, g.Logger)
-	hasPrefixState := state.NewStatePrefixFilter(giveawayStatePrefix, g.fsmService, g.Logger)
-	commandFilter := func(command string) bot.MatchFunc {
-		return func(update *models.Update) bool {
-			if update.Message == nil {
-				return false
-			}
-			return update.Message.Text == command
-		}
-	}
-	combinator := func(filters ...bot.MatchFunc) bot.MatchFunc {
-		return func(update *models.Update) bool {
-			for _, f := range filters {
-				if !f(update) {
-					return false
-				}
-			}
-			return true
-		}
-	}
-
-	b.RegisterHandlerMatchFunc(
-		combinator(
-			isEmptyState,
-			commandFilter(giveawayCommand),
-		),
-		adaptor.New(g.handleGiveawayCommand),
-	)
 
-	b.RegisterHandlerMatchFunc(
-		combinator(
-			hasPrefixState,
-			commandFilter(cancelCommand),
-		),
-		g.handleCancelCommand,
-	)
-
-	b.RegisterHandlerMatchFunc(
-		combinator(
-			state.NewStateFilter(giveawayStateWaitGroup, g.fsmService, g.Logger),
-			func(update *models.Update) bool {
-				return update.CallbackQuery != nil &&
-					strings.HasPrefix(update.CallbackQuery.Data, giveawayCallbackGroup)
+	//nolint:exhaustruct // steps declare only the hooks they need
+	g.Wizard = wizard.New(bot, wizard.Flow[giveawayData]{
+		Name:    giveawayWizard,
+		Command: "giveaway",
+		Start:   g.start,
+		Steps: []wizard.Step[giveawayData]{
+			{
+				Name:     "group",
+				Prompt:   g.promptGroup,
+				Auto:     g.autoGroup,
+				OnChoice: g.chooseGroup,
 			},
-		),
-		g.handleGiveawayGroup,
-	)
-
-	b.RegisterHandlerMatchFunc(
-		combinator(
-			state.NewStateFilter(giveawayStateWaitPhoto, g.fsmService, g.Logger),
-			func(update *models.Update) bool {
-				return update.Message != nil
+			{
+				Name:    "photo",
+				Prompt:  wizard.Static[giveawayData](giveawayPromptPhoto),
+				OnPhoto: g.setPhoto,
 			},
-		),
-		g.handlePhotoAndDescription,
-	)
-
-	b.RegisterHandlerMatchFunc(
-		combinator(
-			state.NewStateFilter(giveawayStateWaitPublishDate, g.fsmService, g.Logger),
-			func(update *models.Update) bool {
-				return update.Message != nil
+			{
+				Name:   "publish_date",
+				Prompt: wizard.Static[giveawayData](giveawayPromptPublishDate),
+				OnText: wizard.Text(
+					wizard.Time(dateTimeLayout, giveawayPromptPublishDate),
+					func(d *giveawayData, t time.Time) { d.PublishDate = t },
+				),
+				After: g.prepareDescription,
 			},
-		),
-		g.handlePublishDate,
-	)
-
-	// Add callback query handlers after the cancel command registration
-	b.RegisterHandlerMatchFunc(
-		combinator(
-			hasPrefixState,
-			func(update *models.Update) bool {
-				return update.CallbackQuery != nil &&
-					update.CallbackQuery.Data == giveawayCallbackConfirm
+			{
+				Name:     "quiz",
+				Prompt:   g.promptQuiz,
+				OnChoice: g.chooseQuiz,
 			},
-		),
-		adaptor.New(g.handleConfirmation),
-	)
+			{
+				Name:   "quiz_question",
+				Prompt: wizard.Static[giveawayData](giveawayPromptQuestion),
+				Auto:   skipUnlessQuiz,
+				OnText: wizard.Text(
+					wizard.String,
+					func(d *giveawayData, v string) { d.QuizQuestion = v },
+					wizard.MaxLength(giveaways.MaxQuizQuestionLength),
+				),
+			},
+			{
+				Name:   "quiz_answers",
+				Prompt: promptQuizAnswers,
+				Auto:   skipUnlessQuiz,
+				OnText: setQuizAnswers,
+			},
+			{
+				Name:     "draw",
+				Prompt:   g.promptDraw,
+				Auto:     g.autoDraw,
+				OnChoice: g.chooseDraw,
+			},
+			{
+				Name:   "draw_param",
+				Prompt: g.promptDrawParam,
+				Auto:   g.autoDrawParam,
+				OnText: g.setDrawParam,
+			},
+			{
+				Name:     "confirmation",
+				Prompt:   g.promptPreview,
+				OnChoice: g.choosePreview,
+			},
+		},
+		Finish: g.finish,
+	}, logger)
+
+	return g
+}
+
+// Register implements handler.Handler.
+func (g *GiveawayScheduler) Register(b *gotelegrambotfx.Bot) {
+	g.Wizard.Register(b)
 
 	b.RegisterHandlerMatchFunc(
-		combinator(
-			hasPrefixState,
+		filter.And(
+			state.NewStateFilter(""),
 			func(update *models.Update) bool {
-				return update.CallbackQuery != nil &&
-					update.CallbackQuery.Data == giveawayCallbackCancel
+				return update.CallbackQuery != nil && strings.HasPrefix(update.CallbackQuery.Data, keyboards.RevisePrefix)
 			},
 		),
-		g.handleCancelCommand,
+		adaptor.New(g.handleRevise),
 	)
 }
 
-func (g *GiveawayScheduler) handleGiveawayCommand(ctx *adaptor.Context, update *models.Update) {
-	if update.Message == nil || update.Message.From == nil {
-		g.WithContext(update).Error("invalid update: missing message or sender")
+// handleRevise starts the wizard over the giveaway sent back for changes, prefilled with its current content.
+func (g *GiveawayScheduler) handleRevise(ctx *adaptor.Context, update *models.Update) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(update.CallbackQuery.Data, keyboards.RevisePrefix), 10, 64)
+	if err != nil {
+		g.HandleError(ctx, update, fmt.Errorf("failed to parse giveaway ID: %w", err))
 		return
 	}
 
-	// Ensure this is not in a group chat
-	if update.Message.Chat.Type != models.ChatTypePrivate {
-		g.SendReply(
-			ctx,
-			update,
-			&bot.SendMessageParams{Text: "❌ Giveaway scheduling is only available in private chats."},
-		)
+	user, err := ctx.User()
+	if err != nil {
+		g.HandleError(ctx, update, err)
 		return
 	}
 
-	logger := g.WithContext(update)
+	giveaway, err := g.giveawaysSvc.GetByID(ctx, id)
+	if err != nil {
+		g.HandleError(ctx, update, err)
+		return
+	}
+
+	if giveaway.AdminUserID != user.ID || giveaway.Status != giveaways.StatusChangesRequested {
+		g.SendReply(ctx, update, &bot.SendMessageParams{Text: "ℹ️ This giveaway can't be revised anymore."})
+		return
+	}
+
+	data := giveawayData{
+		GiveawayID:          giveaway.ID,
+		GroupID:             giveaway.GroupID,
+		PhotoID:             giveaway.PhotoFileID,
+		OriginalDescription: giveaway.Description,
+		Description:         giveaway.Description,
+		PublishDate:         giveaway.PublishDate,
+		IsAnonymous:         giveaway.IsAnonymous,
+		DrawStrategy:        giveaway.Draw.Strategy,
+		DrawParam:           giveaway.Draw.Param,
+		QuizMode:            "",
+		QuizQuestion:        "",
+		QuizAnswers:         nil,
+	}
+	if quiz := giveaway.Quiz; quiz != nil {
+		data.QuizMode = string(quiz.Mode)
+		data.QuizQuestion = quiz.Question
+		data.QuizAnswers = quiz.Answers
+	}
+
+	g.Begin(ctx, update, data)
+}
+
+func (g *GiveawayScheduler) start(ctx *adaptor.Context, _ *giveawayData) error {
+	grps, err := g.managedGroups(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(grps) == 0 {
+		return wizard.Invalid("❌ You must be an owner, manager or editor of a group to create giveaways.")
+	}
 
-	// Register or get user
+	return nil
+}
+
+func (g *GiveawayScheduler) managedGroups(ctx *adaptor.Context) ([]groups.GroupWithSettings, error) {
 	user, err := ctx.User()
 	if err != nil {
-		logger.Error("failed to register user", zap.Error(err))
-		g.SendReply(ctx, update, &bot.SendMessageParams{Text: "❌ Failed to process user. Please try again."})
-		return
+		return nil, err
 	}
 
-	// Check if user is admin of any groups
-	adminGroups, err := g.groupsSvc.GetUserAdminGroups(ctx, user.ID)
+	grps, err := g.accessSvc.Groups(ctx, user.ID, roles.PermDraftGiveaway)
 	if err != nil {
-		logger.Error("failed to get user admin groups", zap.Error(err))
-		g.SendReply(ctx, update, &bot.SendMessageParams{Text: "❌ Failed to verify admin status. Please try again."})
-		return
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
 	}
 
-	if len(adminGroups) == 0 {
-		g.SendReply(
-			ctx,
-			update,
-			&bot.SendMessageParams{Text: "❌ You must be an admin of a group to schedule giveaways."},
-		)
-		return
+	return grps, nil
+}
+
+// autoGroup selects the group when the user manages exactly one. The group of a revised giveaway is kept.
+func (g *GiveawayScheduler) autoGroup(ctx *adaptor.Context, data *giveawayData) (bool, error) {
+	if data.GiveawayID != 0 {
+		return true, nil
 	}
 
-	state, err := state.FromContext(ctx)
+	grps, err := g.managedGroups(ctx)
 	if err != nil {
-		g.HandleError(ctx, update, fmt.Errorf("failed to get state: %w", err))
-		return
+		return false, err
 	}
 
-	// If user is admin of exactly one group, auto-select it
-	if len(adminGroups) == 1 {
-		state.SetName(giveawayStateWaitPhoto)
-		state.AddData(giveawayDataGroupID, strconv.FormatInt(adminGroups[0].ID, 10))
+	if len(grps) != 1 {
+		return false, nil
+	}
 
-		g.SendReply(
-			ctx,
-			update,
-			&bot.SendMessageParams{Text: "📸 Please send a photo with description caption for the giveaway."},
-		)
-		return
+	data.GroupID = grps[0].ID
+	return true, nil
+}
+
+func (g *GiveawayScheduler) promptGroup(ctx *adaptor.Context, _ *giveawayData) (wizard.Prompt, error) {
+	grps, err := g.managedGroups(ctx)
+	if err != nil {
+		return wizard.Prompt{}, err //nolint:exhaustruct // failed prompt
 	}
 
-	// Show group selection keyboard
-	state.SetName(giveawayStateWaitGroup)
-	g.showGroupSelectionKeyboard(ctx, update.Message.Chat.ID, adminGroups)
+	return wizard.Prompt{
+		Text:      "👥 Select a group for the giveaway:",
+		ParseMode: "",
+		PhotoID:   "",
+		Options: lo.Map(grps, func(group groups.GroupWithSettings, _ int) []wizard.Option {
+			return []wizard.Option{{Text: group.Title, Value: strconv.FormatInt(group.ID, 10)}}
+		}),
+	}, nil
 }
 
-func (g *GiveawayScheduler) showGroupSelectionKeyboard(
-	ctx context.Context,
-	chatID int64,
-	groups []groups.GroupWithSettings,
-) {
-	markup := keyboards.GroupSelectionKeyboard(
-		giveawayCallbackGroup,
-		groups,
-	)
+func (g *GiveawayScheduler) chooseGroup(
+	_ *adaptor.Context,
+	value string,
+	data *giveawayData,
+) (wizard.Transition, error) {
+	groupID, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return wizard.Stay, fmt.Errorf("failed to parse group ID: %w", err)
+	}
 
-	g.SendMessage(
-		ctx,
-		&bot.SendMessageParams{
-			ChatID:      chatID,
-			Text:        "👥 Select a group for the giveaway:",
-			ParseMode:   models.ParseModeMarkdown,
-			ReplyMarkup: markup,
-		},
-	)
+	data.GroupID = groupID
+	return wizard.Next, nil
 }
 
-func (g *GiveawayScheduler) handleGiveawayGroup(ctx context.Context, _ *bot.Bot, update *models.Update) {
-	if update.CallbackQuery == nil {
-		return
+func (g *GiveawayScheduler) setPhoto(_ *adaptor.Context, photo wizard.Photo, data *giveawayData) error {
+	if photo.Caption == "" {
+		return wizard.Invalid("❌ Please send a photo with description caption.")
+	}
+
+	data.PhotoID = photo.FileID
+	data.OriginalDescription = photo.Caption
+	return nil
+}
+
+// prepareDescription fills the final description, generating it with the LLM when enabled for the group.
+func (g *GiveawayScheduler) prepareDescription(ctx *adaptor.Context, data *giveawayData) error {
+	_, settings, err := g.loadGroupAndSettings(ctx, data.GroupID)
+	if err != nil {
+		g.Logger.Error("failed to load group and settings", zap.Error(err))
+		return wizard.Invalid("❌ Failed to load group and settings. Please try again.")
+	}
+
+	data.Description = data.OriginalDescription
+	if !settings.LLMDescription {
+		return nil
 	}
 
-	logger := g.WithContext(update)
-	state, err := state.FromContext(ctx)
+	photo, err := g.downloadPhoto(ctx, data.PhotoID)
 	if err != nil {
-		logger.Error("failed to get state", zap.Error(err))
-		g.HandleError(ctx, update, err)
-		return
+		g.Logger.Error("failed to download photo", zap.Error(err))
+		return wizard.Invalid("❌ Failed to download photo. Please try again.")
 	}
 
-	// Parse group ID from callback data
-	groupIDStr := strings.TrimPrefix(update.CallbackQuery.Data, giveawayCallbackGroup)
-	groupID, err := strconv.ParseInt(groupIDStr, 10, 64)
+	description, err := g.giveawaysSvc.GenerateDescription(ctx, data.OriginalDescription, data.PublishDate, photo)
 	if err != nil {
-		logger.Error("failed to parse group ID", zap.Error(err))
-		g.HandleError(ctx, update, err)
-		return
+		g.Logger.Error("failed to generate description", zap.Error(err))
+		return wizard.Invalid("❌ Failed to generate description. Please try again.")
 	}
 
-	state.SetName(giveawayStateWaitPhoto)
-	state.AddData(giveawayDataGroupID, strconv.FormatInt(groupID, 10))
+	data.Description = description
+	return nil
+}
 
-	g.SendReply(
-		ctx,
-		update,
-		&bot.SendMessageParams{Text: "📸 Please send a photo with description caption for the giveaway."},
-	)
+func (g *GiveawayScheduler) promptQuiz(_ *adaptor.Context, _ *giveawayData) (wizard.Prompt, error) {
+	options := [][]wizard.Option{{{Text: "🎟 No, a regular giveaway", Value: giveawayChoiceNoQuiz}}}
+	for _, mode := range giveaways.QuizModes() {
+		options = append(options, []wizard.Option{{Text: mode.Label(), Value: string(mode)}})
+	}
+
+	return wizard.Prompt{
+		Text:      "🧠 Should participants answer a question to enter? The winner is drawn among the correct answers.",
+		ParseMode: "",
+		PhotoID:   "",
+		Options:   options,
+	}, nil
+}
+
+func (g *GiveawayScheduler) chooseQuiz(
+	_ *adaptor.Context,
+	value string,
+	data *giveawayData,
+) (wizard.Transition, error) {
+	if value == giveawayChoiceNoQuiz {
+		data.QuizMode = ""
+		return wizard.Next, nil
+	}
+
+	if !lo.Contains(giveaways.QuizModes(), giveaways.QuizMode(value)) {
+		return wizard.Stay, fmt.Errorf("%w: unknown mode %q", giveaways.ErrInvalidQuiz, value)
+	}
+
+	if data.QuizMode != value {
+		data.QuizAnswers = nil
+	}
+	data.QuizMode = value
+	return wizard.Next, nil
+}
+
+// skipUnlessQuiz skips the quiz steps of regular giveaways.
+func skipUnlessQuiz(_ *adaptor.Context, data *giveawayData) (bool, error) {
+	return data.QuizMode == "", nil
 }
 
-func (g *GiveawayScheduler) handlePhotoAndDescription(ctx context.Context, _ *bot.Bot, update *models.Update) {
-	logger := g.WithContext(update)
+func promptQuizAnswers(_ *adaptor.Context, data *giveawayData) (wizard.Prompt, error) {
+	return wizard.Static[giveawayData](quizAnswersHint(giveaways.QuizMode(data.QuizMode)))(nil, data)
+}
 
-	state, err := state.FromContext(ctx)
+func setQuizAnswers(_ *adaptor.Context, text string, data *giveawayData) error {
+	mode := giveaways.QuizMode(data.QuizMode)
+
+	answers, err := giveaways.ParseQuizAnswers(mode, text)
 	if err != nil {
-		logger.Error("failed to get state", zap.Error(err))
-		g.HandleError(ctx, update, err)
-		return
+		return wizard.Invalid("❌ " + quizAnswersHint(mode))
 	}
 
-	if len(update.Message.Photo) == 0 || update.Message.Caption == "" {
-		g.SendReply(ctx, update, &bot.SendMessageParams{Text: "❌ Please send a photo with description caption."})
-		return
+	data.QuizAnswers = answers
+	return nil
+}
+
+func quizAnswersHint(mode giveaways.QuizMode) string {
+	switch mode {
+	case giveaways.QuizExact:
+		return "✅ Please send the accepted answers, one per line. Answers must match exactly."
+	case giveaways.QuizCaseInsensitive:
+		return "✅ Please send the accepted answers, one per line. The case of letters is ignored."
+	case giveaways.QuizClosest:
+		return "🎯 Please send the correct number. Participants with the closest guesses win."
+	case giveaways.QuizRange:
+		return "📏 Please send the lower and upper bounds separated by a space, e.g. 100 150."
 	}
 
-	photo := lo.MaxBy(
-		update.Message.Photo,
-		func(a, b models.PhotoSize) bool {
-			return a.FileSize > b.FileSize
-		},
-	)
+	return "✅ Please send the accepted answers."
+}
 
-	state.SetName(giveawayStateWaitPublishDate)
-	state.AddData(giveawayDataPhotoID, photo.FileID)
-	state.AddData(giveawayDataOriginalDescription, update.Message.Caption)
+// autoDraw selects the strategy when there is no choice.
+func (g *GiveawayScheduler) autoDraw(_ *adaptor.Context, data *giveawayData) (bool, error) {
+	strategies := g.giveawaysSvc.DrawStrategies()
+	if len(strategies) != 1 {
+		return false, nil
+	}
 
-	// Request start time
-	g.SendReply(
-		ctx,
-		update,
-		&bot.SendMessageParams{
-			Text: "⏰ Please specify the start time in format: YYYY-MM-DD HH:MM (e.g., 2023-12-25 14:30)",
-		},
-	)
+	data.DrawStrategy = strategies[0].Name()
+	return true, nil
 }
 
-func (g *GiveawayScheduler) handlePublishDate(ctx context.Context, _ *bot.Bot, update *models.Update) {
-	logger := g.WithContext(update)
+func (g *GiveawayScheduler) promptDraw(_ *adaptor.Context, _ *giveawayData) (wizard.Prompt, error) {
+	return wizard.Prompt{
+		Text:      "🎰 How should the winner be selected?",
+		ParseMode: "",
+		PhotoID:   "",
+		Options: lo.Map(g.giveawaysSvc.DrawStrategies(), func(strategy giveaways.DrawStrategy, _ int) []wizard.Option {
+			return []wizard.Option{{Text: strategy.Label(), Value: strategy.Name()}}
+		}),
+	}, nil
+}
 
-	state, err := state.FromContext(ctx)
-	if err != nil {
-		logger.Error("failed to get state", zap.Error(err))
-		g.HandleError(ctx, update, err)
-		return
+func (g *GiveawayScheduler) chooseDraw(
+	_ *adaptor.Context,
+	value string,
+	data *giveawayData,
+) (wizard.Transition, error) {
+	if _, err := g.giveawaysSvc.DrawStrategy(value); err != nil {
+		return wizard.Stay, err
 	}
 
-	if update.Message == nil || update.Message.Text == "" {
-		g.SendReply(
-			ctx,
-			update,
-			&bot.SendMessageParams{
-				Text: "⏰ Please specify the start time in format: YYYY-MM-DD HH:MM (e.g., 2023-12-25 14:30)",
-			},
-		)
-		return
+	data.DrawStrategy = value
+	data.DrawParam = 0
+	return wizard.Next, nil
+}
+
+// autoDrawParam skips the parameter of strategies without one.
+func (g *GiveawayScheduler) autoDrawParam(_ *adaptor.Context, data *giveawayData) (bool, error) {
+	strategy, err := g.giveawaysSvc.DrawStrategy(data.drawRules().Strategy)
+	if err != nil {
+		return false, err
 	}
 
-	startTime, err := parseDateTime(update.Message.Text)
+	return strategy.Param() == nil, nil
+}
+
+func (g *GiveawayScheduler) promptDrawParam(_ *adaptor.Context, data *giveawayData) (wizard.Prompt, error) {
+	param, err := g.drawParam(data)
 	if err != nil {
-		g.SendReply(
-			ctx,
-			update,
-			&bot.SendMessageParams{
-				Text: "⏰ Please specify the start time in format: YYYY-MM-DD HH:MM (e.g., 2023-12-25 14:30)",
-			},
-		)
-		return
+		return wizard.Prompt{}, err //nolint:exhaustruct // failed prompt
 	}
 
-	const duration = 24 * time.Hour
-	const resultsDuration = 26 * time.Hour
+	return wizard.Static[giveawayData](
+		fmt.Sprintf("🔢 %s? Send a number from %d to %d.", param.Label, param.Min, param.Max),
+	)(nil, data)
+}
 
-	state.SetName(giveawayStateWaitConfirmation)
-	state.AddData(giveawayDataPublishDate, formatDateTime(startTime))
-	state.AddData(giveawayDataApplicationEndDate, formatDateTime(startTime.Add(duration)))
-	state.AddData(giveawayDataResultsDate, formatDateTime(startTime.Add(resultsDuration)))
+func (g *GiveawayScheduler) setDrawParam(ctx *adaptor.Context, text string, data *giveawayData) error {
+	param, err := g.drawParam(data)
+	if err != nil {
+		return err
+	}
 
-	g.showPreviewAndConfirmation(ctx, update.Message.Chat.ID, state)
+	return wizard.Text(
+		wizard.Int(fmt.Sprintf("❌ Please send a number from %d to %d.", param.Min, param.Max)),
+		func(d *giveawayData, v int) { d.DrawParam = v },
+		wizard.Range(param.Min, param.Max),
+	)(ctx, text, data)
 }
 
-func (g *GiveawayScheduler) showPreviewAndConfirmation(ctx context.Context, chatID int64, state *fsm.State) {
-	group, settings, err := g.loadGroupAndSettings(ctx)
+func (g *GiveawayScheduler) drawParam(data *giveawayData) (*giveaways.DrawParam, error) {
+	strategy, err := g.giveawaysSvc.DrawStrategy(data.drawRules().Strategy)
 	if err != nil {
-		g.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   "❌ Failed to load group and settings. Please try again.",
-		})
-		return
+		return nil, fmt.Errorf("failed to get draw strategy: %w", err)
 	}
 
-	state.AddData(giveawayDataDescription, state.GetData(giveawayDataOriginalDescription))
-	if settings.LLMDescription {
-		photo, downErr := g.downloadPhoto(ctx, state.GetData(giveawayDataPhotoID))
-		if downErr != nil {
-			g.Logger.Error("failed to download photo", zap.Error(downErr))
-			g.SendMessage(ctx, &bot.SendMessageParams{
-				ChatID: chatID,
-				Text:   "❌ Failed to download photo. Please try again.",
-			})
-			return
-		}
+	param := strategy.Param()
+	if param == nil {
+		return nil, fmt.Errorf("%w: %s has no parameter", giveaways.ErrInvalidDrawParam, strategy.Name())
+	}
 
-		publishDate, downErr := parseDateTime(state.GetData(giveawayDataPublishDate))
-		if downErr != nil {
-			g.Logger.Error("failed to parse publish date", zap.Error(downErr))
-			g.SendMessage(ctx, &bot.SendMessageParams{
-				ChatID: chatID,
-				Text:   "❌ Failed to parse publish date. Please try again.",
-			})
-			return
-		}
+	return param, nil
+}
 
-		description, downErr := g.giveawaysSvc.GenerateDescription(
-			ctx,
-			state.GetData(giveawayDataOriginalDescription),
-			publishDate,
-			photo,
-		)
-		if downErr != nil {
-			g.Logger.Error("failed to generate description", zap.Error(downErr))
-			g.SendMessage(ctx, &bot.SendMessageParams{
-				ChatID: chatID,
-				Text:   "❌ Failed to generate description. Please try again.",
-			})
-			return
-		}
+func (g *GiveawayScheduler) promptPreview(ctx *adaptor.Context, data *giveawayData) (wizard.Prompt, error) {
+	group, settings, err := g.loadGroupAndSettings(ctx, data.GroupID)
+	if err != nil {
+		return wizard.Prompt{}, err //nolint:exhaustruct // failed prompt
+	}
 
-		state.AddData(giveawayDataDescription, description)
+	anonymousText := "No"
+	anonymousButton := "🕶 Make anonymous"
+	if data.IsAnonymous {
+		anonymousText = "Yes, the organizer, participants count and winner are hidden"
+		anonymousButton = "👤 Make public"
+	}
+
+	ticketRules := "none"
+	if rules := posts.FormatTicketRules(settings.TicketRules); rules != "" {
+		ticketRules = "\n" + rules
 	}
 
 	previewText := fmt.Sprintf(`🎯 *Preview*
@@ -433,142 +549,122 @@ func (g *GiveawayScheduler) showPreviewAndConfirmation(ctx context.Context, chat
 📝 Description: %s
 ⏰ Start time: %s
 📝 Application end: %s
-🎉 Results: %s`,
+🎉 Results: %s
+🕶 Anonymous: %s
+🎟 Bonus tickets: %s
+🎰 Draw: %s
+🧠 Quiz: %s`,
 		bot.EscapeMarkdown(group.Title),
-		bot.EscapeMarkdown(state.GetData(giveawayDataDescription)),
-		bot.EscapeMarkdown(state.GetData(giveawayDataPublishDate)),
-		bot.EscapeMarkdown(state.GetData(giveawayDataApplicationEndDate)),
-		bot.EscapeMarkdown(state.GetData(giveawayDataResultsDate)),
+		bot.EscapeMarkdown(data.Description),
+		bot.EscapeMarkdown(formatDateTime(data.PublishDate)),
+		bot.EscapeMarkdown(formatDateTime(data.applicationEndDate())),
+		bot.EscapeMarkdown(formatDateTime(data.resultsDate())),
+		bot.EscapeMarkdown(anonymousText),
+		ticketRules,
+		bot.EscapeMarkdown(g.formatDraw(data.drawRules())),
+		bot.EscapeMarkdown(formatQuiz(data.quiz())),
 	)
 
-	markup := &models.InlineKeyboardMarkup{
-		InlineKeyboard: [][]models.InlineKeyboardButton{
-			{
-				{
-					Text:         "✅ Confirm",
-					CallbackData: giveawayCallbackConfirm,
-				},
-				{
-					Text:         "❌ Cancel",
-					CallbackData: giveawayCallbackCancel,
-				},
-			},
+	return wizard.Prompt{
+		Text:      previewText,
+		ParseMode: models.ParseModeMarkdown,
+		PhotoID:   data.PhotoID,
+		Options: [][]wizard.Option{
+			{{Text: anonymousButton, Value: giveawayChoiceAnonymous}},
+			{{Text: "✅ Confirm", Value: giveawayChoiceConfirm}},
 		},
-	}
-
-	_, err = g.Bot.SendPhoto(ctx, &bot.SendPhotoParams{
-		ChatID:      chatID,
-		Photo:       &models.InputFileString{Data: state.GetData(giveawayDataPhotoID)},
-		Caption:     previewText,
-		ParseMode:   models.ParseModeMarkdown,
-		ReplyMarkup: markup,
-	})
-	if err != nil {
-		g.Logger.Error("failed to send message with keyboard", zap.Error(err))
-	}
+	}, nil
 }
 
-func (g *GiveawayScheduler) handleConfirmation(ctx *adaptor.Context, update *models.Update) {
-	logger := g.WithContext(update)
-
-	user, err := ctx.User()
-	if err != nil {
-		logger.Error("failed to register user", zap.Error(err))
-		g.HandleError(ctx, update, err)
-		return
+func (g *GiveawayScheduler) choosePreview(
+	_ *adaptor.Context,
+	value string,
+	data *giveawayData,
+) (wizard.Transition, error) {
+	if value == giveawayChoiceAnonymous {
+		data.IsAnonymous = !data.IsAnonymous
+		return wizard.Stay, nil
 	}
 
-	state, err := state.FromContext(ctx)
-	if err != nil {
-		logger.Error("failed to get state", zap.Error(err))
-		g.HandleError(ctx, update, err)
-		return
-	}
+	return wizard.Next, nil
+}
 
-	groupID, err := strconv.ParseInt(state.GetData(giveawayDataGroupID), 10, 64)
+func (g *GiveawayScheduler) finish(ctx *adaptor.Context, update *models.Update, data *giveawayData) error {
+	user, err := ctx.User()
 	if err != nil {
-		logger.Error("failed to parse group ID", zap.Error(err))
-		g.HandleError(ctx, update, err)
-		return
+		return err
 	}
 
-	if ok, adminErr := g.groupsSvc.IsAdmin(ctx, groupID, user.ID); adminErr != nil {
-		g.HandleError(ctx, update, fmt.Errorf("failed to check if user is group admin: %w", adminErr))
-		return
+	if ok, accessErr := g.accessSvc.Verify(ctx, data.GroupID, user.ID, roles.PermDraftGiveaway); accessErr != nil {
+		return fmt.Errorf("failed to verify permission: %w", accessErr)
 	} else if !ok {
-		logger.Error("user is not group admin", zap.Int64("group_id", groupID), zap.Int64("user_id", user.ID))
-		g.SendReply(ctx, update, &bot.SendMessageParams{Text: "❌ You are not group admin."})
-		return
+		g.WithContext(ctx, update).
+			Warn("user can't create giveaways", zap.Int64("group_id", data.GroupID), zap.Int64("user_id", user.ID))
+		return wizard.Invalid("❌ You are not allowed to create giveaways in this group.")
 	}
 
-	publishDate, err := parseDateTime(state.GetData(giveawayDataPublishDate))
+	// Editors can't schedule giveaways themselves, their drafts wait for an owner or manager
+	canSchedule, err := g.accessSvc.Can(ctx, data.GroupID, user.ID, roles.PermScheduleGiveaway)
 	if err != nil {
-		logger.Error("failed to parse publish date", zap.Error(err))
-		g.HandleError(ctx, update, err)
-		return
+		return fmt.Errorf("failed to check permission: %w", err)
 	}
 
-	applicationEndDate, err := parseDateTime(state.GetData(giveawayDataApplicationEndDate))
-	if err != nil {
-		logger.Error("failed to parse application end date", zap.Error(err))
-		g.HandleError(ctx, update, err)
-		return
-	}
-	resultsDate, err := parseDateTime(state.GetData(giveawayDataResultsDate))
+	_, settings, err := g.loadGroupAndSettings(ctx, data.GroupID)
 	if err != nil {
-		logger.Error("failed to parse results date", zap.Error(err))
-		g.HandleError(ctx, update, err)
-		return
+		return err
 	}
 
-	if createErr := g.giveawaysSvc.Create(ctx, giveaways.GiveawayPrepared{
+	giveaway := giveaways.GiveawayPrepared{
 		GiveawayDraft: giveaways.GiveawayDraft{
-			GroupID:            groupID,
+			GroupID:            data.GroupID,
 			AdminUserID:        user.ID,
-			PhotoFileID:        state.GetData(giveawayDataPhotoID),
-			Description:        state.GetData(giveawayDataDescription),
-			PublishDate:        publishDate,
-			ApplicationEndDate: applicationEndDate,
-			ResultsDate:        resultsDate,
-			IsAnonymous:        false,
+			PhotoFileID:        data.PhotoID,
+			Description:        data.Description,
+			PublishDate:        data.PublishDate,
+			ApplicationEndDate: data.applicationEndDate(),
+			ResultsDate:        data.resultsDate(),
+			IsAnonymous:        data.IsAnonymous,
+			TicketRules:        settings.TicketRules,
+			Draw:               data.drawRules(),
+			Quiz:               data.quiz(),
 		},
-		OriginalDescription: state.GetData(giveawayDataOriginalDescription),
-	}); createErr != nil {
-		logger.Error("failed to create giveaway", zap.Error(createErr))
-		g.HandleError(ctx, update, createErr)
-		return
+		OriginalDescription: data.OriginalDescription,
 	}
 
-	state.Clear()
-	g.SendReply(ctx, update, &bot.SendMessageParams{Text: "✅ Giveaway scheduled successfully!"})
-}
-
-func (g *GiveawayScheduler) handleCancelCommand(ctx context.Context, _ *bot.Bot, update *models.Update) {
-	logger := g.WithContext(update)
+	if data.GiveawayID != 0 {
+		if submitErr := g.giveawaysSvc.Resubmit(ctx, data.GiveawayID, giveaway); submitErr != nil {
+			return fmt.Errorf("failed to resubmit giveaway: %w", submitErr)
+		}
 
-	state, err := state.FromContext(ctx)
-	if err != nil {
-		logger.Error("failed to get state", zap.Error(err))
-		g.HandleError(ctx, update, err)
-		return
+		g.SendReply(ctx, update, &bot.SendMessageParams{
+			Text: "📨 Revised giveaway submitted for approval. You'll be notified once it is reviewed.",
+		})
+		return nil
 	}
 
-	state.Clear()
-
-	g.SendReply(ctx, update, &bot.SendMessageParams{Text: "🔄 Operation cancelled."})
-}
+	if !canSchedule {
+		if submitErr := g.giveawaysSvc.Submit(ctx, giveaway); submitErr != nil {
+			return fmt.Errorf("failed to submit giveaway: %w", submitErr)
+		}
 
-func (g *GiveawayScheduler) loadGroupAndSettings(ctx context.Context) (*groups.Group, *giveaways.Settings, error) {
-	state, err := state.FromContext(ctx)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get state: %w", err)
+		g.SendReply(ctx, update, &bot.SendMessageParams{
+			Text: "📨 Giveaway submitted for approval. You'll be notified once it is reviewed.",
+		})
+		return nil
 	}
 
-	groupID, err := strconv.ParseInt(state.GetData(giveawayDataGroupID), 10, 64)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to parse group ID: %w", err)
+	if createErr := g.giveawaysSvc.Create(ctx, giveaway); createErr != nil {
+		return fmt.Errorf("failed to create giveaway: %w", createErr)
 	}
 
+	g.SendReply(ctx, update, &bot.SendMessageParams{Text: "✅ Giveaway scheduled successfully!"})
+	return nil
+}
+
+func (g *GiveawayScheduler) loadGroupAndSettings(
+	ctx context.Context,
+	groupID int64,
+) (*groups.Group, *giveaways.Settings, error) {
 	group, err := g.groupsSvc.GetByID(ctx, groupID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get group: %w", err)
@@ -620,16 +716,27 @@ func (g *GiveawayScheduler) downloadPhoto(ctx context.Context, fileID string) ([
 	return data, nil
 }
 
-func parseDateTime(timeStr string) (time.Time, error) {
-	// Parse time in YYYY-MM-DD HH:MM format
-	const layout = "2006-01-02 15:04"
-	t, err := time.Parse(layout, timeStr)
+func formatDateTime(t time.Time) string {
+	return t.Format(dateTimeLayout)
+}
+
+func (g *GiveawayScheduler) formatDraw(rules giveaways.DrawRules) string {
+	strategy, err := g.giveawaysSvc.DrawStrategy(rules.Strategy)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid time format: %w", err)
+		return rules.Strategy
+	}
+
+	if param := strategy.Param(); param != nil {
+		return fmt.Sprintf("%s (%s: %d)", strategy.Label(), param.Label, rules.Param)
 	}
-	return t, nil
+
+	return strategy.Label()
 }
 
-func formatDateTime(t time.Time) string {
-	return t.Format("2006-01-02 15:04")
+func formatQuiz(quiz *giveaways.Quiz) string {
+	if quiz == nil {
+		return "No"
+	}
+
+	return fmt.Sprintf("%s (%s: %s)", quiz.Question, quiz.Mode.Label(), strings.Join(quiz.Answers, " / "))
 }
@@ -1,7 +1,9 @@
 package groups
 
 import (
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handlers/roles"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handlers/settings"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handlers/webhooks"
 	"github.com/go-telegram/bot/models"
 )
 
@@ -15,6 +17,18 @@ func managementKeyboard(groupID int64) *models.InlineKeyboardMarkup {
 					CallbackData: settings.NewGroupSettingsData(groupID),
 				},
 			},
+			{
+				{
+					Text:         "🔗 Webhooks",
+					CallbackData: webhooks.NewGroupWebhooksData(groupID),
+				},
+			},
+			{
+				{
+					Text:         "👤 Roles",
+					CallbackData: roles.NewGroupRolesData(groupID),
+				},
+			},
 			{
 				{
 					Text:         "🔙 Back to Groups",
@@ -2,13 +2,17 @@ package groups
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"strings"
 
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/access"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/adaptor"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/admins"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handler"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/keyboards"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/groups"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/roles"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/users"
 	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
 	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx/extractors"
@@ -31,12 +35,16 @@ type Handler struct {
 
 	usersSvc  *users.Service
 	groupsSvc *groups.Service
+	adminsSvc *admins.Service
+	accessSvc *access.Service
 }
 
 func NewHandler(
 	bot *gotelegrambotfx.Bot,
 	usersSvc *users.Service,
 	groupsSvc *groups.Service,
+	adminsSvc *admins.Service,
+	accessSvc *access.Service,
 	logger *zap.Logger,
 ) handler.Handler {
 	return &Handler{
@@ -47,6 +55,8 @@ func NewHandler(
 
 		usersSvc:  usersSvc,
 		groupsSvc: groupsSvc,
+		adminsSvc: adminsSvc,
+		accessSvc: accessSvc,
 	}
 }
 
@@ -57,6 +67,14 @@ func (h *Handler) Register(b *gotelegrambotfx.Bot) {
 		h.handleChatMember,
 	)
 
+	// Keep group admins in sync
+	b.RegisterHandlerMatchFunc(
+		func(update *models.Update) bool {
+			return update.ChatMember != nil
+		},
+		h.handleAdminChange,
+	)
+
 	// Register command handler
 	b.RegisterHandlerMatchFunc(
 		h.filterGroupsCommand,
@@ -69,7 +87,7 @@ func (h *Handler) Register(b *gotelegrambotfx.Bot) {
 			return update.CallbackQuery != nil &&
 				strings.HasPrefix(update.CallbackQuery.Data, groupsSelectionCallback)
 		},
-		h.handleGroupSelection,
+		adaptor.New(h.handleGroupSelection),
 	)
 
 	b.RegisterHandlerMatchFunc(
@@ -113,12 +131,18 @@ func (h *Handler) handleChatMember(ctx context.Context, _ *bot.Bot, update *mode
 
 	switch update.MyChatMember.NewChatMember.Type {
 	case models.ChatMemberTypeOwner, models.ChatMemberTypeAdministrator:
-		if createErr := h.groupsSvc.CreateOrUpdate(
+		group, createErr := h.groupsSvc.CreateOrUpdate(
 			ctx,
 			groups.GroupDraft{TelegramID: update.MyChatMember.Chat.ID, Title: update.MyChatMember.Chat.Title},
-			groups.Admin{UserID: user.ID},
-		); createErr != nil {
+			groups.Admin{UserID: user.ID, Role: groups.RoleAdmin, Rights: groups.Rights{}},
+		)
+		if createErr != nil {
 			h.Logger.Error("failed to create or update group", zap.Error(createErr))
+			return
+		}
+
+		if syncErr := h.adminsSvc.Sync(ctx, group.Group); syncErr != nil {
+			h.Logger.Error("failed to sync group admins", zap.Error(syncErr))
 		}
 	case models.ChatMemberTypeMember,
 		models.ChatMemberTypeRestricted,
@@ -132,8 +156,18 @@ func (h *Handler) handleChatMember(ctx context.Context, _ *bot.Bot, update *mode
 	}
 }
 
+func (h *Handler) handleAdminChange(ctx context.Context, _ *bot.Bot, update *models.Update) {
+	if err := h.adminsSvc.Apply(ctx, update.ChatMember); err != nil {
+		h.Logger.Error(
+			"failed to apply chat member update",
+			zap.Int64("chat_id", update.ChatMember.Chat.ID),
+			zap.Error(err),
+		)
+	}
+}
+
 func (h *Handler) handleGroupsCommand(ctx *adaptor.Context, update *models.Update) {
-	logger := h.WithContext(update)
+	logger := h.WithContext(ctx, update)
 
 	// Register or get user
 	user, err := ctx.User()
@@ -147,30 +181,30 @@ func (h *Handler) handleGroupsCommand(ctx *adaptor.Context, update *models.Updat
 		return
 	}
 
-	// Check if user is admin of any groups
-	adminGroups, err := h.groupsSvc.GetUserAdminGroups(ctx, user.ID)
+	// Check if user has a role in any groups
+	grps, err := h.accessSvc.Groups(ctx, user.ID, roles.PermViewStats)
 	if err != nil {
-		logger.Error("failed to get user admin groups", zap.Error(err))
+		logger.Error("failed to get user groups", zap.Error(err))
 		h.SendReply(
 			ctx,
 			update,
-			&bot.SendMessageParams{Text: "❌ Failed to verify admin status. Please try again."},
+			&bot.SendMessageParams{Text: "❌ Failed to verify access. Please try again."},
 		)
 		return
 	}
 
-	if len(adminGroups) == 0 {
+	if len(grps) == 0 {
 		h.SendReply(
 			ctx,
 			update,
-			&bot.SendMessageParams{Text: "❌ You must be an admin of a group to manage group settings."},
+			&bot.SendMessageParams{Text: "❌ You must have a role in a group to manage it."},
 		)
 		return
 	}
 
-	// If user is admin of exactly one group, show its settings directly
-	if len(adminGroups) == 1 {
-		h.showGroupMenu(ctx, update, adminGroups[0].ID)
+	// If user has a role in exactly one group, show its menu directly
+	if len(grps) == 1 {
+		h.showGroupMenu(ctx, update, grps[0].ID)
 		return
 	}
 
@@ -180,7 +214,7 @@ func (h *Handler) handleGroupsCommand(ctx *adaptor.Context, update *models.Updat
 		logger.Error("failed to extract chat ID")
 		return
 	}
-	h.showGroupSelectionKeyboard(ctx, chatID, adminGroups)
+	h.showGroupSelectionKeyboard(ctx, chatID, grps)
 }
 
 func (h *Handler) showGroupSelectionKeyboard(ctx context.Context, chatID int64, groups []groups.GroupWithSettings) {
@@ -200,8 +234,8 @@ func (h *Handler) showGroupSelectionKeyboard(ctx context.Context, chatID int64,
 	)
 }
 
-func (h *Handler) handleGroupSelection(ctx context.Context, _ *bot.Bot, update *models.Update) {
-	logger := h.WithContext(update)
+func (h *Handler) handleGroupSelection(ctx *adaptor.Context, update *models.Update) {
+	logger := h.WithContext(ctx, update)
 
 	if update.CallbackQuery == nil {
 		return
@@ -214,6 +248,20 @@ func (h *Handler) handleGroupSelection(ctx context.Context, _ *bot.Bot, update *
 		return
 	}
 
+	user, err := ctx.User()
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	if ok, accessErr := h.accessSvc.Can(ctx, groupID, user.ID, roles.PermViewStats); accessErr != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to check permission: %w", accessErr))
+		return
+	} else if !ok {
+		h.SendReply(ctx, update, &bot.SendMessageParams{Text: "❌ You have no role in this group."})
+		return
+	}
+
 	h.showGroupMenu(ctx, update, groupID)
 }
 
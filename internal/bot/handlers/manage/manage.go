@@ -0,0 +1,198 @@
+package manage
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/access"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/adaptor"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handler"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/keyboards"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/groups"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/roles"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+// Handler lets owners and managers list open giveaways and cancel them.
+type Handler struct {
+	handler.BaseHandler
+
+	accessSvc    *access.Service
+	giveawaysSvc *giveaways.Service
+}
+
+func NewHandler(
+	bot *gotelegrambotfx.Bot,
+	accessSvc *access.Service,
+	giveawaysSvc *giveaways.Service,
+	logger *zap.Logger,
+) handler.Handler {
+	return &Handler{
+		BaseHandler: handler.BaseHandler{
+			Bot:    bot,
+			Logger: logger,
+		},
+
+		accessSvc:    accessSvc,
+		giveawaysSvc: giveawaysSvc,
+	}
+}
+
+// Register implements handler.Handler.
+func (h *Handler) Register(b *gotelegrambotfx.Bot) {
+	b.RegisterHandler(
+		bot.HandlerTypeMessageText,
+		"giveaways",
+		bot.MatchTypeCommandStartOnly,
+		adaptor.New(h.handleList),
+	)
+
+	b.RegisterHandlerMatchFunc(h.callbackFilter(keyboards.CancelGiveawayPrefix), adaptor.New(h.handleCancel))
+	b.RegisterHandlerMatchFunc(h.callbackFilter(keyboards.ConfirmCancelGiveawayPrefix), adaptor.New(h.handleConfirm))
+}
+
+func (h *Handler) callbackFilter(prefix string) bot.MatchFunc {
+	return func(update *models.Update) bool {
+		return update.CallbackQuery != nil && strings.HasPrefix(update.CallbackQuery.Data, prefix)
+	}
+}
+
+func (h *Handler) handleList(ctx *adaptor.Context, update *models.Update) {
+	if update.Message.Chat.Type != models.ChatTypePrivate {
+		h.SendReply(ctx, update, &bot.SendMessageParams{Text: "❌ Giveaways are only managed in private chats."})
+		return
+	}
+
+	user, err := ctx.User()
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	grps, err := h.accessSvc.Groups(ctx, user.ID, roles.PermCancelGiveaway)
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	if len(grps) == 0 {
+		h.SendReply(ctx, update, &bot.SendMessageParams{
+			Text: "❌ Only group owners and managers can manage giveaways.",
+		})
+		return
+	}
+
+	items, err := h.giveawaysSvc.ListOpen(ctx, lo.Map(grps, func(g groups.GroupWithSettings, _ int) int64 { return g.ID }))
+	if err != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to list giveaways: %w", err))
+		return
+	}
+
+	if len(items) == 0 {
+		h.SendReply(ctx, update, &bot.SendMessageParams{Text: "📭 There are no open giveaways."})
+		return
+	}
+
+	lines := make([]string, 0, len(items)+1)
+	lines = append(lines, "🎁 Open giveaways:\n")
+	rows := make([][]models.InlineKeyboardButton, 0, len(items))
+	for _, item := range items {
+		title := "#" + strconv.FormatInt(item.ID, 10)
+		lines = append(lines, fmt.Sprintf(
+			"%s in %s, %s, publish date %s",
+			title, item.Group.Title, item.Status, item.PublishDate.Format("2006-01-02 15:04"),
+		))
+		rows = append(rows, keyboards.CancelGiveawayRow(item.ID, title))
+	}
+
+	h.SendReply(ctx, update, &bot.SendMessageParams{
+		Text:        strings.Join(lines, "\n"),
+		ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: rows},
+	})
+}
+
+func (h *Handler) handleCancel(ctx *adaptor.Context, update *models.Update) {
+	giveaway, ok := h.giveaway(ctx, update, strings.TrimPrefix(update.CallbackQuery.Data, keyboards.CancelGiveawayPrefix))
+	if !ok {
+		return
+	}
+
+	h.SendReply(ctx, update, &bot.SendMessageParams{
+		Text: fmt.Sprintf(
+			"⚠️ Cancel giveaway #%d in %s? Participants will not get a winner.",
+			giveaway.ID, giveaway.Group.Title,
+		),
+		ReplyMarkup: keyboards.ConfirmCancelGiveawayKeyboard(giveaway.ID),
+	})
+}
+
+func (h *Handler) handleConfirm(ctx *adaptor.Context, update *models.Update) {
+	giveaway, ok := h.giveaway(
+		ctx,
+		update,
+		strings.TrimPrefix(update.CallbackQuery.Data, keyboards.ConfirmCancelGiveawayPrefix),
+	)
+	if !ok {
+		return
+	}
+
+	user, err := ctx.User()
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	err = h.giveawaysSvc.Cancel(ctx, giveaway.ID, user.ID)
+	if errors.Is(err, giveaways.ErrInvalidTransition) {
+		h.SendReply(ctx, update, &bot.SendMessageParams{Text: "ℹ️ This giveaway is already finished or cancelled."})
+		return
+	}
+	if err != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to cancel giveaway: %w", err))
+		return
+	}
+
+	h.SendReply(ctx, update, &bot.SendMessageParams{Text: fmt.Sprintf("🚫 Giveaway #%d cancelled.", giveaway.ID)})
+}
+
+// giveaway loads the giveaway by its ID and checks that the user may cancel giveaways of its group.
+func (h *Handler) giveaway(ctx *adaptor.Context, update *models.Update, idStr string) (*giveaways.Giveaway, bool) {
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to parse giveaway ID: %w", err))
+		return nil, false
+	}
+
+	giveaway, err := h.giveawaysSvc.GetByID(ctx, id)
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return nil, false
+	}
+
+	user, err := ctx.User()
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return nil, false
+	}
+
+	ok, err := h.accessSvc.Verify(ctx, giveaway.GroupID, user.ID, roles.PermCancelGiveaway)
+	if err != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to verify permission: %w", err))
+		return nil, false
+	}
+
+	if !ok {
+		h.SendReply(ctx, update, &bot.SendMessageParams{
+			Text: "❌ Only group owners and managers can cancel giveaways.",
+		})
+	}
+
+	return giveaway, ok
+}
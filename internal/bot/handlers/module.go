@@ -2,9 +2,16 @@ package handlers
 
 import (
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handler"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handlers/approval"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handlers/cancel"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handlers/drafts"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handlers/fraud"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handlers/groups"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handlers/manage"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handlers/roles"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handlers/settings"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handlers/stats"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handlers/webhooks"
 	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
 	"github.com/go-core-fx/logger"
 	"go.uber.org/fx"
@@ -15,11 +22,21 @@ func Module() fx.Option {
 		"handlers",
 		logger.WithNamedLogger("handlers"),
 		fx.Provide(fx.Annotate(NewStart, fx.ResultTags(`group:"handlers"`))),
+		fx.Provide(fx.Annotate(NewQuiz, fx.ResultTags(`group:"handlers"`))),
 		fx.Provide(fx.Annotate(NewParticipant, fx.ResultTags(`group:"handlers"`))),
+		fx.Provide(fx.Annotate(NewDiscussion, fx.ResultTags(`group:"handlers"`))),
 		fx.Provide(fx.Annotate(groups.NewHandler, fx.ResultTags(`group:"handlers"`))),
 		fx.Provide(fx.Annotate(NewGiveawayScheduler, fx.ResultTags(`group:"handlers"`))),
+		fx.Provide(fx.Annotate(NewGiveawayFlow, fx.ResultTags(`group:"flows"`))),
 		fx.Provide(fx.Annotate(settings.NewHandler, fx.ResultTags(`group:"handlers"`))),
 		fx.Provide(fx.Annotate(cancel.NewHandler, fx.ResultTags(`group:"handlers"`))),
+		fx.Provide(fx.Annotate(fraud.NewHandler, fx.ResultTags(`group:"handlers"`))),
+		fx.Provide(fx.Annotate(stats.NewHandler, fx.ResultTags(`group:"handlers"`))),
+		fx.Provide(fx.Annotate(webhooks.NewHandler, fx.ResultTags(`group:"handlers"`))),
+		fx.Provide(fx.Annotate(roles.NewHandler, fx.ResultTags(`group:"handlers"`))),
+		fx.Provide(fx.Annotate(approval.NewHandler, fx.ResultTags(`group:"handlers"`))),
+		fx.Provide(fx.Annotate(drafts.NewHandler, fx.ResultTags(`group:"handlers"`))),
+		fx.Provide(fx.Annotate(manage.NewHandler, fx.ResultTags(`group:"handlers"`))),
 		fx.Invoke(fx.Annotate(
 			func(handlers []handler.Handler, b *gotelegrambotfx.Bot) {
 				for _, handler := range handlers {
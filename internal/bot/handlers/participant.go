@@ -1,21 +1,24 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/adaptor"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handler"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/keyboards"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/users"
 	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx/extractors"
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
 	"go.uber.org/zap"
 )
 
-const participatePrefix = "participate:"
-
 type Participant struct {
 	handler.BaseHandler
 
@@ -42,6 +45,7 @@ func NewParticipant(
 
 func (p *Participant) Register(b *gotelegrambotfx.Bot) {
 	b.RegisterHandlerMatchFunc(p.filterParticipateCallback, adaptor.New(p.handleParticipate))
+	b.RegisterHandlerMatchFunc(p.filterChallengeCallback, adaptor.New(p.handleChallengeAnswer))
 }
 
 func (p *Participant) filterParticipateCallback(update *models.Update) bool {
@@ -49,7 +53,7 @@ func (p *Participant) filterParticipateCallback(update *models.Update) bool {
 		return false
 	}
 
-	if !strings.HasPrefix(update.CallbackQuery.Data, participatePrefix) {
+	if !strings.HasPrefix(update.CallbackQuery.Data, keyboards.ParticipatePrefix) {
 		return false
 	}
 
@@ -62,9 +66,9 @@ func (p *Participant) handleParticipate(ctx *adaptor.Context, update *models.Upd
 		return
 	}
 
-	logger := p.WithContext(update)
+	logger := p.WithContext(ctx, update)
 
-	alertText := "Ваша заявка принята!"
+	alertText := alertSomethingWrong
 
 	defer func() {
 		if _, err := p.Bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
@@ -78,7 +82,6 @@ func (p *Participant) handleParticipate(ctx *adaptor.Context, update *models.Upd
 
 	user, err := ctx.User()
 	if err != nil {
-		alertText = alertSomethingWrong
 		logger.Error("failed to get user", zap.Error(err))
 		return
 	}
@@ -86,19 +89,70 @@ func (p *Participant) handleParticipate(ctx *adaptor.Context, update *models.Upd
 	logger = logger.With(zap.Int64("user_id", user.ID))
 
 	// Extract giveaway ID from callback data
-	giveawayIDStr := strings.TrimPrefix(update.CallbackQuery.Data, participatePrefix)
+	giveawayIDStr := strings.TrimPrefix(update.CallbackQuery.Data, keyboards.ParticipatePrefix)
 	giveawayID, err := strconv.ParseInt(giveawayIDStr, 10, 64)
 	if err != nil {
-		alertText = alertSomethingWrong
 		logger.Error("failed to parse giveaway ID", zap.Error(err))
 		return
 	}
 
 	logger = logger.With(zap.Int64("giveaway_id", giveawayID))
 
-	if participateErr := p.giveawaysSvc.Participate(ctx, giveawayID, user.ID); participateErr != nil {
-		alertText = alertSomethingWrong
-		logger.Error("failed to participate in giveaway", zap.Error(participateErr))
+	giveaway, err := p.giveawaysSvc.GetByID(ctx, giveawayID)
+	if errors.Is(err, giveaways.ErrNotFound) {
+		alertText = alertNotFound
+		return
+	} else if err != nil {
+		logger.Error("failed to get giveaway", zap.Error(err))
+		return
+	}
+
+	// The button is only valid on the giveaway post in its own group
+	if msg := update.CallbackQuery.Message.Message; msg == nil || msg.Chat.ID != giveaway.Group.TelegramID {
+		logger.Warn("participation from foreign chat", zap.Int64("chat_id", extractors.ChatID(update)))
+		alertText = alertForeignChat
 		return
 	}
+
+	if text, ok := p.requireChallenge(ctx, giveaway, user); !ok {
+		alertText = text
+		return
+	}
+
+	alertText = p.participate(ctx, logger, giveawayID, user.ID)
+}
+
+// participate records the entry and returns the text to show to the user.
+func (p *Participant) participate(ctx context.Context, logger *zap.Logger, giveawayID, userID int64) string {
+	participation, err := p.giveawaysSvc.Participate(ctx, giveawayID, userID)
+	switch {
+	case errors.Is(err, giveaways.ErrNotFound):
+		return alertNotFound
+	case errors.Is(err, giveaways.ErrNotStarted):
+		return alertNotStarted
+	case errors.Is(err, giveaways.ErrClosed):
+		return alertClosed
+	case errors.Is(err, giveaways.ErrCancelled):
+		return alertCancelled
+	case errors.Is(err, giveaways.ErrQuizRequired):
+		return alertQuizRequired
+	case err != nil:
+		logger.Error("failed to participate in giveaway", zap.Error(err))
+		return alertSomethingWrong
+	}
+
+	return participationText("Ваша заявка принята!", participation)
+}
+
+// participationText appends the tickets of the participant to the confirmation text.
+func participationText(text string, participation *giveaways.Participation) string {
+	if participation.Participant.Tickets > 1 {
+		text += fmt.Sprintf("\n🎟 Ваших билетов: %d", participation.Participant.Tickets)
+	}
+
+	if participation.Giveaway.IsAnonymous {
+		text += fmt.Sprintf("\nВаш билет: №%s", participation.Participant.Ticket())
+	}
+
+	return text
 }
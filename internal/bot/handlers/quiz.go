@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/adaptor"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/filter"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handler"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/middlewares/state"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"go.uber.org/zap"
+)
+
+// quizState is the state prefix of a user answering the quiz: quiz:<giveaway ID>.
+const quizState = "quiz:"
+
+// Quiz accepts the answers to quiz giveaways in the private chat. Users open it by the deep link of the post,
+// see Start.startQuiz, and send the answer as the next message.
+type Quiz struct {
+	handler.BaseHandler
+
+	giveawaysSvc *giveaways.Service
+}
+
+func NewQuiz(bot *gotelegrambotfx.Bot, giveawaysSvc *giveaways.Service, logger *zap.Logger) handler.Handler {
+	return &Quiz{
+		BaseHandler: handler.BaseHandler{
+			Bot:    bot,
+			Logger: logger,
+		},
+
+		giveawaysSvc: giveawaysSvc,
+	}
+}
+
+func (q *Quiz) Register(b *gotelegrambotfx.Bot) {
+	b.RegisterHandlerMatchFunc(
+		filter.And(state.NewStatePrefixFilter(quizState), q.filterAnswer),
+		adaptor.New(q.handleAnswer),
+	)
+}
+
+// filterAnswer matches private messages except commands, so /cancel reaches its handler.
+func (q *Quiz) filterAnswer(update *models.Update) bool {
+	return update.Message != nil &&
+		update.Message.Chat.Type == models.ChatTypePrivate &&
+		!strings.HasPrefix(update.Message.Text, "/")
+}
+
+func (q *Quiz) handleAnswer(ctx *adaptor.Context, update *models.Update) {
+	logger := q.WithContext(ctx, update)
+
+	user, err := ctx.User()
+	if err != nil {
+		q.HandleError(ctx, update, err)
+		return
+	}
+
+	st, err := ctx.State()
+	if err != nil {
+		q.HandleError(ctx, update, err)
+		return
+	}
+
+	giveawayID, err := strconv.ParseInt(strings.TrimPrefix(st.Name, quizState), 10, 64)
+	if err != nil {
+		st.Clear()
+		q.HandleError(ctx, update, fmt.Errorf("failed to parse giveaway ID: %w", err))
+		return
+	}
+
+	logger = logger.With(zap.Int64("giveaway_id", giveawayID), zap.Int64("user_id", user.ID))
+
+	participation, err := q.giveawaysSvc.AnswerQuiz(ctx, giveawayID, user.ID, update.Message.Text)
+	if errors.Is(err, giveaways.ErrInvalidAnswer) {
+		q.SendReply(ctx, update, &bot.SendMessageParams{
+			Text: fmt.Sprintf(
+				"❌ Ответ не принят. Отправьте текст длиной до %d символов, а если вопрос о числе, то число.",
+				giveaways.MaxQuizAnswerLength,
+			),
+		})
+		return
+	}
+
+	st.Clear()
+
+	if text, ok := quizErrorText(err); !ok {
+		logger.Error("failed to answer quiz", zap.Error(err))
+		q.SendReply(ctx, update, &bot.SendMessageParams{Text: alertSomethingWrong})
+		return
+	} else if text != "" {
+		q.SendReply(ctx, update, &bot.SendMessageParams{Text: text})
+		return
+	}
+
+	q.SendReply(ctx, update, &bot.SendMessageParams{
+		Text: participationText(
+			"✅ Ответ принят, вы участвуете в розыгрыше! Правильный ответ будет объявлен вместе с итогами.",
+			participation,
+		),
+	})
+}
+
+// quizErrorText returns the text explaining the error to the user, empty for nil.
+// It returns false for unexpected errors.
+func quizErrorText(err error) (string, bool) {
+	switch {
+	case err == nil:
+		return "", true
+	case errors.Is(err, giveaways.ErrNotFound), errors.Is(err, giveaways.ErrNotQuiz):
+		return alertNotFound, true
+	case errors.Is(err, giveaways.ErrNotStarted):
+		return alertNotStarted, true
+	case errors.Is(err, giveaways.ErrClosed):
+		return alertClosed, true
+	case errors.Is(err, giveaways.ErrCancelled):
+		return alertCancelled, true
+	case errors.Is(err, giveaways.ErrAlreadyAnswered):
+		return alertAlreadyAnswered, true
+	}
+
+	return "", false
+}
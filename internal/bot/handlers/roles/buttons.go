@@ -0,0 +1,39 @@
+package roles
+
+import (
+	"fmt"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/roles"
+	"github.com/go-telegram/bot/models"
+)
+
+// member is a user with a granted role shown in the list.
+type member struct {
+	grant roles.Grant
+	name  string
+}
+
+// listKeyboard creates keyboard to revoke granted roles and invite users to new ones.
+func listKeyboard(groupID int64, members []member) *models.InlineKeyboardMarkup {
+	invitable := roles.Invitable()
+	rows := make([][]models.InlineKeyboardButton, 0, len(members)+len(invitable))
+	for _, m := range members {
+		rows = append(rows, []models.InlineKeyboardButton{
+			{
+				Text:         fmt.Sprintf("❌ Revoke %s (%s)", m.name, m.grant.Role.Title()),
+				CallbackData: fmt.Sprintf("%s%d:%d", callbackRevokePrefix, groupID, m.grant.UserID),
+			},
+		})
+	}
+
+	for _, role := range invitable {
+		rows = append(rows, []models.InlineKeyboardButton{
+			{
+				Text:         "➕ Invite " + role.Title(),
+				CallbackData: fmt.Sprintf("%s%d:%s", callbackInvitePrefix, groupID, role),
+			},
+		})
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
@@ -0,0 +1,14 @@
+package roles
+
+import "strconv"
+
+const (
+	callbackGroupPrefix  = "roles:group:"
+	callbackInvitePrefix = "roles:invite:"
+	callbackRevokePrefix = "roles:revoke:"
+)
+
+// NewGroupRolesData returns callback data opening the roles of the group.
+func NewGroupRolesData(groupID int64) string {
+	return callbackGroupPrefix + strconv.FormatInt(groupID, 10)
+}
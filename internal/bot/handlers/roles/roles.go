@@ -0,0 +1,218 @@
+package roles
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/access"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/adaptor"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handler"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/groups"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/roles"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/users"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"go.uber.org/zap"
+)
+
+// Handler lets group owners grant bot roles by invite links and revoke them.
+type Handler struct {
+	handler.BaseHandler
+
+	accessSvc *access.Service
+	rolesSvc  *roles.Service
+	groupsSvc *groups.Service
+	usersSvc  *users.Service
+}
+
+func NewHandler(
+	bot *gotelegrambotfx.Bot,
+	accessSvc *access.Service,
+	rolesSvc *roles.Service,
+	groupsSvc *groups.Service,
+	usersSvc *users.Service,
+	logger *zap.Logger,
+) handler.Handler {
+	return &Handler{
+		BaseHandler: handler.BaseHandler{
+			Bot:    bot,
+			Logger: logger,
+		},
+
+		accessSvc: accessSvc,
+		rolesSvc:  rolesSvc,
+		groupsSvc: groupsSvc,
+		usersSvc:  usersSvc,
+	}
+}
+
+// Register implements handler.Handler.
+func (h *Handler) Register(b *gotelegrambotfx.Bot) {
+	b.RegisterHandlerMatchFunc(h.callbackFilter(callbackGroupPrefix), adaptor.New(h.handleList))
+	b.RegisterHandlerMatchFunc(h.callbackFilter(callbackInvitePrefix), adaptor.New(h.handleInvite))
+	b.RegisterHandlerMatchFunc(h.callbackFilter(callbackRevokePrefix), adaptor.New(h.handleRevoke))
+}
+
+func (h *Handler) callbackFilter(prefix string) bot.MatchFunc {
+	return func(update *models.Update) bool {
+		return update.CallbackQuery != nil && strings.HasPrefix(update.CallbackQuery.Data, prefix)
+	}
+}
+
+func (h *Handler) handleList(ctx *adaptor.Context, update *models.Update) {
+	groupID, err := strconv.ParseInt(strings.TrimPrefix(update.CallbackQuery.Data, callbackGroupPrefix), 10, 64)
+	if err != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to parse group ID: %w", err))
+		return
+	}
+
+	if !h.requireOwner(ctx, update, groupID) {
+		return
+	}
+
+	h.showList(ctx, update, groupID)
+}
+
+func (h *Handler) handleInvite(ctx *adaptor.Context, update *models.Update) {
+	groupIDStr, role, _ := strings.Cut(strings.TrimPrefix(update.CallbackQuery.Data, callbackInvitePrefix), ":")
+	groupID, err := strconv.ParseInt(groupIDStr, 10, 64)
+	if err != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to parse group ID: %w", err))
+		return
+	}
+
+	if !h.requireOwner(ctx, update, groupID) {
+		return
+	}
+
+	user, err := ctx.User()
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	invite, err := h.rolesSvc.CreateInvite(ctx, groupID, roles.Role(role), user.ID)
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	h.SendReply(ctx, update, &bot.SendMessageParams{
+		Text: fmt.Sprintf(
+			"🔗 Invite link for the %s role:\n\nhttps://t.me/%s?start=%s\n\n"+
+				"The link can be used once and expires on %s.",
+			invite.Role.Title(),
+			h.Bot.Username(),
+			invite.Payload(),
+			invite.ExpiresAt.Format("02.01.2006 15:04"),
+		),
+	})
+}
+
+func (h *Handler) handleRevoke(ctx *adaptor.Context, update *models.Update) {
+	groupIDStr, userIDStr, _ := strings.Cut(strings.TrimPrefix(update.CallbackQuery.Data, callbackRevokePrefix), ":")
+	groupID, err := strconv.ParseInt(groupIDStr, 10, 64)
+	if err != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to parse group ID: %w", err))
+		return
+	}
+	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	if err != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to parse user ID: %w", err))
+		return
+	}
+
+	if !h.requireOwner(ctx, update, groupID) {
+		return
+	}
+
+	user, err := ctx.User()
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	if revokeErr := h.rolesSvc.Revoke(ctx, groupID, userID, user.ID); revokeErr != nil {
+		h.HandleError(ctx, update, revokeErr)
+		return
+	}
+
+	h.SendReply(ctx, update, &bot.SendMessageParams{Text: "🗑 Role revoked."})
+	h.showList(ctx, update, groupID)
+}
+
+// requireOwner re-verifies with Telegram that the user may manage roles of the group.
+func (h *Handler) requireOwner(ctx *adaptor.Context, update *models.Update, groupID int64) bool {
+	user, err := ctx.User()
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return false
+	}
+
+	ok, err := h.accessSvc.Verify(ctx, groupID, user.ID, roles.PermManageRoles)
+	if err != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to verify permission: %w", err))
+		return false
+	}
+
+	if !ok {
+		h.SendReply(ctx, update, &bot.SendMessageParams{Text: "❌ Only group owners can manage roles."})
+	}
+
+	return ok
+}
+
+func (h *Handler) showList(ctx *adaptor.Context, update *models.Update, groupID int64) {
+	grants, err := h.rolesSvc.Grants(ctx, groupID)
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	members := make([]member, 0, len(grants))
+	for _, grant := range grants {
+		name := "#" + strconv.FormatInt(grant.UserID, 10)
+		u, userErr := h.usersSvc.GetByID(ctx, grant.UserID)
+		switch {
+		case errors.Is(userErr, users.ErrNotFound):
+		case userErr != nil:
+			h.HandleError(ctx, update, userErr)
+			return
+		case u.Username != "":
+			name = "@" + u.Username
+		default:
+			name = u.FirstName
+		}
+
+		members = append(members, member{grant: grant, name: name})
+	}
+
+	h.SendReply(ctx, update, &bot.SendMessageParams{
+		Text:        formatList(members),
+		ReplyMarkup: listKeyboard(groupID, members),
+	})
+}
+
+func formatList(members []member) string {
+	var b strings.Builder
+
+	b.WriteString("👤 Roles\n\n" +
+		"Chat admins get their roles from Telegram. " +
+		"Invite other users as managers (schedule and approve giveaways), " +
+		"editors (draft giveaways for approval) or viewers (statistics only).\n")
+
+	if len(members) == 0 {
+		b.WriteString("\nNo roles granted yet.")
+		return b.String()
+	}
+
+	b.WriteString("\n")
+	for _, m := range members {
+		fmt.Fprintf(&b, "• %s — %s\n", m.name, m.grant.Role.Title())
+	}
+
+	return b.String()
+}
@@ -5,12 +5,13 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/access"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/adaptor"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/filter"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handler"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/middlewares/state"
-	"github.com/capcom6/lucky-pick-tg-bot/internal/fsm"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/groups"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/roles"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/settings"
 	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
 	"github.com/go-telegram/bot"
@@ -22,15 +23,15 @@ import (
 type Settings struct {
 	handler.BaseHandler
 
-	fsmService  *fsm.Service
 	groupsSvc   *groups.Service
+	accessSvc   *access.Service
 	settingsSvc *settings.Service
 }
 
 func NewHandler(
 	bot *gotelegrambotfx.Bot,
-	fsmService *fsm.Service,
 	groupsSvc *groups.Service,
+	accessSvc *access.Service,
 	settingsSvc *settings.Service,
 	logger *zap.Logger,
 ) handler.Handler {
@@ -40,8 +41,8 @@ func NewHandler(
 			Logger: logger,
 		},
 
-		fsmService:  fsmService,
 		groupsSvc:   groupsSvc,
+		accessSvc:   accessSvc,
 		settingsSvc: settingsSvc,
 	}
 }
@@ -77,7 +78,7 @@ func (s *Settings) registerStateHandlers(b *gotelegrambotfx.Bot) {
 	b.RegisterHandlerMatchFunc(
 		filter.And(
 			func(update *models.Update) bool { return update.Message != nil },
-			state.NewStatePrefixFilter(settingInputPrefix, s.fsmService, s.Logger),
+			state.NewStatePrefixFilter(settingInputPrefix),
 		),
 		adaptor.New(s.handleTextInput),
 	)
@@ -111,13 +112,13 @@ func (s *Settings) checkAdminPermission(ctx *adaptor.Context, groupID int64) boo
 		return false
 	}
 
-	isAdmin, err := s.groupsSvc.IsAdmin(ctx, groupID, user.ID)
+	ok, err := s.accessSvc.Can(ctx, groupID, user.ID, roles.PermManageSettings)
 	if err != nil {
-		s.Logger.Error("failed to check if user is group admin", zap.Error(err))
+		s.Logger.Error("failed to check permission", zap.Error(err))
 		return false
 	}
 
-	return isAdmin
+	return ok
 }
 
 // Handler implementations
@@ -140,12 +141,12 @@ func (s *Settings) handleSettingsList(ctx *adaptor.Context, update *models.Updat
 }
 
 func (s *Settings) showCategoriesList(ctx *adaptor.Context, update *models.Update, groupID int64) {
-	logger := s.WithContext(update)
+	logger := s.WithContext(ctx, update)
 
 	// Check admin permission
 	if !s.checkAdminPermission(ctx, groupID) {
 		s.SendReply(ctx, update, &bot.SendMessageParams{
-			Text: "❌ You must be an admin of this group to edit settings.",
+			Text: "❌ Only group owners can edit settings.",
 		})
 		return
 	}
@@ -194,7 +195,7 @@ Select a category to edit:`, bot.EscapeMarkdown(group.Title)),
 }
 
 func (s *Settings) handleCategorySelect(ctx *adaptor.Context, update *models.Update) {
-	logger := s.WithContext(update)
+	logger := s.WithContext(ctx, update)
 
 	if update.CallbackQuery == nil {
 		return
@@ -230,12 +231,12 @@ func (s *Settings) handleCategorySelect(ctx *adaptor.Context, update *models.Upd
 }
 
 func (s *Settings) showSettingsList(ctx *adaptor.Context, update *models.Update, groupID int64, category string) {
-	logger := s.WithContext(update)
+	logger := s.WithContext(ctx, update)
 
 	// Check admin permission
 	if !s.checkAdminPermission(ctx, groupID) {
 		s.SendReply(ctx, update, &bot.SendMessageParams{
-			Text: "❌ You must be an admin of this group to edit settings.",
+			Text: "❌ Only group owners can edit settings.",
 		})
 		return
 	}
@@ -282,7 +283,7 @@ Select a setting to edit:`, bot.EscapeMarkdown(category)),
 }
 
 func (s *Settings) handleSettingSelect(ctx *adaptor.Context, update *models.Update) {
-	logger := s.WithContext(update)
+	logger := s.WithContext(ctx, update)
 
 	if update.CallbackQuery == nil {
 		return
@@ -322,12 +323,12 @@ func (s *Settings) showSettingEdit(
 	groupID int64,
 	settingKey string,
 ) {
-	logger := s.WithContext(update)
+	logger := s.WithContext(ctx, update)
 
 	// Check admin permission
 	if !s.checkAdminPermission(ctx, groupID) {
 		s.SendReply(ctx, update, &bot.SendMessageParams{
-			Text: "❌ You must be an admin of this group to edit settings.",
+			Text: "❌ Only group owners can edit settings.",
 		})
 		return
 	}
@@ -405,7 +406,7 @@ func (s *Settings) handleBooleanInput(ctx *adaptor.Context, update *models.Updat
 }
 
 func (s *Settings) processSettingInput(ctx *adaptor.Context, update *models.Update, inputValue string) {
-	logger := s.WithContext(update)
+	logger := s.WithContext(ctx, update)
 
 	// Get current state
 	state, err := s.state(ctx)
@@ -449,8 +450,24 @@ func (s *Settings) processSettingInput(ctx *adaptor.Context, update *models.Upda
 		return
 	}
 
+	user, err := ctx.User()
+	if err != nil {
+		s.HandleError(ctx, update, err)
+		return
+	}
+
+	// Re-verify with Telegram, the local admin list may be stale
+	if ok, verifyErr := s.accessSvc.Verify(ctx, groupID, user.ID, roles.PermManageSettings); verifyErr != nil {
+		s.HandleError(ctx, update, fmt.Errorf("failed to verify permission: %w", verifyErr))
+		return
+	} else if !ok {
+		state.Clear()
+		s.SendReply(ctx, update, &bot.SendMessageParams{Text: "❌ Only group owners can change settings."})
+		return
+	}
+
 	// Save setting
-	if updErr := s.settingsSvc.UpdateSetting(ctx, groupID, settingKey, inputValue); updErr != nil {
+	if updErr := s.settingsSvc.UpdateSetting(ctx, groupID, user.ID, settingKey, inputValue); updErr != nil {
 		logger.Error("failed to save setting", zap.Error(updErr))
 		s.HandleError(ctx, update, updErr)
 		return
@@ -1,8 +1,17 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/adaptor"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handler"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/groups"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/roles"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/users"
 	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
@@ -11,14 +20,31 @@ import (
 
 type Start struct {
 	handler.BaseHandler
+
+	usersSvc     *users.Service
+	groupsSvc    *groups.Service
+	rolesSvc     *roles.Service
+	giveawaysSvc *giveaways.Service
 }
 
-func NewStart(bot *gotelegrambotfx.Bot, logger *zap.Logger) handler.Handler {
+func NewStart(
+	bot *gotelegrambotfx.Bot,
+	usersSvc *users.Service,
+	groupsSvc *groups.Service,
+	rolesSvc *roles.Service,
+	giveawaysSvc *giveaways.Service,
+	logger *zap.Logger,
+) handler.Handler {
 	return &Start{
 		BaseHandler: handler.BaseHandler{
 			Bot:    bot,
 			Logger: logger,
 		},
+
+		usersSvc:     usersSvc,
+		groupsSvc:    groupsSvc,
+		rolesSvc:     rolesSvc,
+		giveawaysSvc: giveawaysSvc,
 	}
 }
 
@@ -33,7 +59,7 @@ func (s *Start) Register(b *gotelegrambotfx.Bot) {
 
 func (s *Start) handleStart(ctx *adaptor.Context, update *models.Update) {
 	if update.Message == nil || update.Message.From == nil {
-		s.WithContext(update).Error("invalid update: missing message or sender")
+		s.WithContext(ctx, update).Error("invalid update: missing message or sender")
 		return
 	}
 
@@ -43,6 +69,23 @@ func (s *Start) handleStart(ctx *adaptor.Context, update *models.Update) {
 		return
 	}
 
+	logger := s.WithContext(ctx, update).With(zap.Int64("user_id", user.ID))
+
+	_, payload, _ := strings.Cut(update.Message.Text, " ")
+	payload = strings.TrimSpace(payload)
+	switch {
+	case strings.HasPrefix(payload, users.ReferralPrefix):
+		if _, refErr := s.usersSvc.ApplyReferral(ctx, user, payload); refErr != nil {
+			logger.Warn("failed to apply referral", zap.String("code", payload), zap.Error(refErr))
+		}
+	case strings.HasPrefix(payload, roles.InvitePrefix):
+		s.acceptInvite(ctx, update, user.ID, payload)
+	case strings.HasPrefix(payload, giveaways.QuizPrefix):
+		// The question replaces the greeting, so it stays the last message
+		s.startQuiz(ctx, update, user.ID, payload)
+		return
+	}
+
 	displayName := user.Username
 	if displayName == "" {
 		displayName = user.FirstName
@@ -51,11 +94,88 @@ func (s *Start) handleStart(ctx *adaptor.Context, update *models.Update) {
 		displayName = "пользователь"
 	}
 
+	text := "Привет, " + displayName + "!\n\nДобро пожаловать в Lucky Pick Bot!\n\nТеперь ты сможешь получать уведомления о выигрыше здесь."
+
+	text += fmt.Sprintf(
+		"\n\nПриглашай друзей и получай дополнительные билеты в розыгрышах: https://t.me/%s?start=%s",
+		s.Bot.Username(),
+		user.ReferralCode(),
+	)
+
 	s.SendMessage(
 		ctx,
 		&bot.SendMessageParams{
 			ChatID: update.Message.Chat.ID,
-			Text:   "Привет, " + displayName + "!\n\nДобро пожаловать в Lucky Pick Bot!\n\nТеперь ты сможешь получать уведомления о выигрыше здесь.",
+			Text:   text,
 		},
 	)
 }
+
+// startQuiz asks the question of the quiz giveaway opened by the deep link, see giveaways.QuizCode.
+func (s *Start) startQuiz(ctx *adaptor.Context, update *models.Update, userID int64, payload string) {
+	st, err := ctx.State()
+	if err != nil {
+		s.HandleError(ctx, update, err)
+		return
+	}
+
+	if st.Name != "" && !st.IsPrefixed(quizState) {
+		s.SendReply(ctx, update, &bot.SendMessageParams{
+			Text: "Сначала завершите текущее действие или отправьте /cancel, затем откройте ссылку снова.",
+		})
+		return
+	}
+
+	giveawayID, err := giveaways.ParseQuizCode(payload)
+	if err != nil {
+		s.SendReply(ctx, update, &bot.SendMessageParams{Text: alertNotFound})
+		return
+	}
+
+	giveaway, err := s.giveawaysSvc.GetQuiz(ctx, giveawayID, userID)
+	if text, ok := quizErrorText(err); !ok {
+		s.HandleError(ctx, update, err)
+		return
+	} else if text != "" {
+		s.SendReply(ctx, update, &bot.SendMessageParams{Text: text})
+		return
+	}
+
+	hint := "Отправьте ответ одним сообщением."
+	if giveaway.Quiz.Mode.IsNumeric() {
+		hint = "Отправьте ответ числом."
+	}
+
+	st.SetName(quizState + strconv.FormatInt(giveawayID, 10))
+	s.SendReply(ctx, update, &bot.SendMessageParams{
+		Text: fmt.Sprintf(
+			"❓ Вопрос розыгрыша в «%s»:\n\n%s\n\n%s Изменить ответ будет нельзя.",
+			giveaway.Group.Title,
+			giveaway.Quiz.Question,
+			hint,
+		),
+	})
+}
+
+func (s *Start) acceptInvite(ctx *adaptor.Context, update *models.Update, userID int64, payload string) {
+	invite, err := s.rolesSvc.AcceptInvite(ctx, payload, userID)
+	if errors.Is(err, roles.ErrInviteNotFound) {
+		s.SendReply(ctx, update, &bot.SendMessageParams{Text: "❌ The invite link is invalid, used or expired."})
+		return
+	}
+	if err != nil {
+		s.HandleError(ctx, update, err)
+		return
+	}
+
+	title := ""
+	if group, groupErr := s.groupsSvc.GetByID(ctx, invite.GroupID); groupErr != nil {
+		s.WithContext(ctx, update).Warn("failed to get group", zap.Int64("group_id", invite.GroupID), zap.Error(groupErr))
+	} else {
+		title = " in «" + group.Title + "»"
+	}
+
+	s.SendReply(ctx, update, &bot.SendMessageParams{
+		Text: fmt.Sprintf("✅ You are now a %s%s. Use /groups to get started.", invite.Role.Title(), title),
+	})
+}
@@ -0,0 +1,28 @@
+package stats
+
+import (
+	"fmt"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/stats"
+	"github.com/go-telegram/bot/models"
+	"github.com/samber/lo"
+)
+
+// periodKeyboard creates keyboard to switch the statistics period, marking the current one.
+func periodKeyboard(groupID int64, current int) *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			lo.Map(stats.Periods, func(period int, _ int) models.InlineKeyboardButton {
+				text := fmt.Sprintf("%d days", period)
+				if period == current {
+					text = "• " + text + " •"
+				}
+
+				return models.InlineKeyboardButton{
+					Text:         text,
+					CallbackData: fmt.Sprintf("%s%d:%d", statsPeriodCallback, groupID, period),
+				}
+			}),
+		},
+	}
+}
@@ -0,0 +1,240 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/actions"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/access"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/adaptor"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handler"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/keyboards"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/groups"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/roles"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/stats"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx/extractors"
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// Stats command constants.
+	statsCommand = "/stats"
+
+	// Stats callback constants.
+	statsGroupCallback  = "stats:group:"
+	statsPeriodCallback = "stats:period:"
+)
+
+// Handler shows group statistics to users with any role in the group.
+type Handler struct {
+	handler.BaseHandler
+
+	accessSvc *access.Service
+	groupsSvc *groups.Service
+	statsSvc  *stats.Service
+}
+
+func NewHandler(
+	bot *gotelegrambotfx.Bot,
+	accessSvc *access.Service,
+	groupsSvc *groups.Service,
+	statsSvc *stats.Service,
+	logger *zap.Logger,
+) handler.Handler {
+	return &Handler{
+		BaseHandler: handler.BaseHandler{
+			Bot:    bot,
+			Logger: logger,
+		},
+
+		accessSvc: accessSvc,
+		groupsSvc: groupsSvc,
+		statsSvc:  statsSvc,
+	}
+}
+
+// Register implements handler.Handler.
+func (h *Handler) Register(b *gotelegrambotfx.Bot) {
+	b.RegisterHandlerMatchFunc(
+		func(update *models.Update) bool {
+			return update.Message != nil &&
+				update.Message.Text == statsCommand &&
+				update.Message.Chat.Type == models.ChatTypePrivate
+		},
+		adaptor.New(h.handleStatsCommand),
+	)
+
+	b.RegisterHandlerMatchFunc(
+		func(update *models.Update) bool {
+			return update.CallbackQuery != nil &&
+				(strings.HasPrefix(update.CallbackQuery.Data, statsGroupCallback) ||
+					strings.HasPrefix(update.CallbackQuery.Data, statsPeriodCallback))
+		},
+		adaptor.New(h.handleStatsCallback),
+	)
+}
+
+func (h *Handler) handleStatsCommand(ctx *adaptor.Context, update *models.Update) {
+	logger := h.WithContext(ctx, update)
+
+	user, err := ctx.User()
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	grps, err := h.accessSvc.Groups(ctx, user.ID, roles.PermViewStats)
+	if err != nil {
+		logger.Error("failed to get user groups", zap.Error(err))
+		h.SendReply(ctx, update, &bot.SendMessageParams{Text: "❌ Failed to verify access. Please try again."})
+		return
+	}
+
+	switch len(grps) {
+	case 0:
+		h.SendReply(ctx, update, &bot.SendMessageParams{Text: "❌ You must have a role in a group to view statistics."})
+	case 1:
+		h.showStats(ctx, update, &grps[0].Group, stats.DefaultPeriod)
+	default:
+		h.SendReply(ctx, update, &bot.SendMessageParams{
+			Text:        "📊 Select a group to view statistics:",
+			ReplyMarkup: keyboards.GroupSelectionKeyboard(statsGroupCallback, grps),
+		})
+	}
+}
+
+func (h *Handler) handleStatsCallback(ctx *adaptor.Context, update *models.Update) {
+	logger := h.WithContext(ctx, update)
+
+	user, err := ctx.User()
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	data := update.CallbackQuery.Data
+	period := stats.DefaultPeriod
+	var groupIDStr string
+	if strings.HasPrefix(data, statsGroupCallback) {
+		groupIDStr = strings.TrimPrefix(data, statsGroupCallback)
+	} else {
+		var periodStr string
+		groupIDStr, periodStr, _ = strings.Cut(strings.TrimPrefix(data, statsPeriodCallback), ":")
+		if period, err = strconv.Atoi(periodStr); err != nil {
+			logger.Error("failed to parse period", zap.Error(err))
+			return
+		}
+	}
+
+	groupID, err := strconv.ParseInt(groupIDStr, 10, 64)
+	if err != nil {
+		logger.Error("failed to parse group ID", zap.Error(err))
+		return
+	}
+
+	if ok, accessErr := h.accessSvc.Can(ctx, groupID, user.ID, roles.PermViewStats); accessErr != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to check permission: %w", accessErr))
+		return
+	} else if !ok {
+		h.SendReply(ctx, update, &bot.SendMessageParams{Text: "❌ You have no role in this group."})
+		return
+	}
+
+	group, err := h.groupsSvc.GetByID(ctx, groupID)
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	h.showStats(ctx, update, &group.Group, period)
+}
+
+func (h *Handler) showStats(ctx context.Context, update *models.Update, group *groups.Group, period int) {
+	groupStats, err := h.statsSvc.ForGroup(ctx, group.ID, period)
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	h.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      extractors.ChatID(update),
+		Text:        formatStats(group.Title, groupStats),
+		ReplyMarkup: periodKeyboard(group.ID, period),
+	})
+}
+
+func formatStats(title string, s *stats.GroupStats) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "📊 Statistics for «%s» for the last %d days\n\n", title, s.Period)
+
+	total := 0
+	for _, count := range s.GiveawaysByStatus {
+		total += count
+	}
+	fmt.Fprintf(&b, "🎁 Giveaways: %d\n", total)
+	for _, status := range []giveaways.Status{
+		giveaways.StatusPendingApproval,
+		giveaways.StatusChangesRequested,
+		giveaways.StatusScheduled,
+		giveaways.StatusActive,
+		giveaways.StatusClosed,
+		giveaways.StatusFinished,
+		giveaways.StatusCancelled,
+	} {
+		if count := s.GiveawaysByStatus[string(status)]; count > 0 {
+			fmt.Fprintf(&b, "  • %s: %d\n", status, count)
+		}
+	}
+
+	fmt.Fprintf(&b, "\n👥 Average participants: %.1f\n", s.AverageParticipants)
+	fmt.Fprintf(&b, "🙋 Unique participants: %d\n", s.UniqueParticipants)
+	fmt.Fprintf(&b, "🔁 Repeat participants: %d\n", s.RepeatParticipants)
+
+	joins := 0
+	for _, count := range s.JoinDelays {
+		joins += count
+	}
+	if joins > 0 {
+		b.WriteString("\n⏱ Joined after publication:\n")
+		for _, delay := range stats.JoinDelays {
+			count := s.JoinDelays[delay]
+			fmt.Fprintf(&b, "  • %s: %d (%d%%)\n", joinDelayLabel(delay), count, count*100/joins) //nolint:mnd // percent
+		}
+	}
+
+	fmt.Fprintf(&b, "\n💬 Discussions started: %d\n", s.Actions[actions.KindDiscussionStarted])
+	fmt.Fprintf(&b, "🗨 Answers in discussions: %d from %d user(s)\n", s.DiscussionAnswers, s.DiscussionUsers)
+
+	if len(s.TopParticipants) > 0 {
+		b.WriteString("\n🏆 Top participants:\n")
+		for i, p := range s.TopParticipants {
+			name := p.FirstName
+			if p.Username != "" {
+				name = "@" + p.Username
+			}
+			fmt.Fprintf(&b, "  %d. %s — %d giveaway(s), %d win(s)\n", i+1, name, p.Participations, p.Wins)
+		}
+	}
+
+	return b.String()
+}
+
+func joinDelayLabel(delay stats.JoinDelay) string {
+	switch delay {
+	case stats.JoinDelayFirstHour:
+		return "within an hour"
+	case stats.JoinDelaySameDay:
+		return "within a day"
+	case stats.JoinDelayLater:
+		return "later"
+	}
+
+	return string(delay)
+}
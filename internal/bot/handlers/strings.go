@@ -2,4 +2,14 @@ package handlers
 
 const (
 	alertSomethingWrong = "К сожалению, возникла ошибка. Обратитесь к администратору."
+
+	alertNotFound   = "Розыгрыш не найден."
+	alertNotStarted = "Розыгрыш ещё не начался."
+	alertClosed     = "Приём заявок на розыгрыш завершён."
+	alertCancelled  = "Розыгрыш отменён."
+
+	alertForeignChat = "Участвовать можно только через публикацию розыгрыша в группе."
+
+	alertQuizRequired    = "Чтобы участвовать, ответьте на вопрос розыгрыша по кнопке под публикацией."
+	alertAlreadyAnswered = "Вы уже ответили на вопрос этого розыгрыша. Правильный ответ будет объявлен вместе с итогами."
 )
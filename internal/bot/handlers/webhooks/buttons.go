@@ -0,0 +1,66 @@
+package webhooks
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/webhooks"
+	"github.com/go-telegram/bot/models"
+)
+
+// listKeyboard creates keyboard with webhooks of the group and a button to add a new one.
+func listKeyboard(groupID int64, items []webhooks.Webhook) *models.InlineKeyboardMarkup {
+	rows := make([][]models.InlineKeyboardButton, 0, len(items)+1)
+	for _, item := range items {
+		rows = append(rows, []models.InlineKeyboardButton{
+			{
+				Text:         "🔗 " + item.URL,
+				CallbackData: callbackViewPrefix + strconv.FormatInt(item.ID, 10),
+			},
+		})
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{
+		{
+			Text:         "➕ Add webhook",
+			CallbackData: callbackAddPrefix + strconv.FormatInt(groupID, 10),
+		},
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// detailsKeyboard creates keyboard to toggle subscribed events and delete the webhook.
+func detailsKeyboard(webhook *webhooks.Webhook) *models.InlineKeyboardMarkup {
+	rows := make([][]models.InlineKeyboardButton, 0, len(webhooks.EventTypes())+2)
+	for _, event := range webhooks.EventTypes() {
+		mark := "➖"
+		if webhook.IsSubscribed(event) {
+			mark = "✅"
+		}
+
+		rows = append(rows, []models.InlineKeyboardButton{
+			{
+				Text:         fmt.Sprintf("%s %s", mark, event),
+				CallbackData: fmt.Sprintf("%s%d:%s", callbackTogglePrefix, webhook.ID, event),
+			},
+		})
+	}
+
+	rows = append(rows,
+		[]models.InlineKeyboardButton{
+			{
+				Text:         "🗑 Delete",
+				CallbackData: callbackDeletePrefix + strconv.FormatInt(webhook.ID, 10),
+			},
+		},
+		[]models.InlineKeyboardButton{
+			{
+				Text:         "🔙 Back to Webhooks",
+				CallbackData: NewGroupWebhooksData(webhook.GroupID),
+			},
+		},
+	)
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
@@ -0,0 +1,16 @@
+package webhooks
+
+import "strconv"
+
+const (
+	callbackGroupPrefix  = "webhooks:group:"
+	callbackAddPrefix    = "webhooks:add:"
+	callbackViewPrefix   = "webhooks:view:"
+	callbackTogglePrefix = "webhooks:toggle:"
+	callbackDeletePrefix = "webhooks:delete:"
+)
+
+// NewGroupWebhooksData returns callback data opening the webhooks of the group.
+func NewGroupWebhooksData(groupID int64) string {
+	return callbackGroupPrefix + strconv.FormatInt(groupID, 10)
+}
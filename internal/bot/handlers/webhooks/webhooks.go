@@ -0,0 +1,336 @@
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/access"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/adaptor"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/filter"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handler"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/middlewares/state"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/roles"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/webhooks"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// stateWaitURL waits for the URL of a new webhook.
+	stateWaitURL = "webhooks:wait_url"
+
+	// dataGroupID keeps the group of the new webhook.
+	dataGroupID = "webhooks:group_id"
+
+	// deliveriesLimit is the number of deliveries shown in the log.
+	deliveriesLimit = 10
+)
+
+// Handler lets group admins manage outgoing webhooks.
+type Handler struct {
+	handler.BaseHandler
+
+	accessSvc   *access.Service
+	webhooksSvc *webhooks.Service
+}
+
+func NewHandler(
+	bot *gotelegrambotfx.Bot,
+	accessSvc *access.Service,
+	webhooksSvc *webhooks.Service,
+	logger *zap.Logger,
+) handler.Handler {
+	return &Handler{
+		BaseHandler: handler.BaseHandler{
+			Bot:    bot,
+			Logger: logger,
+		},
+
+		accessSvc:   accessSvc,
+		webhooksSvc: webhooksSvc,
+	}
+}
+
+// Register implements handler.Handler.
+func (h *Handler) Register(b *gotelegrambotfx.Bot) {
+	b.RegisterHandlerMatchFunc(h.callbackFilter(callbackGroupPrefix), adaptor.New(h.handleList))
+	b.RegisterHandlerMatchFunc(h.callbackFilter(callbackAddPrefix), adaptor.New(h.handleAdd))
+	b.RegisterHandlerMatchFunc(h.callbackFilter(callbackViewPrefix), adaptor.New(h.handleView))
+	b.RegisterHandlerMatchFunc(h.callbackFilter(callbackTogglePrefix), adaptor.New(h.handleToggle))
+	b.RegisterHandlerMatchFunc(h.callbackFilter(callbackDeletePrefix), adaptor.New(h.handleDelete))
+
+	b.RegisterHandlerMatchFunc(
+		filter.And(
+			func(update *models.Update) bool {
+				return update.Message != nil && update.Message.Chat.Type == models.ChatTypePrivate
+			},
+			state.NewStateFilter(stateWaitURL),
+		),
+		adaptor.New(h.handleURL),
+	)
+}
+
+func (h *Handler) callbackFilter(prefix string) bot.MatchFunc {
+	return func(update *models.Update) bool {
+		return update.CallbackQuery != nil && strings.HasPrefix(update.CallbackQuery.Data, prefix)
+	}
+}
+
+func (h *Handler) handleList(ctx *adaptor.Context, update *models.Update) {
+	groupID, err := strconv.ParseInt(strings.TrimPrefix(update.CallbackQuery.Data, callbackGroupPrefix), 10, 64)
+	if err != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to parse group ID: %w", err))
+		return
+	}
+
+	if !h.requireAdmin(ctx, update, groupID) {
+		return
+	}
+
+	h.showList(ctx, update, groupID)
+}
+
+func (h *Handler) handleAdd(ctx *adaptor.Context, update *models.Update) {
+	groupID, err := strconv.ParseInt(strings.TrimPrefix(update.CallbackQuery.Data, callbackAddPrefix), 10, 64)
+	if err != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to parse group ID: %w", err))
+		return
+	}
+
+	if !h.requireAdmin(ctx, update, groupID) {
+		return
+	}
+
+	st, err := ctx.State()
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	st.SetName(stateWaitURL)
+	st.AddData(dataGroupID, strconv.FormatInt(groupID, 10))
+
+	h.SendReply(ctx, update, &bot.SendMessageParams{
+		Text: "🔗 Send the HTTPS URL of the endpoint.\n\nUse /cancel to abort.",
+	})
+}
+
+func (h *Handler) handleURL(ctx *adaptor.Context, update *models.Update) {
+	logger := h.WithContext(ctx, update)
+
+	st, err := ctx.State()
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	groupID, err := strconv.ParseInt(st.GetData(dataGroupID), 10, 64)
+	if err != nil {
+		logger.Error("missing group ID in state", zap.Error(err))
+		st.Clear()
+		h.SendReply(ctx, update, &bot.SendMessageParams{
+			Text: "❌ Missing group context. Please start from the groups menu.",
+		})
+		return
+	}
+
+	if !h.requireAdmin(ctx, update, groupID) {
+		st.Clear()
+		return
+	}
+
+	user, err := ctx.User()
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	webhook, err := h.webhooksSvc.Create(ctx, groupID, user.ID, strings.TrimSpace(update.Message.Text))
+	if errors.Is(err, webhooks.ErrInvalidURL) {
+		h.SendReply(ctx, update, &bot.SendMessageParams{
+			Text: "❌ Invalid URL. Please send an absolute HTTPS URL of a public host, e.g. https://example.com/hooks/giveaways.",
+		})
+		return
+	}
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	st.Clear()
+
+	h.SendReply(ctx, update, &bot.SendMessageParams{
+		Text: fmt.Sprintf(
+			"✅ Webhook added and subscribed to all events.\n\n"+
+				"🔑 Secret: %s\n\n"+
+				"Each request carries the X-Webhook-Signature-256 header with "+
+				"sha256=<HMAC-SHA256 of the body with this secret>. "+
+				"Store the secret now, it won't be shown again.",
+			webhook.Secret,
+		),
+	})
+
+	h.showDetails(ctx, update, webhook)
+}
+
+func (h *Handler) handleView(ctx *adaptor.Context, update *models.Update) {
+	webhook, ok := h.webhook(ctx, update, strings.TrimPrefix(update.CallbackQuery.Data, callbackViewPrefix))
+	if !ok {
+		return
+	}
+
+	h.showDetails(ctx, update, webhook)
+}
+
+func (h *Handler) handleToggle(ctx *adaptor.Context, update *models.Update) {
+	idStr, event, _ := strings.Cut(strings.TrimPrefix(update.CallbackQuery.Data, callbackTogglePrefix), ":")
+
+	webhook, ok := h.webhook(ctx, update, idStr)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhooksSvc.ToggleEvent(ctx, webhook.ID, webhooks.EventType(event))
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	h.showDetails(ctx, update, webhook)
+}
+
+func (h *Handler) handleDelete(ctx *adaptor.Context, update *models.Update) {
+	webhook, ok := h.webhook(ctx, update, strings.TrimPrefix(update.CallbackQuery.Data, callbackDeletePrefix))
+	if !ok {
+		return
+	}
+
+	if err := h.webhooksSvc.Delete(ctx, webhook.ID); err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	h.SendReply(ctx, update, &bot.SendMessageParams{Text: "🗑 Webhook deleted."})
+	h.showList(ctx, update, webhook.GroupID)
+}
+
+// webhook loads the webhook by its ID from callback data and checks that the user administers its group.
+func (h *Handler) webhook(ctx *adaptor.Context, update *models.Update, idStr string) (*webhooks.Webhook, bool) {
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to parse webhook ID: %w", err))
+		return nil, false
+	}
+
+	webhook, err := h.webhooksSvc.GetByID(ctx, id)
+	if errors.Is(err, webhooks.ErrNotFound) {
+		h.SendReply(ctx, update, &bot.SendMessageParams{Text: "❌ Webhook not found."})
+		return nil, false
+	}
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return nil, false
+	}
+
+	if !h.requireAdmin(ctx, update, webhook.GroupID) {
+		return nil, false
+	}
+
+	return webhook, true
+}
+
+func (h *Handler) requireAdmin(ctx *adaptor.Context, update *models.Update, groupID int64) bool {
+	user, err := ctx.User()
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return false
+	}
+
+	ok, err := h.accessSvc.Verify(ctx, groupID, user.ID, roles.PermManageSettings)
+	if err != nil {
+		h.HandleError(ctx, update, fmt.Errorf("failed to verify permission: %w", err))
+		return false
+	}
+
+	if !ok {
+		h.SendReply(ctx, update, &bot.SendMessageParams{Text: "❌ Only group owners can manage webhooks."})
+	}
+
+	return ok
+}
+
+func (h *Handler) showList(ctx *adaptor.Context, update *models.Update, groupID int64) {
+	items, err := h.webhooksSvc.ListByGroup(ctx, groupID)
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	text := "🔗 Webhooks\n\nNo webhooks yet. Add one to receive giveaway events."
+	if len(items) > 0 {
+		text = "🔗 Webhooks\n\nSelect a webhook to manage events and view deliveries:"
+	}
+
+	h.SendReply(ctx, update, &bot.SendMessageParams{
+		Text:        text,
+		ReplyMarkup: listKeyboard(groupID, items),
+	})
+}
+
+func (h *Handler) showDetails(ctx *adaptor.Context, update *models.Update, webhook *webhooks.Webhook) {
+	deliveries, err := h.webhooksSvc.ListDeliveries(ctx, webhook.ID, deliveriesLimit)
+	if err != nil {
+		h.HandleError(ctx, update, err)
+		return
+	}
+
+	h.SendReply(ctx, update, &bot.SendMessageParams{
+		Text:        formatDetails(webhook, deliveries),
+		ReplyMarkup: detailsKeyboard(webhook),
+	})
+}
+
+func formatDetails(webhook *webhooks.Webhook, deliveries []webhooks.Delivery) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "🔗 %s\n\nTap an event to subscribe or unsubscribe.\n", webhook.URL)
+
+	if len(deliveries) == 0 {
+		b.WriteString("\n📭 No deliveries yet.")
+		return b.String()
+	}
+
+	b.WriteString("\n📬 Recent deliveries:\n")
+	for _, d := range deliveries {
+		fmt.Fprintf(&b, "%s %s %s", deliveryIcon(d.Status), d.CreatedAt.Format("02.01 15:04"), d.Event)
+		if d.ResponseCode > 0 {
+			fmt.Fprintf(&b, " — HTTP %d", d.ResponseCode)
+		}
+		if d.Status == webhooks.DeliveryPending && d.Attempts > 0 {
+			fmt.Fprintf(&b, " — retry #%d at %s", d.Attempts, d.NextAttemptAt.Format("15:04"))
+		}
+		if d.Status == webhooks.DeliveryFailed {
+			fmt.Fprintf(&b, " — gave up after %d attempts", d.Attempts)
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func deliveryIcon(status webhooks.DeliveryStatus) string {
+	switch status {
+	case webhooks.DeliveryDelivered:
+		return "✅"
+	case webhooks.DeliveryFailed:
+		return "❌"
+	case webhooks.DeliveryPending:
+		return "⏳"
+	}
+
+	return "•"
+}
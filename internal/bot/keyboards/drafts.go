@@ -0,0 +1,24 @@
+package keyboards
+
+import (
+	"strconv"
+
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// DraftResumePrefix is the callback data prefix of the button resuming a draft.
+	DraftResumePrefix = "drafts:resume:"
+	// DraftDiscardPrefix is the callback data prefix of the button discarding a draft.
+	DraftDiscardPrefix = "drafts:discard:"
+)
+
+// DraftRow creates a row with buttons to resume and discard the draft.
+func DraftRow(draftID int64, title string) []models.InlineKeyboardButton {
+	id := strconv.FormatInt(draftID, 10)
+
+	return []models.InlineKeyboardButton{
+		{Text: "▶️ " + title, CallbackData: DraftResumePrefix + id},
+		{Text: "🗑 Discard", CallbackData: DraftDiscardPrefix + id},
+	}
+}
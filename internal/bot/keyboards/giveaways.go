@@ -0,0 +1,120 @@
+package keyboards
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// ParticipatePrefix is the callback data prefix of the participation button.
+const ParticipatePrefix = "participate:"
+
+// ParticipateKeyboard creates the keyboard attached to a published giveaway.
+// The participants count is shown on the button only when it is positive.
+func ParticipateKeyboard(giveawayID int64, participantsCount int) *models.InlineKeyboardMarkup {
+	text := "✅ Хочу!"
+	if participantsCount > 0 {
+		text = fmt.Sprintf("%s (%d)", text, participantsCount)
+	}
+
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: text, CallbackData: ParticipatePrefix + strconv.FormatInt(giveawayID, 10)},
+			},
+		},
+	}
+}
+
+// QuizKeyboard creates the keyboard attached to a published quiz giveaway. The button opens the private chat
+// with the bot by the deep link, where the question is answered.
+func QuizKeyboard(link string, participantsCount int) *models.InlineKeyboardMarkup {
+	text := "✍️ Ответить"
+	if participantsCount > 0 {
+		text = fmt.Sprintf("%s (%d)", text, participantsCount)
+	}
+
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: text, URL: link},
+			},
+		},
+	}
+}
+
+// ResultKeyboard creates the keyboard attached to a finished giveaway, linking to the result message.
+// The keyboard is empty when there is no link, which removes the participation button.
+func ResultKeyboard(resultLink string) *models.InlineKeyboardMarkup {
+	rows := [][]models.InlineKeyboardButton{}
+	if resultLink != "" {
+		rows = append(rows, []models.InlineKeyboardButton{{Text: "🏆 Итоги розыгрыша", URL: resultLink}})
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+const (
+	// ApprovePrefix is the callback data prefix of the button approving a submitted giveaway.
+	ApprovePrefix = "approval:approve:"
+	// RejectPrefix is the callback data prefix of the button rejecting a submitted giveaway.
+	RejectPrefix = "approval:reject:"
+	// RequestChangesPrefix is the callback data prefix of the button sending a submitted giveaway back to its author.
+	RequestChangesPrefix = "approval:changes:"
+	// RevisePrefix is the callback data prefix of the button revising a giveaway sent back for changes.
+	RevisePrefix = "approval:revise:"
+)
+
+// ReviewKeyboard creates the keyboard attached to a giveaway submitted for approval.
+func ReviewKeyboard(giveawayID int64) *models.InlineKeyboardMarkup {
+	id := strconv.FormatInt(giveawayID, 10)
+
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: "✅ Approve", CallbackData: ApprovePrefix + id},
+				{Text: "❌ Reject", CallbackData: RejectPrefix + id},
+			},
+			{
+				{Text: "✏️ Request changes", CallbackData: RequestChangesPrefix + id},
+			},
+		},
+	}
+}
+
+// ReviseKeyboard creates the keyboard offering the author to revise the giveaway sent back for changes.
+func ReviseKeyboard(giveawayID int64) *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: "✏️ Revise and resubmit", CallbackData: RevisePrefix + strconv.FormatInt(giveawayID, 10)},
+			},
+		},
+	}
+}
+
+const (
+	// CancelGiveawayPrefix is the callback data prefix of the button asking to cancel a giveaway.
+	CancelGiveawayPrefix = "giveaways:cancel:"
+	// ConfirmCancelGiveawayPrefix is the callback data prefix of the button confirming the cancellation.
+	ConfirmCancelGiveawayPrefix = "giveaways:cancel_confirm:"
+)
+
+// CancelGiveawayRow creates a row with the button asking to cancel the giveaway.
+func CancelGiveawayRow(giveawayID int64, title string) []models.InlineKeyboardButton {
+	return []models.InlineKeyboardButton{
+		{Text: "🚫 Cancel " + title, CallbackData: CancelGiveawayPrefix + strconv.FormatInt(giveawayID, 10)},
+	}
+}
+
+// ConfirmCancelGiveawayKeyboard creates the keyboard confirming the cancellation of the giveaway.
+func ConfirmCancelGiveawayKeyboard(giveawayID int64) *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: "🚫 Yes, cancel", CallbackData: ConfirmCancelGiveawayPrefix + strconv.FormatInt(giveawayID, 10)},
+			},
+		},
+	}
+}
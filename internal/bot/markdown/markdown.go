@@ -0,0 +1,19 @@
+package markdown
+
+import (
+	"fmt"
+
+	"github.com/go-telegram/bot"
+)
+
+// UserMention returns a MarkdownV2 mention of a Telegram user.
+func UserMention(telegramID int64, username, firstName string) string {
+	switch {
+	case username != "":
+		return "@" + bot.EscapeMarkdown(username)
+	case firstName != "":
+		return fmt.Sprintf("[%s](tg://user?id=%d)", bot.EscapeMarkdown(firstName), telegramID)
+	default:
+		return fmt.Sprintf("[%d](tg://user?id=%d)", telegramID, telegramID)
+	}
+}
@@ -3,6 +3,7 @@ package callback
 import (
 	"context"
 
+	"github.com/capcom6/lucky-pick-tg-bot/internal/tracing"
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
 	"go.uber.org/zap"
@@ -20,15 +21,16 @@ func NewMiddleware(logger *zap.Logger) bot.Middleware {
 			if _, err := b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 				CallbackQueryID: update.CallbackQuery.ID,
 			}); err != nil {
-				logger.Error("failed to answer callback query", zap.Error(err))
+				logger.Error("failed to answer callback query", append(tracing.Fields(ctx), zap.Error(err))...)
 			}
 
-			if update.CallbackQuery.Message.Message != nil {
+			// Only menus in private chats are disposable, group posts must stay in place
+			if msg := update.CallbackQuery.Message.Message; msg != nil && msg.Chat.Type == models.ChatTypePrivate {
 				if _, err := b.DeleteMessage(ctx, &bot.DeleteMessageParams{
-					ChatID:    update.CallbackQuery.Message.Message.Chat.ID,
-					MessageID: update.CallbackQuery.Message.Message.ID,
+					ChatID:    msg.Chat.ID,
+					MessageID: msg.ID,
 				}); err != nil {
-					logger.Error("failed to delete message", zap.Error(err))
+					logger.Error("failed to delete message", append(tracing.Fields(ctx), zap.Error(err))...)
 				}
 			}
 		}
@@ -0,0 +1,65 @@
+package signature
+
+import (
+	"context"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/tracing"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"go.uber.org/zap"
+)
+
+const alertOutdated = "⌛ This button is outdated."
+
+// NewMiddleware verifies the signature of the callback data and strips it, so handlers see the raw payload.
+// Callback queries with a missing or invalid signature are answered with an alert and dropped.
+func NewMiddleware(signer *gotelegrambotfx.Signer, logger *zap.Logger) bot.Middleware {
+	return func(next bot.HandlerFunc) bot.HandlerFunc {
+		return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+			if update.CallbackQuery == nil {
+				next(ctx, b, update)
+				return
+			}
+
+			chatID, messageID, ok := source(update.CallbackQuery)
+			if ok {
+				if data, err := signer.Verify(chatID, messageID, update.CallbackQuery.Data); err == nil {
+					update.CallbackQuery.Data = data
+					next(ctx, b, update)
+					return
+				}
+			}
+
+			logger.Warn(
+				"callback data rejected",
+				append(
+					tracing.Fields(ctx),
+					zap.Int64("user_id", update.CallbackQuery.From.ID),
+					zap.Int64("chat_id", chatID),
+					zap.String("data", update.CallbackQuery.Data),
+				)...,
+			)
+
+			if _, err := b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+				CallbackQueryID: update.CallbackQuery.ID,
+				Text:            alertOutdated,
+				ShowAlert:       true,
+			}); err != nil {
+				logger.Error("failed to answer callback query", append(tracing.Fields(ctx), zap.Error(err))...)
+			}
+		}
+	}
+}
+
+// source returns the chat and the message the button is attached to.
+func source(query *models.CallbackQuery) (int64, int, bool) {
+	switch {
+	case query.Message.Message != nil:
+		return query.Message.Message.Chat.ID, query.Message.Message.ID, true
+	case query.Message.InaccessibleMessage != nil:
+		return query.Message.InaccessibleMessage.Chat.ID, query.Message.InaccessibleMessage.MessageID, true
+	default:
+		return 0, 0, false
+	}
+}
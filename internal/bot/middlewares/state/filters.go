@@ -1,45 +1,52 @@
 package state
 
 import (
-	"context"
 	"strings"
+	"sync"
 
 	"github.com/capcom6/lucky-pick-tg-bot/internal/fsm"
-	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx/extractors"
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
-	"go.uber.org/zap"
 )
 
-func NewStateFilter(target string, fsm *fsm.Service, logger *zap.Logger) bot.MatchFunc {
-	return func(update *models.Update) bool {
-		userID := extractors.UserID(update)
+// bound keeps states loaded by the middleware, so filters don't load them again.
+//
+//nolint:gochecknoglobals // filters get only the update, so the state is shared by its pointer
+var bound sync.Map
 
-		if userID == 0 {
-			return false
-		}
+func bind(update *models.Update, state *fsm.State) {
+	bound.Store(update, state)
+}
 
-		state, err := fsm.Get(context.Background(), userID)
-		if err != nil {
-			logger.Error("get state", zap.Error(err))
-			return false
-		}
+func unbind(update *models.Update) {
+	bound.Delete(update)
+}
 
-		return state.Name == target
+// lookup returns the state bound to the update. Updates without a user have no state.
+func lookup(update *models.Update) (*fsm.State, bool) {
+	v, ok := bound.Load(update)
+	if !ok {
+		return nil, false
 	}
+
+	return v.(*fsm.State), true //nolint:errcheck,forcetypeassert // only states are stored
 }
 
-func NewStatePrefixFilter(prefix string, fsm *fsm.Service, logger *zap.Logger) bot.MatchFunc {
+func NewStateFilter(target string) bot.MatchFunc {
 	return func(update *models.Update) bool {
-		userID := extractors.UserID(update)
-
-		if userID == 0 {
+		state, ok := lookup(update)
+		if !ok {
 			return false
 		}
 
-		state, err := fsm.Get(context.Background(), userID)
-		if err != nil {
-			logger.Error("get state", zap.Error(err))
+		return state.Name == target
+	}
+}
+
+func NewStatePrefixFilter(prefix string) bot.MatchFunc {
+	return func(update *models.Update) bool {
+		state, ok := lookup(update)
+		if !ok {
 			return false
 		}
 
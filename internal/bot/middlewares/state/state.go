@@ -5,7 +5,7 @@ import (
 	"errors"
 
 	"github.com/capcom6/lucky-pick-tg-bot/internal/fsm"
-	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/tracing"
 	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx/extractors"
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
@@ -18,6 +18,9 @@ const stateKey contextKey = "state"
 
 var ErrContextKeyNotFound = errors.New("context key not found")
 
+// NewMiddleware loads the state of the user once per update and stores it after the handler.
+// Updates of the same user are handled one at a time, so the handler always sees the latest state.
+// Handlers must be matched after this middleware, see gotelegrambotfx.Bot, for filters to see the state.
 func NewMiddleware(svc *fsm.Service, logger *zap.Logger) bot.Middleware {
 	return func(next bot.HandlerFunc) bot.HandlerFunc {
 		return func(ctx context.Context, b *bot.Bot, update *models.Update) {
@@ -28,28 +31,53 @@ func NewMiddleware(svc *fsm.Service, logger *zap.Logger) bot.Middleware {
 				return
 			}
 
+			unlock := svc.Lock(userID)
+			defer unlock()
+
 			state, err := svc.Get(ctx, userID)
 			if err != nil {
-				logger.Error("get state", zap.Error(err))
-				if _, sendErr := (&gotelegrambotfx.Bot{Bot: b}).SendReply(ctx, update, &bot.SendMessageParams{
-					Text: "❌ Failed to get state. Please try again.",
-				}); sendErr != nil {
-					logger.Error("failed to send error message", zap.Error(sendErr))
-				}
+				logger.Error("get state", append(tracing.Fields(ctx), zap.Error(err))...)
+				reply(ctx, b, update, "❌ Failed to get state. Please try again.", logger)
 				return
 			}
 
 			ctx = context.WithValue(ctx, stateKey, state)
+			bind(update, state)
+			defer unbind(update)
 
 			next(ctx, b, update)
 
-			if setErr := svc.Set(ctx, userID, state); setErr != nil {
-				logger.Error("set state", zap.Error(setErr))
+			// Any update of an active conversation extends its TTL, so only untouched empty states are skipped
+			if state.Name == "" && !state.Changed() {
+				return
+			}
+
+			setErr := svc.Set(ctx, userID, state)
+			if errors.Is(setErr, fsm.ErrConflict) {
+				logger.Warn("state was changed concurrently", append(tracing.Fields(ctx), zap.Int64("user_id", userID))...)
+				reply(
+					ctx, b, update,
+					"⚠️ The operation was changed in the meantime. Please check the latest message and try again.",
+					logger,
+				)
+				return
+			}
+			if setErr != nil {
+				logger.Error("set state", append(tracing.Fields(ctx), zap.Error(setErr))...)
 			}
 		}
 	}
 }
 
+func reply(ctx context.Context, b *bot.Bot, update *models.Update, text string, logger *zap.Logger) {
+	if _, err := b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: extractors.From(update),
+		Text:   text,
+	}); err != nil {
+		logger.Error("failed to send error message", append(tracing.Fields(ctx), zap.Error(err))...)
+	}
+}
+
 func FromContext(ctx context.Context) (*fsm.State, error) {
 	if v, ok := ctx.Value(stateKey).(*fsm.State); ok {
 		return v, nil
@@ -0,0 +1,48 @@
+package trace
+
+import (
+	"context"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/tracing"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx/extractors"
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// NewMiddleware starts a root span for every update. It must be the first middleware,
+// so the span covers the rest of the chain.
+func NewMiddleware() bot.Middleware {
+	return func(next bot.HandlerFunc) bot.HandlerFunc {
+		return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+			updateType := updateType(update)
+
+			ctx, span := tracing.StartRoot(
+				ctx,
+				"update "+updateType,
+				attribute.Int64("telegram.update_id", update.ID),
+				attribute.String("telegram.update_type", updateType),
+				attribute.Int64("telegram.user_id", extractors.UserID(update)),
+				attribute.Int64("telegram.chat_id", extractors.ChatID(update)),
+			)
+			defer span.End()
+
+			next(ctx, b, update)
+		}
+	}
+}
+
+func updateType(update *models.Update) string {
+	switch {
+	case update.Message != nil:
+		return "message"
+	case update.CallbackQuery != nil:
+		return "callback_query"
+	case update.MyChatMember != nil:
+		return "my_chat_member"
+	case update.EditedMessage != nil:
+		return "edited_message"
+	default:
+		return "other"
+	}
+}
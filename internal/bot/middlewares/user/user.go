@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/capcom6/lucky-pick-tg-bot/internal/tracing"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/users"
 	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx/extractors"
 	"github.com/go-telegram/bot"
@@ -31,7 +32,7 @@ func NewMiddleware(usersSvc *users.Service, logger *zap.Logger) bot.Middleware {
 
 			user, err := usersSvc.RegisterUser(ctx, ToDomain(tgUser))
 			if err != nil {
-				logger.Error("register user", zap.Error(err))
+				logger.Error("register user", append(tracing.Fields(ctx), zap.Error(err))...)
 				_, _ = b.SendMessage(
 					ctx,
 					&bot.SendMessageParams{
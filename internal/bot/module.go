@@ -3,9 +3,13 @@ package bot
 import (
 	"context"
 
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/access"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/admins"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handlers"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/middlewares/callback"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/middlewares/signature"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/middlewares/state"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/middlewares/trace"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/middlewares/user"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/fsm"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/users"
@@ -21,14 +25,23 @@ func Module() fx.Option {
 		"bot",
 		logger.WithNamedLogger("bot"),
 		fx.Provide(
-			func(usersSvc *users.Service, stateSvc *fsm.Service, log *zap.Logger) []bot.Option {
+			func(
+				signer *gotelegrambotfx.Signer,
+				usersSvc *users.Service,
+				stateSvc *fsm.Service,
+				log *zap.Logger,
+			) []bot.Option {
 				return []bot.Option{
+					bot.WithHTTPClient(pollTimeout, newInstrumentedClient(pollTimeout)),
 					bot.WithAllowedUpdates(bot.AllowedUpdates{
 						"message",
 						"callback_query",
 						"my_chat_member",
+						"chat_member",
 					}),
 					bot.WithMiddlewares(
+						trace.NewMiddleware(),
+						signature.NewMiddleware(signer, log),
 						user.NewMiddleware(usersSvc, log),
 						state.NewMiddleware(stateSvc, log),
 						callback.NewMiddleware(log),
@@ -36,6 +49,8 @@ func Module() fx.Option {
 				}
 			},
 		),
+		admins.Module(),
+		access.Module(),
 		handlers.Module(),
 		fx.Invoke(func(lc fx.Lifecycle, b *gotelegrambotfx.Bot, log *zap.Logger) {
 			lc.Append(fx.Hook{
@@ -0,0 +1,131 @@
+package posts
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/keyboards"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/markdown"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// supergroupPrefix is the prefix of the supergroup IDs, which is dropped in message links.
+const supergroupPrefix = "-100"
+
+// Caption returns the MarkdownV2 caption of the giveaway post, draw is the rule of the draw
+// as returned by giveaways.Service.DescribeDraw.
+func Caption(giveaway *giveaways.Giveaway, draw string) string {
+	caption := bot.EscapeMarkdown(giveaway.Description) + "\n\n"
+	if !giveaway.IsAnonymous {
+		caption += fmt.Sprintf(
+			"*Организатор*: %s\n",
+			markdown.UserMention(giveaway.AdminTelegramID, giveaway.AdminUsername, giveaway.AdminFirstName),
+		)
+	}
+	caption += fmt.Sprintf(
+		"*Завершение*: %s\n*Итоги*: %s",
+		bot.EscapeMarkdown(giveaway.ApplicationEndDate.Format("02.01.2006 15:04")),
+		bot.EscapeMarkdown(giveaway.ResultsDate.Format("02.01.2006 15:04")),
+	)
+	if draw != "" {
+		caption += "\n*Выбор победителя*: " + bot.EscapeMarkdown(draw)
+	}
+	if rules := FormatTicketRules(giveaway.TicketRules); rules != "" {
+		caption += "\n\n*Дополнительные билеты*:\n" + rules
+	}
+	if quiz := giveaway.Quiz; quiz != nil {
+		caption += "\n\n❓ *Вопрос*: " + bot.EscapeMarkdown(quiz.Question) + "\n" +
+			bot.EscapeMarkdown("Чтобы участвовать, ответьте боту в личных сообщениях. Принимается один ответ.")
+	}
+
+	return caption
+}
+
+// ClosedCaption returns the caption of the post after applications are closed.
+// Anonymous giveaways don't reveal the number of participants.
+func ClosedCaption(giveaway *giveaways.Giveaway, draw string, participantsCount int) string {
+	caption := Caption(giveaway, draw) + "\n\n🔒 *Приём заявок завершён*"
+	if !giveaway.IsAnonymous {
+		caption += fmt.Sprintf("\nУчастников: %d", participantsCount)
+	}
+
+	return caption
+}
+
+// FinishedCaption returns the caption of the post after the draw.
+func FinishedCaption(winner giveaways.Winner, draw string) string {
+	caption := Caption(&winner.Giveaway, draw) + "\n\n"
+
+	switch {
+	case winner.Participant == nil:
+		reason := "недостаточно участников."
+		switch {
+		case errors.Is(winner.Reason, giveaways.ErrCancelled):
+			reason = "решение организатора."
+		case errors.Is(winner.Reason, giveaways.ErrAllParticipantsExcluded):
+			reason = "все участники недавно побеждали в розыгрышах группы."
+		case errors.Is(winner.Reason, giveaways.ErrAllParticipantsSuspicious):
+			reason = "все участники исключены проверкой на накрутку."
+		case errors.Is(winner.Reason, giveaways.ErrNoCorrectAnswers):
+			reason = "правильных ответов не оказалось."
+		}
+		caption += "❌ *Розыгрыш отменён*: " + bot.EscapeMarkdown(reason)
+	case winner.Giveaway.IsAnonymous:
+		caption += "🏆 *Выигрышный билет*: №" + bot.EscapeMarkdown(winner.Participant.Ticket())
+	default:
+		caption += "🏆 *Победитель*: " + markdown.UserMention(
+			winner.Participant.UserTelegramID,
+			winner.Participant.UserUsername,
+			winner.Participant.UserFirstName,
+		)
+	}
+
+	return caption
+}
+
+// Keyboard returns the keyboard of the published giveaway: the participation button or, for quizzes,
+// the deep link to answer in the private chat with the bot.
+func Keyboard(giveaway *giveaways.Giveaway, botUsername string, participantsCount int) *models.InlineKeyboardMarkup {
+	if giveaway.Quiz != nil {
+		return keyboards.QuizKeyboard(
+			fmt.Sprintf("https://t.me/%s?start=%s", botUsername, giveaways.QuizCode(giveaway.ID)),
+			participantsCount,
+		)
+	}
+
+	return keyboards.ParticipateKeyboard(giveaway.ID, participantsCount)
+}
+
+// MessageLink returns the link to the message in the group, empty if the group doesn't support links,
+// i.e. it isn't a supergroup.
+func MessageLink(chatID int64, messageID int) string {
+	id := strconv.FormatInt(chatID, 10)
+	if !strings.HasPrefix(id, supergroupPrefix) {
+		return ""
+	}
+
+	return fmt.Sprintf("https://t.me/c/%s/%d", strings.TrimPrefix(id, supergroupPrefix), messageID)
+}
+
+// FormatTicketRules returns the bonus ticket rules as MarkdownV2 lines, empty if no bonus tickets are granted.
+func FormatTicketRules(rules giveaways.TicketRules) string {
+	lines := make([]string, 0, 3)
+	if rules.ReferralBonus > 0 {
+		lines = append(lines, fmt.Sprintf("• \\+%d за каждого приглашённого участника", rules.ReferralBonus))
+	}
+	if rules.MembershipDays > 0 && rules.MembershipBonus > 0 {
+		lines = append(
+			lines,
+			fmt.Sprintf("• \\+%d участникам группы от %d дней", rules.MembershipBonus, rules.MembershipDays),
+		)
+	}
+	if rules.LoyaltyBonus > 0 {
+		lines = append(lines, fmt.Sprintf("• \\+%d за каждый розыгрыш без победы", rules.LoyaltyBonus))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,113 @@
+package wizard
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/adaptor"
+)
+
+// InvalidError is an answer rejected by a step. The message is shown to the user.
+type InvalidError struct {
+	Message string
+}
+
+func (e *InvalidError) Error() string {
+	return "invalid answer: " + e.Message
+}
+
+// Invalid rejects an answer with the message for the user.
+func Invalid(message string) error {
+	return &InvalidError{Message: message}
+}
+
+// Text builds a typed text handler: parse converts the text, validators check the value and set stores it.
+// Errors of parse and validators that are not Invalid are shown as a generic hint.
+func Text[T, V any](
+	parse func(string) (V, error),
+	set func(*T, V),
+	validators ...func(V) error,
+) func(*adaptor.Context, string, *T) error {
+	return func(_ *adaptor.Context, text string, data *T) error {
+		value, err := parse(strings.TrimSpace(text))
+		if err != nil {
+			return asInvalid(err)
+		}
+
+		for _, validate := range validators {
+			if err = validate(value); err != nil {
+				return asInvalid(err)
+			}
+		}
+
+		set(data, value)
+		return nil
+	}
+}
+
+func asInvalid(err error) error {
+	if invalid := new(InvalidError); errors.As(err, &invalid) {
+		return invalid
+	}
+
+	return Invalid("❌ Invalid value. Please try again.")
+}
+
+// String accepts any non-empty text.
+func String(text string) (string, error) {
+	if text == "" {
+		return "", Invalid("❌ Please send a text.")
+	}
+
+	return text, nil
+}
+
+// Int returns a parser of integers rejecting other input with the message.
+func Int(message string) func(string) (int, error) {
+	return func(text string) (int, error) {
+		v, err := strconv.Atoi(text)
+		if err != nil {
+			return 0, Invalid(message)
+		}
+
+		return v, nil
+	}
+}
+
+// Time returns a parser of times in the layout rejecting other input with the message.
+func Time(layout, message string) func(string) (time.Time, error) {
+	return func(text string) (time.Time, error) {
+		v, err := time.Parse(layout, text)
+		if err != nil {
+			return time.Time{}, Invalid(message)
+		}
+
+		return v, nil
+	}
+}
+
+// MaxLength limits the length of a text in characters.
+func MaxLength(n int) func(string) error {
+	return func(text string) error {
+		if utf8.RuneCountInString(text) > n {
+			return Invalid(fmt.Sprintf("❌ The text is too long, the limit is %d characters.", n))
+		}
+
+		return nil
+	}
+}
+
+// Range limits an integer to [minValue, maxValue].
+func Range(minValue, maxValue int) func(int) error {
+	return func(v int) error {
+		if v < minValue || v > maxValue {
+			return Invalid(fmt.Sprintf("❌ Please send a number from %d to %d.", minValue, maxValue))
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,78 @@
+package wizard
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/fsm"
+)
+
+// dataKey is the key of the session in the state data.
+const dataKey = "wizard"
+
+var ErrUnknownStep = errors.New("unknown step")
+
+// session is the progress of a wizard kept in the user state.
+type session[T any] struct {
+	step int
+
+	// History holds the names of the answered steps for the back button.
+	History []string `json:"history"`
+	Data    T        `json:"data"`
+}
+
+func (w *Wizard[T]) load(st *fsm.State) (*session[T], error) {
+	name := strings.TrimPrefix(st.Name, w.prefix())
+	step := w.index(name)
+	if step < 0 {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownStep, name)
+	}
+
+	s := new(session[T])
+	if raw := st.GetData(dataKey); raw != "" {
+		if err := json.Unmarshal([]byte(raw), s); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal session: %w", err)
+		}
+	}
+	s.step = step
+
+	return s, nil
+}
+
+func (w *Wizard[T]) save(st *fsm.State, s *session[T]) error {
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	st.SetName(w.prefix() + w.flow.Steps[s.step].Name)
+	st.AddData(dataKey, string(raw))
+
+	return nil
+}
+
+func (w *Wizard[T]) index(name string) int {
+	for i, step := range w.flow.Steps {
+		if step.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// back returns to the last answered step. It reports false on the first step.
+func (s *session[T]) back(index func(string) int) bool {
+	for len(s.History) > 0 {
+		name := s.History[len(s.History)-1]
+		s.History = s.History[:len(s.History)-1]
+		if i := index(name); i >= 0 {
+			s.step = i
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,67 @@
+package wizard
+
+import (
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/adaptor"
+	"github.com/go-telegram/bot/models"
+)
+
+// Transition tells the wizard where to go after an answer.
+type Transition int
+
+const (
+	// Next moves to the next step, or finishes the wizard after the last one.
+	Next Transition = iota
+	// Stay shows the prompt of the current step again, e.g. after a toggle.
+	Stay
+)
+
+// Option is a button answering a choice step.
+type Option struct {
+	Text  string
+	Value string
+}
+
+// Prompt is the question of a step.
+type Prompt struct {
+	Text      string
+	ParseMode models.ParseMode
+	// PhotoID sends the prompt as a photo with the text as caption.
+	PhotoID string
+	// Options are rows of buttons answering the step. Navigation buttons are added by the wizard.
+	Options [][]Option
+}
+
+// Photo is an answer with a photo.
+type Photo struct {
+	FileID  string
+	Caption string
+}
+
+// Step is a single question of a wizard over the data T.
+//
+// A step accepts the kinds of answers it has handlers for. A validation error is returned as Invalid
+// and keeps the user on the step.
+type Step[T any] struct {
+	// Name identifies the step in the state and callback data.
+	Name string
+	// Prompt renders the question, see Static for a fixed text.
+	Prompt func(ctx *adaptor.Context, data *T) (Prompt, error)
+	// Auto answers the step without asking, e.g. when there is a single option. Optional.
+	Auto func(ctx *adaptor.Context, data *T) (bool, error)
+	// Optional adds a skip button leaving the data as is.
+	Optional bool
+
+	OnText   func(ctx *adaptor.Context, text string, data *T) error
+	OnPhoto  func(ctx *adaptor.Context, photo Photo, data *T) error
+	OnChoice func(ctx *adaptor.Context, value string, data *T) (Transition, error)
+
+	// After runs once the answer is accepted, before the next step. Optional.
+	After func(ctx *adaptor.Context, data *T) error
+}
+
+// Static returns a prompt with the fixed text.
+func Static[T any](text string) func(*adaptor.Context, *T) (Prompt, error) {
+	return func(*adaptor.Context, *T) (Prompt, error) {
+		return Prompt{Text: text, ParseMode: "", PhotoID: "", Options: nil}, nil
+	}
+}
@@ -0,0 +1,442 @@
+package wizard
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/adaptor"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/filter"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/handler"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/keyboards"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/middlewares/state"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/fsm"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx/extractors"
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+const (
+	callbackPrefix = "wz:"
+
+	// ContinueData is the callback data of a button showing the current step of any wizard again,
+	// e.g. after a draft is restored.
+	ContinueData = callbackPrefix + "continue"
+
+	actionBack   = "back"
+	actionSkip   = "skip"
+	actionCancel = "cancel"
+	actionPick   = "pick"
+)
+
+// Flow declares a wizard over the data T.
+type Flow[T any] struct {
+	// Name is the state prefix and the callback namespace of the wizard, keep it short.
+	Name string
+	// Command starts the wizard in a private chat, e.g. "giveaway". Optional.
+	Command string
+	// Steps are asked in order.
+	Steps []Step[T]
+
+	// Start prepares the data before the first step. Invalid aborts the wizard with the message. Optional.
+	Start func(ctx *adaptor.Context, data *T) error
+	// Finish completes the wizard after the last step. On error the user stays on the last step.
+	Finish func(ctx *adaptor.Context, update *models.Update, data *T) error
+}
+
+// Wizard runs a Flow: it stores the progress in the user state, shows the prompts with navigation buttons
+// and dispatches the answers to the current step.
+type Wizard[T any] struct {
+	handler.BaseHandler
+
+	flow Flow[T]
+}
+
+func New[T any](bot *gotelegrambotfx.Bot, flow Flow[T], logger *zap.Logger) *Wizard[T] {
+	return &Wizard[T]{
+		BaseHandler: handler.BaseHandler{
+			Bot:    bot,
+			Logger: logger.With(zap.String("wizard", flow.Name)),
+		},
+
+		flow: flow,
+	}
+}
+
+// FSMFlow describes the wizard to the fsm service, so its expired sessions are kept as drafts.
+func FSMFlow(name, title string) fsm.Flow {
+	return fsm.Flow{
+		Prefix: name + ":",
+		Title:  title,
+	}
+}
+
+// Register implements handler.Handler.
+func (w *Wizard[T]) Register(b *gotelegrambotfx.Bot) {
+	inFlow := state.NewStatePrefixFilter(w.prefix())
+
+	if w.flow.Command != "" {
+		b.RegisterHandlerMatchFunc(
+			filter.And(state.NewStateFilter(""), isCommand("/"+w.flow.Command)),
+			adaptor.New(w.handleStart),
+		)
+	}
+
+	b.RegisterHandlerMatchFunc(filter.And(inFlow, isCallback(w.callbackPrefix())), adaptor.New(w.handleCallback))
+	b.RegisterHandlerMatchFunc(filter.And(inFlow, isCallback(ContinueData)), adaptor.New(w.handleContinue))
+	b.RegisterHandlerMatchFunc(filter.And(inFlow, isAnswer), adaptor.New(w.handleMessage))
+}
+
+func (w *Wizard[T]) prefix() string {
+	return w.flow.Name + ":"
+}
+
+func (w *Wizard[T]) callbackPrefix() string {
+	return callbackPrefix + w.flow.Name + ":"
+}
+
+func (w *Wizard[T]) callbackData(step, action, value string) string {
+	data := w.callbackPrefix() + step + ":" + action
+	if value != "" {
+		data += ":" + value
+	}
+
+	return data
+}
+
+func isCommand(command string) bot.MatchFunc {
+	return func(update *models.Update) bool {
+		if update.Message == nil {
+			return false
+		}
+
+		text := update.Message.Text
+		return text == command || strings.HasPrefix(text, command+"@")
+	}
+}
+
+func isCallback(prefix string) bot.MatchFunc {
+	return func(update *models.Update) bool {
+		return update.CallbackQuery != nil && strings.HasPrefix(update.CallbackQuery.Data, prefix)
+	}
+}
+
+// isAnswer matches private messages except commands, so /cancel and others reach their handlers.
+func isAnswer(update *models.Update) bool {
+	return update.Message != nil &&
+		update.Message.Chat.Type == models.ChatTypePrivate &&
+		!strings.HasPrefix(update.Message.Text, "/")
+}
+
+func (w *Wizard[T]) handleStart(ctx *adaptor.Context, update *models.Update) {
+	if update.Message.Chat.Type != models.ChatTypePrivate {
+		w.SendReply(ctx, update, &bot.SendMessageParams{Text: "❌ This command is only available in private chats."})
+		return
+	}
+
+	st, err := ctx.State()
+	if err != nil {
+		w.HandleError(ctx, update, err)
+		return
+	}
+
+	s := new(session[T])
+	if w.flow.Start != nil {
+		if err = w.flow.Start(ctx, &s.Data); err != nil {
+			w.fail(ctx, update, err)
+			return
+		}
+	}
+
+	w.enter(ctx, update, st, s)
+}
+
+// Begin starts the wizard with the prepared data, e.g. to edit an existing item. Unlike the command,
+// it skips Flow.Start, the caller checks whatever is needed.
+func (w *Wizard[T]) Begin(ctx *adaptor.Context, update *models.Update, data T) {
+	st, err := ctx.State()
+	if err != nil {
+		w.HandleError(ctx, update, err)
+		return
+	}
+
+	s := new(session[T])
+	s.Data = data
+
+	w.enter(ctx, update, st, s)
+}
+
+func (w *Wizard[T]) handleMessage(ctx *adaptor.Context, update *models.Update) {
+	st, s, ok := w.session(ctx, update)
+	if !ok {
+		return
+	}
+
+	step := w.flow.Steps[s.step]
+	msg := update.Message
+
+	var err error
+	switch {
+	case len(msg.Photo) > 0 && step.OnPhoto != nil:
+		photo := lo.MaxBy(msg.Photo, func(a, b models.PhotoSize) bool {
+			return a.FileSize > b.FileSize
+		})
+		err = step.OnPhoto(ctx, Photo{FileID: photo.FileID, Caption: msg.Caption}, &s.Data)
+	case msg.Text != "" && step.OnText != nil:
+		err = step.OnText(ctx, msg.Text, &s.Data)
+	default:
+		err = Invalid(hint(step))
+	}
+
+	if err != nil {
+		w.fail(ctx, update, err)
+		return
+	}
+
+	w.forward(ctx, update, st, s)
+}
+
+func (w *Wizard[T]) handleCallback(ctx *adaptor.Context, update *models.Update) {
+	st, s, ok := w.session(ctx, update)
+	if !ok {
+		return
+	}
+
+	// step:action[:value]
+	data := strings.TrimPrefix(update.CallbackQuery.Data, w.callbackPrefix())
+	parts := strings.SplitN(data, ":", 3) //nolint:mnd // step, action, value
+	stepName, action, value := parts[0], "", ""
+	if len(parts) > 1 {
+		action = parts[1]
+	}
+	if len(parts) > 2 { //nolint:mnd // with value
+		value = parts[2]
+	}
+
+	if action == actionCancel {
+		st.Clear()
+		w.SendReply(ctx, update, &bot.SendMessageParams{Text: "🔄 Operation cancelled."})
+		return
+	}
+
+	step := w.flow.Steps[s.step]
+	if stepName != step.Name {
+		w.SendReply(ctx, update, &bot.SendMessageParams{Text: "⌛ This button is outdated."})
+		return
+	}
+
+	switch action {
+	case actionBack:
+		if !s.back(w.index) {
+			return
+		}
+		w.stay(ctx, update, st, s)
+	case actionSkip:
+		if !step.Optional {
+			return
+		}
+		w.forward(ctx, update, st, s)
+	case actionPick:
+		if step.OnChoice == nil {
+			return
+		}
+
+		transition, err := step.OnChoice(ctx, value, &s.Data)
+		if err != nil {
+			w.fail(ctx, update, err)
+			return
+		}
+
+		if transition == Stay {
+			w.stay(ctx, update, st, s)
+			return
+		}
+		w.forward(ctx, update, st, s)
+	}
+}
+
+func (w *Wizard[T]) handleContinue(ctx *adaptor.Context, update *models.Update) {
+	_, s, ok := w.session(ctx, update)
+	if !ok {
+		return
+	}
+
+	w.prompt(ctx, update, s)
+}
+
+// session loads the state and the wizard progress. Outdated progress is reset.
+func (w *Wizard[T]) session(ctx *adaptor.Context, update *models.Update) (*fsm.State, *session[T], bool) {
+	st, err := ctx.State()
+	if err != nil {
+		w.HandleError(ctx, update, err)
+		return nil, nil, false
+	}
+
+	s, err := w.load(st)
+	if errors.Is(err, ErrUnknownStep) {
+		w.WithContext(ctx, update).Warn("resetting outdated wizard state", zap.Error(err))
+		st.Clear()
+		w.SendReply(ctx, update, &bot.SendMessageParams{Text: "❌ This operation is outdated. Please start over."})
+		return nil, nil, false
+	}
+	if err != nil {
+		w.HandleError(ctx, update, err)
+		return nil, nil, false
+	}
+
+	return st, s, true
+}
+
+// forward completes the current step and moves to the next one.
+func (w *Wizard[T]) forward(ctx *adaptor.Context, update *models.Update, st *fsm.State, s *session[T]) {
+	step := w.flow.Steps[s.step]
+	if step.After != nil {
+		if err := step.After(ctx, &s.Data); err != nil {
+			w.fail(ctx, update, err)
+			return
+		}
+	}
+
+	if s.step == len(w.flow.Steps)-1 {
+		w.finish(ctx, update, st, s)
+		return
+	}
+
+	s.History = append(s.History, step.Name)
+	s.step++
+	w.enter(ctx, update, st, s)
+}
+
+// enter shows the current step, answering automatic steps on the way.
+func (w *Wizard[T]) enter(ctx *adaptor.Context, update *models.Update, st *fsm.State, s *session[T]) {
+	for ; s.step < len(w.flow.Steps); s.step++ {
+		auto := w.flow.Steps[s.step].Auto
+		if auto == nil {
+			break
+		}
+
+		ok, err := auto(ctx, &s.Data)
+		if err != nil {
+			w.fail(ctx, update, err)
+			return
+		}
+		if !ok {
+			break
+		}
+	}
+
+	if s.step == len(w.flow.Steps) {
+		s.step--
+		w.finish(ctx, update, st, s)
+		return
+	}
+
+	w.stay(ctx, update, st, s)
+}
+
+// stay saves the progress and shows the current step.
+func (w *Wizard[T]) stay(ctx *adaptor.Context, update *models.Update, st *fsm.State, s *session[T]) {
+	if err := w.save(st, s); err != nil {
+		w.HandleError(ctx, update, err)
+		return
+	}
+
+	w.prompt(ctx, update, s)
+}
+
+func (w *Wizard[T]) finish(ctx *adaptor.Context, update *models.Update, st *fsm.State, s *session[T]) {
+	if err := w.flow.Finish(ctx, update, &s.Data); err != nil {
+		if saveErr := w.save(st, s); saveErr != nil {
+			w.WithContext(ctx, update).Error("failed to save wizard state", zap.Error(saveErr))
+		}
+		w.fail(ctx, update, err)
+		return
+	}
+
+	st.Clear()
+}
+
+func (w *Wizard[T]) prompt(ctx *adaptor.Context, update *models.Update, s *session[T]) {
+	step := w.flow.Steps[s.step]
+	p, err := step.Prompt(ctx, &s.Data)
+	if err != nil {
+		w.fail(ctx, update, fmt.Errorf("failed to render prompt of %q: %w", step.Name, err))
+		return
+	}
+
+	markup := w.keyboard(step, p.Options, len(s.History) > 0)
+	chatID := extractors.From(update)
+
+	if p.PhotoID == "" {
+		w.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        p.Text,
+			ParseMode:   p.ParseMode,
+			ReplyMarkup: markup,
+		})
+		return
+	}
+
+	if _, err = w.Bot.SendPhoto(ctx, &bot.SendPhotoParams{
+		ChatID:      chatID,
+		Photo:       &models.InputFileString{Data: p.PhotoID},
+		Caption:     p.Text,
+		ParseMode:   p.ParseMode,
+		ReplyMarkup: markup,
+	}); err != nil {
+		w.WithContext(ctx, update).Error("failed to send prompt", zap.Error(err))
+	}
+}
+
+func (w *Wizard[T]) keyboard(step Step[T], options [][]Option, canBack bool) *models.InlineKeyboardMarkup {
+	rows := lo.Map(options, func(row []Option, _ int) []models.InlineKeyboardButton {
+		return lo.Map(row, func(option Option, _ int) models.InlineKeyboardButton {
+			return models.InlineKeyboardButton{
+				Text:         option.Text,
+				CallbackData: w.callbackData(step.Name, actionPick, option.Value),
+			}
+		})
+	})
+
+	nav := make([]models.InlineKeyboardButton, 0, 3) //nolint:mnd // back, skip, cancel
+	if canBack {
+		nav = append(nav, keyboards.BackButton(w.callbackData(step.Name, actionBack, "")))
+	}
+	if step.Optional {
+		nav = append(nav, models.InlineKeyboardButton{
+			Text:         "⏭ Skip",
+			CallbackData: w.callbackData(step.Name, actionSkip, ""),
+		})
+	}
+	nav = append(nav, models.InlineKeyboardButton{
+		Text:         "❌ Cancel",
+		CallbackData: w.callbackData(step.Name, actionCancel, ""),
+	})
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: append(rows, nav)}
+}
+
+// fail shows Invalid errors to the user and reports others.
+func (w *Wizard[T]) fail(ctx *adaptor.Context, update *models.Update, err error) {
+	var invalid *InvalidError
+	if errors.As(err, &invalid) {
+		w.SendReply(ctx, update, &bot.SendMessageParams{Text: invalid.Message})
+		return
+	}
+
+	w.HandleError(ctx, update, err)
+}
+
+func hint[T any](step Step[T]) string {
+	switch {
+	case step.OnPhoto != nil:
+		return "❌ Please send a photo."
+	case step.OnText != nil:
+		return "❌ Please send a text."
+	default:
+		return "👆 Please use the buttons above."
+	}
+}
@@ -1,8 +1,12 @@
 package config
 
 import (
+	"github.com/capcom6/lucky-pick-tg-bot/internal/actions"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/discussions"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/fsm"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/tracing"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/webhooks"
 	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
 	"github.com/go-core-fx/cachefx"
 	"github.com/go-core-fx/fiberfx"
@@ -43,7 +47,8 @@ func Module() fx.Option {
 		fx.Provide(
 			func(cfg Config) gotelegrambotfx.Config {
 				return gotelegrambotfx.Config{
-					Token: cfg.Telegram.Token,
+					Token:          cfg.Telegram.Token,
+					CallbackSecret: cfg.Telegram.CallbackSecret,
 				}
 			},
 		),
@@ -64,6 +69,14 @@ func Module() fx.Option {
 			},
 		),
 		//
+		fx.Provide(
+			func(cfg Config) actions.Config {
+				return actions.Config{
+					Retention: cfg.Actions.Retention,
+					Archive:   cfg.Actions.Archive,
+				}
+			},
+		),
 		fx.Provide(
 			func(cfg Config) giveaways.Config {
 				return giveaways.Config{
@@ -78,5 +91,31 @@ func Module() fx.Option {
 				}
 			},
 		),
+		fx.Provide(
+			func(cfg Config) webhooks.Config {
+				return webhooks.Config{
+					Timeout:     cfg.Webhooks.Timeout,
+					MaxAttempts: cfg.Webhooks.MaxAttempts,
+				}
+			},
+		),
+		fx.Provide(
+			func(cfg Config) fsm.Config {
+				return fsm.Config{
+					TTL:       cfg.FSM.TTL,
+					DraftsTTL: cfg.FSM.DraftsTTL,
+				}
+			},
+		),
+		fx.Provide(
+			func(cfg Config) tracing.Config {
+				return tracing.Config{
+					Enabled:     cfg.Tracing.Enabled,
+					Endpoint:    cfg.Tracing.Endpoint,
+					ServiceName: cfg.Tracing.ServiceName,
+					SampleRatio: cfg.Tracing.SampleRatio,
+				}
+			},
+		),
 	)
 }
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `users`
+ADD COLUMN `referrer_id` BIGINT UNSIGNED NULL,
+    ADD CONSTRAINT `fk_users_referrer` FOREIGN KEY (`referrer_id`) REFERENCES `users`(`id`) ON DELETE
+SET NULL;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `giveaways`
+ADD COLUMN `referral_bonus` INT UNSIGNED NOT NULL DEFAULT 0,
+    ADD COLUMN `membership_days` INT UNSIGNED NOT NULL DEFAULT 0,
+    ADD COLUMN `membership_bonus` INT UNSIGNED NOT NULL DEFAULT 0,
+    ADD COLUMN `loyalty_bonus` INT UNSIGNED NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `giveaway_participants`
+ADD COLUMN `tickets` INT UNSIGNED NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+---
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `giveaway_participants` DROP COLUMN `tickets`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `giveaways` DROP COLUMN `loyalty_bonus`,
+    DROP COLUMN `membership_bonus`,
+    DROP COLUMN `membership_days`,
+    DROP COLUMN `referral_bonus`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `users` DROP FOREIGN KEY `fk_users_referrer`,
+    DROP COLUMN `referrer_id`;
+-- +goose StatementEnd
\ No newline at end of file
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `giveaways`
+ADD COLUMN `published_at` DATETIME NULL;
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TABLE `fraud_flags` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    `giveaway_id` BIGINT UNSIGNED NOT NULL,
+    `group_id` BIGINT UNSIGNED NOT NULL,
+    `user_id` BIGINT UNSIGNED NOT NULL,
+    `score` TINYINT UNSIGNED NOT NULL,
+    `reasons` VARCHAR(255) NOT NULL,
+    `status` ENUM(
+        'pending',
+        'confirmed',
+        'dismissed'
+    ) NOT NULL DEFAULT 'pending',
+    `reviewed_by` BIGINT UNSIGNED NULL,
+    `reviewed_at` DATETIME NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY `unique_fraud_flag` (`giveaway_id`, `user_id`),
+    INDEX `idx_group_status` (`group_id`, `status`),
+    FOREIGN KEY (`giveaway_id`) REFERENCES `giveaways`(`id`) ON DELETE CASCADE,
+    FOREIGN KEY (`group_id`) REFERENCES `groups`(`id`) ON DELETE CASCADE,
+    FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE RESTRICT,
+    FOREIGN KEY (`reviewed_by`) REFERENCES `users`(`id`) ON DELETE
+    SET NULL
+);
+-- +goose StatementEnd
+---
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `fraud_flags`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `giveaways` DROP COLUMN `published_at`;
+-- +goose StatementEnd
\ No newline at end of file
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `action_logs`
+ADD COLUMN `group_id` BIGINT UNSIGNED NULL
+AFTER `id`,
+    ADD COLUMN `payload` JSON NULL
+AFTER `description`,
+    ADD CONSTRAINT `fk_action_logs_group` FOREIGN KEY (`group_id`) REFERENCES `groups`(`id`) ON DELETE
+SET NULL,
+    ADD INDEX `idx_group_created` (`group_id`, `created_at`);
+-- +goose StatementEnd
+-- +goose StatementBegin
+UPDATE `action_logs` AS `al`
+    JOIN `giveaways` AS `ga` ON `ga`.`id` = `al`.`giveaway_id`
+SET `al`.`group_id` = `ga`.`group_id`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TABLE `action_logs_archive` LIKE `action_logs`;
+-- +goose StatementEnd
+---
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `action_logs_archive`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `action_logs` DROP FOREIGN KEY `fk_action_logs_group`,
+    DROP INDEX `idx_group_created`,
+    DROP COLUMN `payload`,
+    DROP COLUMN `group_id`;
+-- +goose StatementEnd
\ No newline at end of file
@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE `webhooks` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    `group_id` BIGINT UNSIGNED NOT NULL,
+    `url` VARCHAR(2048) NOT NULL,
+    `secret` VARCHAR(64) NOT NULL,
+    `events` VARCHAR(255) NOT NULL,
+    `created_by` BIGINT UNSIGNED NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX `idx_group` (`group_id`),
+    FOREIGN KEY (`group_id`) REFERENCES `groups`(`id`) ON DELETE CASCADE,
+    FOREIGN KEY (`created_by`) REFERENCES `users`(`id`) ON DELETE
+    SET NULL
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TABLE `webhook_deliveries` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    `webhook_id` BIGINT UNSIGNED NOT NULL,
+    `event` VARCHAR(64) NOT NULL,
+    `payload` JSON NOT NULL,
+    `status` ENUM(
+        'pending',
+        'delivered',
+        'failed'
+    ) NOT NULL DEFAULT 'pending',
+    `attempts` TINYINT UNSIGNED NOT NULL DEFAULT 0,
+    `response_code` SMALLINT UNSIGNED NULL,
+    `error` VARCHAR(255) NULL,
+    `next_attempt_at` DATETIME NOT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX `idx_status_next_attempt` (`status`, `next_attempt_at`),
+    INDEX `idx_webhook_created` (`webhook_id`, `created_at`),
+    FOREIGN KEY (`webhook_id`) REFERENCES `webhooks`(`id`) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+---
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `webhook_deliveries`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE `webhooks`;
+-- +goose StatementEnd
\ No newline at end of file
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE `fsm_states` (
+    `telegram_user_id` BIGINT NOT NULL PRIMARY KEY,
+    `name` VARCHAR(64) NOT NULL,
+    `data` JSON NOT NULL,
+    `expires_at` DATETIME NOT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX `idx_expires_at` (`expires_at`)
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TABLE `fsm_drafts` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    `telegram_user_id` BIGINT NOT NULL,
+    `name` VARCHAR(64) NOT NULL,
+    `data` JSON NOT NULL,
+    `notified_at` DATETIME NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX `idx_user` (`telegram_user_id`),
+    INDEX `idx_notified_at` (`notified_at`),
+    INDEX `idx_created_at` (`created_at`)
+);
+-- +goose StatementEnd
+---
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `fsm_drafts`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE `fsm_states`;
+-- +goose StatementEnd
\ No newline at end of file
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `fsm_states`
+ADD COLUMN `version` BIGINT UNSIGNED NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+---
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `fsm_states` DROP COLUMN `version`;
+-- +goose StatementEnd
\ No newline at end of file
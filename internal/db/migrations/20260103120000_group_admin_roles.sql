@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `group_admins`
+ADD COLUMN `role` ENUM(
+        'owner',
+        'admin'
+    ) NOT NULL DEFAULT 'admin',
+ADD COLUMN `can_manage_chat` BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN `can_delete_messages` BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN `can_restrict_members` BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN `can_pin_messages` BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN `can_invite_users` BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN `can_promote_members` BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN `synced_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP;
+-- +goose StatementEnd
+---
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `group_admins` DROP COLUMN `role`,
+    DROP COLUMN `can_manage_chat`,
+    DROP COLUMN `can_delete_messages`,
+    DROP COLUMN `can_restrict_members`,
+    DROP COLUMN `can_pin_messages`,
+    DROP COLUMN `can_invite_users`,
+    DROP COLUMN `can_promote_members`,
+    DROP COLUMN `synced_at`;
+-- +goose StatementEnd
\ No newline at end of file
@@ -0,0 +1,55 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE `group_roles` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    `group_id` BIGINT UNSIGNED NOT NULL,
+    `user_id` BIGINT UNSIGNED NOT NULL,
+    `role` ENUM(
+        'owner',
+        'manager',
+        'editor',
+        'viewer'
+    ) NOT NULL,
+    `granted_by` BIGINT UNSIGNED NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY `unique_group_role` (`group_id`, `user_id`),
+    INDEX `idx_user_id` (`user_id`),
+    FOREIGN KEY (`group_id`) REFERENCES `groups`(`id`) ON DELETE CASCADE,
+    FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE,
+    FOREIGN KEY (`granted_by`) REFERENCES `users`(`id`) ON DELETE
+    SET NULL
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TABLE `role_invites` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    `group_id` BIGINT UNSIGNED NOT NULL,
+    `role` ENUM(
+        'owner',
+        'manager',
+        'editor',
+        'viewer'
+    ) NOT NULL,
+    `code` VARCHAR(32) NOT NULL,
+    `created_by` BIGINT UNSIGNED NULL,
+    `expires_at` DATETIME NOT NULL,
+    `used_by` BIGINT UNSIGNED NULL,
+    `used_at` DATETIME NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY `unique_code` (`code`),
+    FOREIGN KEY (`group_id`) REFERENCES `groups`(`id`) ON DELETE CASCADE,
+    FOREIGN KEY (`created_by`) REFERENCES `users`(`id`) ON DELETE
+    SET NULL,
+    FOREIGN KEY (`used_by`) REFERENCES `users`(`id`) ON DELETE
+    SET NULL
+);
+-- +goose StatementEnd
+---
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `role_invites`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE `group_roles`;
+-- +goose StatementEnd
\ No newline at end of file
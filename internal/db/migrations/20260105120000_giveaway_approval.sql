@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `giveaways`
+MODIFY COLUMN `status` ENUM(
+        'pending_approval',
+        'scheduled',
+        'active',
+        'closed',
+        'finished',
+        'cancelled'
+    ) DEFAULT 'scheduled';
+-- +goose StatementEnd
+---
+-- +goose Down
+-- +goose StatementBegin
+UPDATE `giveaways`
+SET `status` = 'cancelled'
+WHERE `status` = 'pending_approval';
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `giveaways`
+MODIFY COLUMN `status` ENUM(
+        'scheduled',
+        'active',
+        'closed',
+        'finished',
+        'cancelled'
+    ) DEFAULT 'scheduled';
+-- +goose StatementEnd
\ No newline at end of file
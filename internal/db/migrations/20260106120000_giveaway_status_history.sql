@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE `giveaway_status_history` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    `giveaway_id` BIGINT UNSIGNED NOT NULL,
+    `from_status` ENUM(
+        'pending_approval',
+        'scheduled',
+        'active',
+        'closed',
+        'finished',
+        'cancelled'
+    ) NULL,
+    `to_status` ENUM(
+        'pending_approval',
+        'scheduled',
+        'active',
+        'closed',
+        'finished',
+        'cancelled'
+    ) NOT NULL,
+    `actor_user_id` BIGINT UNSIGNED NULL,
+    `reason` VARCHAR(255) NOT NULL DEFAULT '',
+    `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX `idx_giveaway_id` (`giveaway_id`, `id`),
+    FOREIGN KEY (`giveaway_id`) REFERENCES `giveaways`(`id`) ON DELETE CASCADE,
+    FOREIGN KEY (`actor_user_id`) REFERENCES `users`(`id`) ON DELETE
+    SET NULL
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+INSERT INTO `giveaway_status_history` (`giveaway_id`, `to_status`, `reason`, `created_at`)
+SELECT `id`,
+    `status`,
+    'status before history tracking',
+    `updated_at`
+FROM `giveaways`;
+-- +goose StatementEnd
+---
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `giveaway_status_history`;
+-- +goose StatementEnd
\ No newline at end of file
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE `giveaway_reminders` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    `giveaway_id` BIGINT UNSIGNED NOT NULL,
+    `kind` ENUM(
+        'application_end',
+        'results'
+    ) NOT NULL,
+    `offset_minutes` INT UNSIGNED NOT NULL,
+    `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY `unique_giveaway_reminder` (`giveaway_id`, `kind`, `offset_minutes`),
+    FOREIGN KEY (`giveaway_id`) REFERENCES `giveaways`(`id`) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+---
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `giveaway_reminders`;
+-- +goose StatementEnd
\ No newline at end of file
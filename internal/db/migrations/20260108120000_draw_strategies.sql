@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `giveaways`
+ADD COLUMN `draw_strategy` VARCHAR(32) NOT NULL DEFAULT 'weighted',
+    ADD COLUMN `draw_param` INT UNSIGNED NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `giveaway_discussions`
+ADD INDEX `idx_telegram_message_id` (`telegram_message_id`);
+-- +goose StatementEnd
+---
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `giveaway_discussions` DROP INDEX `idx_telegram_message_id`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `giveaways` DROP COLUMN `draw_param`,
+    DROP COLUMN `draw_strategy`;
+-- +goose StatementEnd
\ No newline at end of file
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `giveaways`
+ADD COLUMN `quiz_mode` ENUM(
+        'exact',
+        'case_insensitive',
+        'closest',
+        'range'
+    ) NULL,
+    ADD COLUMN `quiz_question` VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN `quiz_answers` VARCHAR(1024) NOT NULL DEFAULT '';
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TABLE `giveaway_quiz_answers` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    `giveaway_id` BIGINT UNSIGNED NOT NULL,
+    `user_id` BIGINT UNSIGNED NOT NULL,
+    `answer` VARCHAR(255) NOT NULL,
+    `created_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY `unique_giveaway_user` (`giveaway_id`, `user_id`),
+    FOREIGN KEY (`giveaway_id`) REFERENCES `giveaways`(`id`) ON DELETE CASCADE,
+    FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE RESTRICT
+);
+-- +goose StatementEnd
+---
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `giveaway_quiz_answers`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `giveaways` DROP COLUMN `quiz_answers`,
+    DROP COLUMN `quiz_question`,
+    DROP COLUMN `quiz_mode`;
+-- +goose StatementEnd
\ No newline at end of file
@@ -0,0 +1,76 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `giveaways`
+MODIFY COLUMN `status` ENUM(
+        'pending_approval',
+        'changes_requested',
+        'scheduled',
+        'active',
+        'closed',
+        'finished',
+        'cancelled'
+    ) DEFAULT 'scheduled';
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `giveaway_status_history`
+MODIFY COLUMN `from_status` ENUM(
+        'pending_approval',
+        'changes_requested',
+        'scheduled',
+        'active',
+        'closed',
+        'finished',
+        'cancelled'
+    ) NULL,
+    MODIFY COLUMN `to_status` ENUM(
+        'pending_approval',
+        'changes_requested',
+        'scheduled',
+        'active',
+        'closed',
+        'finished',
+        'cancelled'
+    ) NOT NULL;
+-- +goose StatementEnd
+---
+-- +goose Down
+-- +goose StatementBegin
+UPDATE `giveaways`
+SET `status` = 'cancelled'
+WHERE `status` = 'changes_requested';
+-- +goose StatementEnd
+-- +goose StatementBegin
+DELETE FROM `giveaway_status_history`
+WHERE `from_status` = 'changes_requested'
+    OR `to_status` = 'changes_requested';
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `giveaway_status_history`
+MODIFY COLUMN `from_status` ENUM(
+        'pending_approval',
+        'scheduled',
+        'active',
+        'closed',
+        'finished',
+        'cancelled'
+    ) NULL,
+    MODIFY COLUMN `to_status` ENUM(
+        'pending_approval',
+        'scheduled',
+        'active',
+        'closed',
+        'finished',
+        'cancelled'
+    ) NOT NULL;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `giveaways`
+MODIFY COLUMN `status` ENUM(
+        'pending_approval',
+        'scheduled',
+        'active',
+        'closed',
+        'finished',
+        'cancelled'
+    ) DEFAULT 'scheduled';
+-- +goose StatementEnd
\ No newline at end of file
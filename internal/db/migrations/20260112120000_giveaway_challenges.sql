@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE `giveaway_challenges` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    `giveaway_id` BIGINT UNSIGNED NOT NULL,
+    `user_id` BIGINT UNSIGNED NOT NULL,
+    `answer` TINYINT NOT NULL DEFAULT -1,
+    `attempts` INT UNSIGNED NOT NULL DEFAULT 0,
+    `locked_until` DATETIME NULL,
+    `expires_at` DATETIME NOT NULL,
+    UNIQUE KEY `unique_giveaway_user` (`giveaway_id`, `user_id`),
+    INDEX `idx_expires_at` (`expires_at`),
+    FOREIGN KEY (`giveaway_id`) REFERENCES `giveaways`(`id`) ON DELETE CASCADE,
+    FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+---
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `giveaway_challenges`;
+-- +goose StatementEnd
\ No newline at end of file
@@ -20,3 +20,10 @@ type Discussion struct {
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
 }
+
+// Answer is a message of a user replying to a question.
+type Answer struct {
+	UserID            int64
+	TelegramMessageID int64
+	Text              string
+}
@@ -0,0 +1,49 @@
+package discussions
+
+import (
+	"context"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
+	"github.com/samber/lo"
+)
+
+// AnsweredStrategy picks a random winner among the candidates who answered the discussion questions.
+type AnsweredStrategy struct {
+	discussions *Repository
+}
+
+func NewAnsweredStrategy(discussions *Repository) giveaways.DrawStrategy {
+	return &AnsweredStrategy{
+		discussions: discussions,
+	}
+}
+
+func (s *AnsweredStrategy) Name() string {
+	return "answered"
+}
+
+func (s *AnsweredStrategy) Label() string {
+	return "💬 Random among answers"
+}
+
+func (s *AnsweredStrategy) Describe(int) string {
+	return "случайный выбор среди ответивших на вопрос в обсуждении"
+}
+
+func (s *AnsweredStrategy) Param() *giveaways.DrawParam {
+	return nil
+}
+
+func (s *AnsweredStrategy) Draw(ctx context.Context, draw giveaways.Draw) (*giveaways.Participant, error) {
+	userIDs, err := s.discussions.ListAnswerers(ctx, draw.GiveawayID)
+	if err != nil {
+		return nil, err
+	}
+
+	answered := lo.SliceToMap(userIDs, func(id int64) (int64, struct{}) { return id, struct{}{} })
+
+	return giveaways.PickRandom(lo.Filter(draw.Candidates, func(item giveaways.Participant, _ int) bool {
+		_, ok := answered[item.UserID]
+		return ok
+	}))
+}
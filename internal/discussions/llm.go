@@ -10,11 +10,16 @@ import (
 	"strings"
 	"time"
 
+	"github.com/capcom6/lucky-pick-tg-bot/internal/metrics"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/tracing"
 	"github.com/revrost/go-openrouter"
 	"github.com/revrost/go-openrouter/jsonschema"
 	"go.uber.org/zap"
 )
 
+// llmFeature labels LLM metrics and spans of the package.
+const llmFeature = "discussion_question"
+
 const (
 	// GiveawayQuestionPrompt is a Russian-language prompt template for generating moderation questions
 	// about giveaway lots in a Telegram group. It contains placeholders:
@@ -104,16 +109,22 @@ func (s *LLM) MakeQuestion(ctx context.Context, description string, age time.Dur
 		},
 	}
 
-	res, err := s.client.CreateChatCompletion(ctx, request)
+	started := time.Now()
+	spanCtx, span := tracing.StartLLM(ctx, llmFeature, s.config.LLMModel)
+	res, err := s.client.CreateChatCompletion(spanCtx, request)
+	tracing.EndLLM(span, res.Usage, err)
+	metrics.ObserveLLM(llmFeature, s.config.LLMModel, started, res.Usage, err)
 	if err != nil {
 		return "", fmt.Errorf("failed to create chat completion: %w", err)
 	}
 
 	if len(res.Choices) == 0 {
+		metrics.LLMFailed(llmFeature, s.config.LLMModel)
 		return "", fmt.Errorf("%w: no choices returned", ErrLLMFailed)
 	}
 
 	if jsonErr := json.Unmarshal([]byte(res.Choices[0].Message.Content.Text), answer); jsonErr != nil {
+		metrics.LLMFailed(llmFeature, s.config.LLMModel)
 		return "", fmt.Errorf("failed to unmarshal answer: %w", jsonErr)
 	}
 
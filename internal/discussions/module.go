@@ -13,6 +13,7 @@ func Module() fx.Option {
 		fx.Provide(NewRepository, fx.Private),
 		fx.Provide(NewLLM, fx.Private),
 		fx.Provide(NewService),
+		fx.Provide(fx.Annotate(NewAnsweredStrategy, fx.ResultTags(`group:"draw_strategies"`))),
 		fx.Invoke(func(settingsSvc *settings.Service) {
 			for _, v := range SettingDefinitions() {
 				settingsSvc.RegisterDefinition(v)
@@ -111,3 +111,39 @@ func (r *Repository) SetTelegramID(ctx context.Context, id int64, telegramID int
 
 	return nil
 }
+
+// GetQuestion returns the question of the bot posted as the message in the group.
+func (r *Repository) GetQuestion(ctx context.Context, telegramGroupID, telegramMessageID int64) (*Discussion, error) {
+	discussion := new(discussionModel)
+	if err := r.db.NewSelect().
+		Model(discussion).
+		Join("JOIN `giveaways` AS ga ON ga.id = gd.giveaway_id").
+		Join("JOIN `groups` AS g ON g.id = ga.group_id").
+		Where("g.telegram_group_id = ?", telegramGroupID).
+		Where("gd.telegram_message_id = ?", telegramMessageID).
+		Where("gd.user_id IS NULL").
+		Limit(1).
+		Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+
+	return discussion.toDiscussion(), nil
+}
+
+// ListAnswerers returns the IDs of the users who answered the questions of the giveaway.
+func (r *Repository) ListAnswerers(ctx context.Context, giveawayID int64) ([]int64, error) {
+	userIDs := make([]int64, 0)
+	if err := r.db.NewSelect().
+		Model((*discussionModel)(nil)).
+		ColumnExpr("DISTINCT user_id").
+		Where("giveaway_id = ?", giveawayID).
+		Where("user_id IS NOT NULL").
+		Scan(ctx, &userIDs); err != nil {
+		return nil, fmt.Errorf("failed to list answerers: %w", err)
+	}
+
+	return userIDs, nil
+}
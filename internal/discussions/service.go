@@ -102,6 +102,26 @@ func (s *Service) SetTelegramID(ctx context.Context, id int64, telegramID int64)
 	return s.discussions.SetTelegramID(ctx, id, telegramID)
 }
 
+// Answer records the message of the user replying to a question of the bot.
+// It returns ErrNotFound if the message replies to something else.
+func (s *Service) Answer(ctx context.Context, telegramGroupID, replyToMessageID int64, answer Answer) error {
+	question, err := s.discussions.GetQuestion(ctx, telegramGroupID, replyToMessageID)
+	if err != nil {
+		return err
+	}
+
+	created, err := s.discussions.Create(ctx, DiscussionDraft{
+		GiveawayID: question.GiveawayID,
+		UserID:     answer.UserID,
+		Text:       answer.Text,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.discussions.SetTelegramID(ctx, created.ID, answer.TelegramMessageID)
+}
+
 func (s *Service) prepare(ctx context.Context) ([]giveaways.Giveaway, error) {
 	givs, err := s.giveawaysSvc.ListActive(ctx)
 	if err != nil {
@@ -0,0 +1,53 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// Bus delivers events to subscribers in-process.
+type Bus struct {
+	subscribers []Subscriber
+	mux         sync.RWMutex
+
+	logger *zap.Logger
+}
+
+func NewBus(logger *zap.Logger) *Bus {
+	return &Bus{
+		subscribers: []Subscriber{},
+		mux:         sync.RWMutex{},
+
+		logger: logger,
+	}
+}
+
+// Subscribe adds the subscriber to the bus.
+func (b *Bus) Subscribe(subscriber Subscriber) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	b.subscribers = append(b.subscribers, subscriber)
+}
+
+// Publish delivers the event to all subscribers in order of subscription.
+// Failures of subscribers are logged and don't affect the publisher or other subscribers.
+func (b *Bus) Publish(ctx context.Context, event Event) {
+	b.mux.RLock()
+	subscribers := b.subscribers
+	b.mux.RUnlock()
+
+	logger := b.logger.With(zap.String("event", event.Name()))
+	logger.Debug("publishing event", zap.Int("subscribers", len(subscribers)))
+
+	for _, subscriber := range subscribers {
+		if err := subscriber.Handle(ctx, event); err != nil {
+			logger.Error("failed to handle event",
+				zap.String("subscriber", subscriber.Name()),
+				zap.Error(err),
+			)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package events
+
+import "context"
+
+// Event is a domain event published after a state change is committed.
+type Event interface {
+	Name() string
+}
+
+// Subscriber reacts to published events. It ignores events it isn't interested in.
+type Subscriber interface {
+	Name() string
+	Handle(ctx context.Context, event Event) error
+}
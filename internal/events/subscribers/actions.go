@@ -0,0 +1,149 @@
+package subscribers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/actions"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/events"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
+)
+
+// ActionLog records giveaway lifecycle events in the action log.
+type ActionLog struct {
+	actionsSvc *actions.Service
+}
+
+func NewActionLog(actionsSvc *actions.Service) events.Subscriber {
+	return &ActionLog{
+		actionsSvc: actionsSvc,
+	}
+}
+
+func (s *ActionLog) Name() string {
+	return "ActionLog"
+}
+
+func (s *ActionLog) Handle(ctx context.Context, event events.Event) error {
+	switch e := event.(type) {
+	case giveaways.GiveawayCreated:
+		s.actionsSvc.Log(ctx, actions.Action{
+			Kind:        actions.KindGiveawayCreated,
+			UserID:      e.Giveaway.AdminUserID,
+			GroupID:     e.Giveaway.GroupID,
+			GiveawayID:  e.ID,
+			Description: "Create giveaway",
+			Payload: &actions.Payload{
+				NewStatus: string(giveaways.StatusScheduled),
+			},
+		})
+	case giveaways.GiveawaySubmitted:
+		description, oldStatus := "Submit giveaway for approval", ""
+		if e.Revised {
+			description, oldStatus = "Resubmit revised giveaway for approval", string(giveaways.StatusChangesRequested)
+		}
+		s.actionsSvc.Log(ctx, actions.Action{
+			Kind:        actions.KindGiveawaySubmitted,
+			UserID:      e.Giveaway.AdminUserID,
+			GroupID:     e.Giveaway.GroupID,
+			GiveawayID:  e.ID,
+			Description: description,
+			Payload: &actions.Payload{
+				OldStatus: oldStatus,
+				NewStatus: string(giveaways.StatusPendingApproval),
+			},
+		})
+	case giveaways.GiveawayReviewed:
+		s.logReview(ctx, e)
+	case giveaways.GiveawayPublished:
+		s.actionsSvc.Log(ctx, actions.Action{
+			Kind:        actions.KindGiveawayPublished,
+			GroupID:     e.Giveaway.GroupID,
+			GiveawayID:  e.Giveaway.ID,
+			Description: fmt.Sprintf("Publish giveaway with message ID %d", e.Giveaway.TelegramMessageID),
+			Payload: &actions.Payload{
+				OldStatus: string(giveaways.StatusScheduled),
+				NewStatus: string(giveaways.StatusActive),
+				MessageID: e.Giveaway.TelegramMessageID,
+			},
+		})
+	case giveaways.ParticipantJoined:
+		p := e.Participation
+		s.actionsSvc.Log(ctx, actions.Action{
+			Kind:        actions.KindGiveawayParticipated,
+			UserID:      p.Participant.UserID,
+			GroupID:     p.Giveaway.GroupID,
+			GiveawayID:  p.Giveaway.ID,
+			Description: fmt.Sprintf("Participate in giveaway with %d tickets", p.Participant.Tickets),
+			Payload:     &actions.Payload{Tickets: p.Participant.Tickets},
+		})
+	case giveaways.GiveawayClosed:
+		s.actionsSvc.Log(ctx, actions.Action{
+			Kind:        actions.KindGiveawayClosed,
+			GroupID:     e.Giveaway.GroupID,
+			GiveawayID:  e.Giveaway.ID,
+			Description: "Close giveaway",
+			Payload: &actions.Payload{
+				OldStatus: string(giveaways.StatusActive),
+				NewStatus: string(giveaways.StatusClosed),
+			},
+		})
+	case giveaways.GiveawayFinished:
+		s.actionsSvc.Log(ctx, actions.Action{
+			Kind:        actions.KindGiveawayFinished,
+			UserID:      e.Winner.Giveaway.WinnerUserID,
+			GroupID:     e.Winner.Giveaway.GroupID,
+			GiveawayID:  e.Winner.Giveaway.ID,
+			Description: "Finish giveaway",
+			Payload: &actions.Payload{
+				OldStatus:    string(giveaways.StatusClosed),
+				NewStatus:    string(giveaways.StatusFinished),
+				TargetUserID: e.Winner.Giveaway.WinnerUserID,
+			},
+		})
+	case giveaways.GiveawayCancelled:
+		s.actionsSvc.Log(ctx, actions.Action{
+			Kind:        actions.KindGiveawayCancelled,
+			UserID:      e.ActorID,
+			GroupID:     e.Winner.Giveaway.GroupID,
+			GiveawayID:  e.Winner.Giveaway.ID,
+			Description: fmt.Sprintf("Cancel giveaway: %s", e.Reason),
+			Payload: &actions.Payload{
+				OldStatus: string(e.From),
+				NewStatus: string(giveaways.StatusCancelled),
+			},
+		})
+	}
+
+	return nil
+}
+
+func (s *ActionLog) logReview(ctx context.Context, e giveaways.GiveawayReviewed) {
+	var kind actions.Kind
+	switch e.Decision {
+	case giveaways.DecisionApproved:
+		kind = actions.KindGiveawayApproved
+	case giveaways.DecisionRejected:
+		kind = actions.KindGiveawayRejected
+	case giveaways.DecisionChangesRequested:
+		kind = actions.KindGiveawayChangesRequested
+	}
+
+	payload := &actions.Payload{
+		OldStatus:    string(giveaways.StatusPendingApproval),
+		NewStatus:    string(e.Decision.Status()),
+		TargetUserID: e.Giveaway.AdminUserID,
+	}
+	if e.Comment != "" {
+		payload.NewValue = &e.Comment
+	}
+
+	s.actionsSvc.Log(ctx, actions.Action{
+		Kind:        kind,
+		UserID:      e.ReviewerID,
+		GroupID:     e.Giveaway.GroupID,
+		GiveawayID:  e.Giveaway.ID,
+		Description: fmt.Sprintf("Review giveaway: %s", e.Decision),
+		Payload:     payload,
+	})
+}
@@ -0,0 +1,183 @@
+package subscribers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/keyboards"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/markdown"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/posts"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/events"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/roles"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/users"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"go.uber.org/zap"
+)
+
+// Approval sends submitted giveaways to the owners and managers of the group and tells the author
+// about the decision.
+type Approval struct {
+	base
+
+	giveawaysSvc *giveaways.Service
+	rolesSvc     *roles.Service
+	usersSvc     *users.Service
+}
+
+func NewApproval(
+	bot *gotelegrambotfx.Bot,
+	giveawaysSvc *giveaways.Service,
+	rolesSvc *roles.Service,
+	usersSvc *users.Service,
+	logger *zap.Logger,
+) events.Subscriber {
+	return &Approval{
+		base: base{
+			bot:    bot,
+			logger: logger,
+		},
+
+		giveawaysSvc: giveawaysSvc,
+		rolesSvc:     rolesSvc,
+		usersSvc:     usersSvc,
+	}
+}
+
+func (s *Approval) Name() string {
+	return "Approval"
+}
+
+func (s *Approval) Handle(ctx context.Context, event events.Event) error {
+	switch e := event.(type) {
+	case giveaways.GiveawaySubmitted:
+		return s.requestReview(ctx, e.ID, e.Revised)
+	case giveaways.GiveawayReviewed:
+		return s.notifyAuthor(ctx, e)
+	}
+
+	return nil
+}
+
+// requestReview sends the preview of the giveaway to everyone who can approve it.
+func (s *Approval) requestReview(ctx context.Context, giveawayID int64, revised bool) error {
+	giveaway, err := s.giveawaysSvc.GetByID(ctx, giveawayID)
+	if err != nil {
+		return fmt.Errorf("failed to get giveaway: %w", err)
+	}
+
+	approvers, err := s.rolesSvc.Members(ctx, giveaway.GroupID, roles.PermScheduleGiveaway)
+	if err != nil {
+		return fmt.Errorf("failed to get approvers: %w", err)
+	}
+
+	errs := make([]error, 0)
+	for _, userID := range approvers {
+		user, userErr := s.usersSvc.GetByID(ctx, userID)
+		if userErr != nil {
+			errs = append(errs, fmt.Errorf("failed to get approver %d: %w", userID, userErr))
+			continue
+		}
+
+		if _, sendErr := s.bot.SendPhoto(ctx, &bot.SendPhotoParams{
+			ChatID:      user.TelegramUserID,
+			Photo:       &models.InputFileString{Data: giveaway.PhotoFileID},
+			Caption:     formatSubmission(giveaway, s.giveawaysSvc.DescribeDraw(giveaway.Draw), revised),
+			ParseMode:   models.ParseModeMarkdown,
+			ReplyMarkup: keyboards.ReviewKeyboard(giveaway.ID),
+		}); sendErr != nil {
+			errs = append(errs, fmt.Errorf("failed to notify approver %d: %w", userID, sendErr))
+		}
+	}
+
+	if len(approvers) == 0 {
+		s.logger.Warn("no approvers for submitted giveaway", zap.Int64("giveaway_id", giveaway.ID))
+	}
+
+	return errors.Join(errs...)
+}
+
+// notifyAuthor tells the author of the giveaway about the decision.
+func (s *Approval) notifyAuthor(ctx context.Context, e giveaways.GiveawayReviewed) error {
+	if e.Giveaway.AdminTelegramID == 0 || e.ReviewerID == e.Giveaway.AdminUserID {
+		return nil
+	}
+
+	var text string
+	switch e.Decision {
+	case giveaways.DecisionApproved:
+		text = fmt.Sprintf(
+			"✅ Your giveaway in «%s» is approved and will be published at %s.",
+			e.Giveaway.Group.Title,
+			e.Giveaway.PublishDate.Format("02.01.2006 15:04"),
+		)
+	case giveaways.DecisionRejected:
+		text = fmt.Sprintf("❌ Your giveaway in «%s» is rejected.", e.Giveaway.Group.Title)
+	case giveaways.DecisionChangesRequested:
+		text = fmt.Sprintf(
+			"✏️ Changes are requested for your giveaway in «%s»:\n\n%s",
+			e.Giveaway.Group.Title,
+			e.Comment,
+		)
+	}
+
+	if _, err := s.bot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: e.Giveaway.AdminTelegramID,
+		Text:   text,
+	}); err != nil {
+		return fmt.Errorf("failed to notify author: %w", err)
+	}
+
+	if e.Decision != giveaways.DecisionChangesRequested {
+		return nil
+	}
+
+	// The button is sent separately, private menus are deleted once used and the comment must stay
+	if _, err := s.bot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      e.Giveaway.AdminTelegramID,
+		Text:        "Revise the giveaway and submit it for approval again when ready.",
+		ReplyMarkup: keyboards.ReviseKeyboard(e.Giveaway.ID),
+	}); err != nil {
+		return fmt.Errorf("failed to offer revision: %w", err)
+	}
+
+	return nil
+}
+
+// formatSubmission returns the preview of the submitted giveaway: the details approvers need followed by the post
+// as it will be published. Approvers see the accepted quiz answers too, they are hidden from participants.
+func formatSubmission(giveaway *giveaways.Giveaway, draw string, revised bool) string {
+	title := "📨 *Giveaway submitted for approval*"
+	if revised {
+		title = "📨 *Revised giveaway submitted for approval*"
+	}
+
+	anonymous := "No"
+	if giveaway.IsAnonymous {
+		anonymous = "Yes"
+	}
+
+	text := fmt.Sprintf(
+		"%s\n\n"+
+			"📱 Group: %s\n"+
+			"👤 Author: %s\n"+
+			"⏰ Start time: %s\n"+
+			"🕶 Anonymous: %s\n\n"+
+			"%s",
+		title,
+		bot.EscapeMarkdown(giveaway.Group.Title),
+		markdown.UserMention(giveaway.AdminTelegramID, giveaway.AdminUsername, giveaway.AdminFirstName),
+		bot.EscapeMarkdown(giveaway.PublishDate.Format("02.01.2006 15:04")),
+		anonymous,
+		posts.Caption(giveaway, draw),
+	)
+
+	if quiz := giveaway.Quiz; quiz != nil {
+		text += "\n✅ Accepted answers: " + bot.EscapeMarkdown(quiz.CorrectAnswer())
+	}
+
+	return text
+}
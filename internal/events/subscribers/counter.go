@@ -0,0 +1,54 @@
+package subscribers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/posts"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/events"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/go-telegram/bot"
+	"go.uber.org/zap"
+)
+
+// Counter shows the number of participants on the participate button.
+type Counter struct {
+	base
+}
+
+func NewCounter(bot *gotelegrambotfx.Bot, logger *zap.Logger) events.Subscriber {
+	return &Counter{
+		base: base{
+			bot:    bot,
+			logger: logger,
+		},
+	}
+}
+
+func (s *Counter) Name() string {
+	return "Counter"
+}
+
+func (s *Counter) Handle(ctx context.Context, event events.Event) error {
+	e, ok := event.(giveaways.ParticipantJoined)
+	if !ok {
+		return nil
+	}
+
+	// Anonymous giveaways don't reveal activity
+	ga := e.Participation.Giveaway
+	if ga.IsAnonymous {
+		return nil
+	}
+
+	if _, err := s.bot.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+		ChatID:      ga.Group.TelegramID,
+		MessageID:   int(ga.TelegramMessageID),
+		ReplyMarkup: posts.Keyboard(&ga, s.bot.Username(), e.Participation.ParticipantsCount),
+	}); err != nil {
+		return fmt.Errorf("failed to update participants count: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package subscribers
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+const (
+	// liveDrawTick is the delay between the frames of the show, it keeps the edits within the group rate limits.
+	liveDrawTick = 2 * time.Second
+	// liveDrawDiceDelay lets the dice animation finish before the reveal.
+	liveDrawDiceDelay = 4 * time.Second
+	// liveDrawTimeout bounds the show, it takes about 20 seconds.
+	liveDrawTimeout = time.Minute
+	// announceTimeout bounds the announcement following the show.
+	announceTimeout = 30 * time.Second
+
+	liveDrawCountdown = 3
+	liveDrawShuffles  = 5
+)
+
+// liveDraw returns the live draw rules of the group of the giveaway, false if there is no show to play.
+func (n *Notify) liveDraw(winner giveaways.Winner) (giveaways.LiveDrawRules, bool) {
+	if winner.Participant == nil {
+		return giveaways.LiveDrawRules{}, false
+	}
+
+	settings, err := giveaways.NewSettings(winner.Giveaway.Group.Settings)
+	if err != nil {
+		n.logger.Error("failed to parse group settings", zap.Int64("giveaway_id", winner.Giveaway.ID), zap.Error(err))
+		return giveaways.LiveDrawRules{}, false
+	}
+
+	return settings.LiveDraw, settings.LiveDraw.Enabled
+}
+
+// startLiveDraw plays the live draw in the background and announces the winner once it's over. Events are
+// delivered synchronously, so playing it in place would hold up the publisher for the whole show. The winner
+// is saved before the event is published, the show only delays the announcement.
+func (n *Notify) startLiveDraw(ctx context.Context, winner giveaways.Winner, rules giveaways.LiveDrawRules) {
+	detached := context.WithoutCancel(ctx)
+
+	n.shows.Go(func() {
+		showCtx, cancel := context.WithTimeout(detached, liveDrawTimeout)
+		stopShow := context.AfterFunc(n.stopped, cancel)
+		n.playLiveDraw(showCtx, winner, rules)
+		stopShow()
+		cancel()
+
+		announceCtx, cancel := context.WithTimeout(detached, announceTimeout)
+		defer cancel()
+
+		if err := n.announce(announceCtx, winner); err != nil {
+			n.logger.Error("failed to announce winner", zap.Int64("giveaway_id", winner.Giveaway.ID), zap.Error(err))
+		}
+	})
+}
+
+// playLiveDraw plays the draw in the group before the result is announced: a countdown, a shuffle through
+// the participants and an optional dice roll. The winner is already selected, the show is for the audience only,
+// so failures are logged and never prevent the announcement.
+func (n *Notify) playLiveDraw(ctx context.Context, winner giveaways.Winner, rules giveaways.LiveDrawRules) {
+	logger := n.logger.With(zap.Int64("giveaway_id", winner.Giveaway.ID))
+
+	participants, err := n.giveawaysSvc.ListParticipants(ctx, winner.Giveaway.ID)
+	if err != nil {
+		logger.Error("failed to list participants", zap.Error(err))
+		return
+	}
+
+	chatID := winner.Giveaway.Group.TelegramID
+	message, err := n.bot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   fmt.Sprintf("🎬 Розыгрыш начинается через %d…", liveDrawCountdown),
+		ReplyParameters: &models.ReplyParameters{
+			MessageID:                int(winner.Giveaway.TelegramMessageID),
+			ChatID:                   chatID,
+			AllowSendingWithoutReply: false,
+		},
+	})
+	if err != nil {
+		logger.Error("failed to start live draw", zap.Error(err))
+		return
+	}
+
+	edit := func(text string) bool {
+		if !sleep(ctx, liveDrawTick) {
+			return false
+		}
+
+		if _, editErr := n.bot.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:    chatID,
+			MessageID: message.ID,
+			Text:      text,
+		}); editErr != nil {
+			logger.Warn("failed to edit live draw message", zap.Error(editErr))
+		}
+
+		return true
+	}
+
+	for i := liveDrawCountdown - 1; i > 0; i-- {
+		if !edit(fmt.Sprintf("🎬 Розыгрыш начинается через %d…", i)) {
+			return
+		}
+	}
+
+	labels := shuffleLabels(winner, participants)
+	for _, label := range labels {
+		if !edit("🔀 " + label) {
+			return
+		}
+	}
+
+	if rules.Dice {
+		if _, diceErr := n.bot.SendDice(ctx, &bot.SendDiceParams{
+			ChatID: chatID,
+			Emoji:  "🎲",
+		}); diceErr != nil {
+			logger.Warn("failed to roll dice", zap.Error(diceErr))
+		} else if !sleep(ctx, liveDrawDiceDelay) {
+			return
+		}
+	}
+
+	edit("🥁 И победитель…")
+}
+
+// shuffleLabels returns random participants to show during the shuffle. The winner is left out,
+// unless nobody else takes part, so the shuffle doesn't spoil the reveal.
+func shuffleLabels(winner giveaways.Winner, participants []giveaways.Participant) []string {
+	others := lo.Filter(participants, func(item giveaways.Participant, _ int) bool {
+		return item.ID != winner.Participant.ID
+	})
+	if len(others) == 0 {
+		others = []giveaways.Participant{*winner.Participant}
+	}
+
+	labels := make([]string, 0, liveDrawShuffles)
+	for range liveDrawShuffles {
+		//nolint:gosec // not security sensitive, the winner is already selected
+		participant := others[rand.IntN(len(others))]
+		labels = append(labels, participantLabel(winner.Giveaway.IsAnonymous, &participant))
+	}
+
+	return labels
+}
+
+// participantLabel returns the plain text name of the participant, the ticket for anonymous giveaways.
+func participantLabel(anonymous bool, participant *giveaways.Participant) string {
+	switch {
+	case anonymous:
+		return "Билет №" + participant.Ticket()
+	case participant.UserUsername != "":
+		return "@" + participant.UserUsername
+	case participant.UserFirstName != "":
+		return participant.UserFirstName
+	default:
+		return "Участник №" + participant.Ticket()
+	}
+}
+
+// sleep waits for the duration, it returns false if the context is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
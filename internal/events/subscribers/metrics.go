@@ -0,0 +1,47 @@
+package subscribers
+
+import (
+	"context"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/events"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/metrics"
+)
+
+// Metrics counts giveaway status transitions and joined participants.
+type Metrics struct{}
+
+func NewMetrics() events.Subscriber {
+	return &Metrics{}
+}
+
+func (s *Metrics) Name() string {
+	return "Metrics"
+}
+
+func (s *Metrics) Handle(_ context.Context, event events.Event) error {
+	switch e := event.(type) {
+	case giveaways.GiveawayCreated:
+		metrics.GiveawayTransition("", string(giveaways.StatusScheduled))
+	case giveaways.GiveawaySubmitted:
+		from := ""
+		if e.Revised {
+			from = string(giveaways.StatusChangesRequested)
+		}
+		metrics.GiveawayTransition(from, string(giveaways.StatusPendingApproval))
+	case giveaways.GiveawayReviewed:
+		metrics.GiveawayTransition(string(giveaways.StatusPendingApproval), string(e.Decision.Status()))
+	case giveaways.GiveawayPublished:
+		metrics.GiveawayTransition(string(giveaways.StatusScheduled), string(giveaways.StatusActive))
+	case giveaways.GiveawayClosed:
+		metrics.GiveawayTransition(string(giveaways.StatusActive), string(giveaways.StatusClosed))
+	case giveaways.GiveawayFinished:
+		metrics.GiveawayTransition(string(giveaways.StatusClosed), string(giveaways.StatusFinished))
+	case giveaways.GiveawayCancelled:
+		metrics.GiveawayTransition(string(e.From), string(giveaways.StatusCancelled))
+	case giveaways.ParticipantJoined:
+		metrics.ParticipantJoined()
+	}
+
+	return nil
+}
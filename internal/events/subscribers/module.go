@@ -0,0 +1,29 @@
+package subscribers
+
+import (
+	"github.com/capcom6/lucky-pick-tg-bot/internal/events"
+	"github.com/go-core-fx/logger"
+	"go.uber.org/fx"
+)
+
+func Module() fx.Option {
+	return fx.Module(
+		"subscribers",
+		logger.WithNamedLogger("subscribers"),
+		fx.Provide(fx.Annotate(NewActionLog, fx.ResultTags(`group:"subscribers"`))),
+		fx.Provide(fx.Annotate(NewPin, fx.ResultTags(`group:"subscribers"`))),
+		fx.Provide(fx.Annotate(NewCounter, fx.ResultTags(`group:"subscribers"`))),
+		fx.Provide(fx.Annotate(NewNotify, fx.ResultTags(`group:"subscribers"`))),
+		fx.Provide(fx.Annotate(NewApproval, fx.ResultTags(`group:"subscribers"`))),
+		fx.Provide(fx.Annotate(NewWebhooks, fx.ResultTags(`group:"subscribers"`))),
+		fx.Provide(fx.Annotate(NewMetrics, fx.ResultTags(`group:"subscribers"`))),
+		fx.Invoke(fx.Annotate(
+			func(bus *events.Bus, subscribers []events.Subscriber) {
+				for _, subscriber := range subscribers {
+					bus.Subscribe(subscriber)
+				}
+			},
+			fx.ParamTags(``, `group:"subscribers"`),
+		)),
+	)
+}
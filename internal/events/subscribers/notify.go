@@ -0,0 +1,300 @@
+package subscribers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/keyboards"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/markdown"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/posts"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/events"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Notify informs the group, the winner and the organizer about the progress of a giveaway.
+type Notify struct {
+	base
+
+	giveawaysSvc *giveaways.Service
+
+	// shows are the live draws in progress, stopped cuts them short on shutdown
+	shows   sync.WaitGroup
+	stopped context.Context
+}
+
+func NewNotify(
+	bot *gotelegrambotfx.Bot,
+	giveawaysSvc *giveaways.Service,
+	lc fx.Lifecycle,
+	logger *zap.Logger,
+) events.Subscriber {
+	stopped, stop := context.WithCancel(context.Background())
+
+	n := &Notify{
+		base: base{
+			bot:    bot,
+			logger: logger,
+		},
+
+		giveawaysSvc: giveawaysSvc,
+
+		shows:   sync.WaitGroup{},
+		stopped: stopped,
+	}
+
+	// The winners are announced right after the shows are stopped, so wait for them
+	lc.Append(fx.StopHook(func(ctx context.Context) {
+		stop()
+
+		done := make(chan struct{})
+		go func() {
+			n.shows.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+		}
+	}))
+
+	return n
+}
+
+func (n *Notify) Name() string {
+	return "Notify"
+}
+
+func (n *Notify) Handle(ctx context.Context, event events.Event) error {
+	switch e := event.(type) {
+	case giveaways.GiveawayClosed:
+		return errors.Join(
+			n.markClosed(ctx, &e.Giveaway, e.ParticipantsCount),
+			n.reportSuspicious(ctx, &e.Giveaway),
+		)
+	case giveaways.GiveawayFinished:
+		if rules, ok := n.liveDraw(e.Winner); ok {
+			n.startLiveDraw(ctx, e.Winner, rules)
+			return nil
+		}
+		return n.announce(ctx, e.Winner)
+	case giveaways.GiveawayCancelled:
+		// Giveaways cancelled before publication have no post to announce in
+		if e.Winner.Giveaway.TelegramMessageID == 0 {
+			return nil
+		}
+		return n.announce(ctx, e.Winner)
+	}
+
+	return nil
+}
+
+// markClosed replaces the participation button of the giveaway post with the final number of participants.
+func (n *Notify) markClosed(ctx context.Context, giveaway *giveaways.Giveaway, participantsCount int) error {
+	return n.editPost(
+		ctx,
+		giveaway,
+		posts.ClosedCaption(giveaway, n.giveawaysSvc.DescribeDraw(giveaway.Draw), participantsCount),
+		keyboards.ResultKeyboard(""),
+	)
+}
+
+// reportSuspicious asks the organizer to review flagged participants before the draw.
+func (n *Notify) reportSuspicious(ctx context.Context, giveaway *giveaways.Giveaway) error {
+	flags, err := n.giveawaysSvc.ScreenParticipants(ctx, giveaway.ID)
+	if err != nil {
+		return fmt.Errorf("failed to screen participants: %w", err)
+	}
+
+	if len(flags) == 0 || giveaway.AdminTelegramID == 0 {
+		return nil
+	}
+
+	if _, sendErr := n.bot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: giveaway.AdminTelegramID,
+		Text: fmt.Sprintf(
+			"🛡 Applications for the giveaway in «%s» are closed.\n\n"+
+				"%d participant(s) look suspicious. Review them with /fraud before the results at %s.",
+			giveaway.Group.Title,
+			len(flags),
+			giveaway.ResultsDate.Format("02.01.2006 15:04"),
+		),
+	}); sendErr != nil {
+		return fmt.Errorf("failed to report suspicious participants: %w", sendErr)
+	}
+
+	return nil
+}
+
+// announce replies to the giveaway post with the result and shows the result on the post itself.
+func (n *Notify) announce(ctx context.Context, winner giveaways.Winner) error {
+	params := &bot.SendMessageParams{
+		ChatID: winner.Giveaway.Group.TelegramID,
+		Text:   formatResult(winner),
+		ReplyParameters: &models.ReplyParameters{
+			MessageID:                int(winner.Giveaway.TelegramMessageID),
+			ChatID:                   winner.Giveaway.Group.TelegramID,
+			AllowSendingWithoutReply: false,
+		},
+		ParseMode: models.ParseModeMarkdown,
+	}
+	message, err := n.bot.SendMessage(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+
+	if winner.Giveaway.IsAnonymous && winner.Participant != nil {
+		n.notifyPrivately(ctx, winner)
+	}
+
+	return n.editPost(
+		ctx,
+		&winner.Giveaway,
+		posts.FinishedCaption(winner, n.giveawaysSvc.DescribeDraw(winner.Giveaway.Draw)),
+		keyboards.ResultKeyboard(posts.MessageLink(winner.Giveaway.Group.TelegramID, message.ID)),
+	)
+}
+
+// editPost replaces the caption and the keyboard of the giveaway post. If the caption can't be set,
+// e.g. it exceeds the Telegram limit, the keyboard is replaced alone, so the participation button goes away anyway.
+func (n *Notify) editPost(
+	ctx context.Context,
+	giveaway *giveaways.Giveaway,
+	caption string,
+	keyboard *models.InlineKeyboardMarkup,
+) error {
+	_, err := n.bot.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{
+		ChatID:      giveaway.Group.TelegramID,
+		MessageID:   int(giveaway.TelegramMessageID),
+		Caption:     caption,
+		ParseMode:   models.ParseModeMarkdown,
+		ReplyMarkup: keyboard,
+	})
+	if err == nil {
+		return nil
+	}
+
+	n.logger.Error("failed to edit giveaway caption", zap.Int64("giveaway_id", giveaway.ID), zap.Error(err))
+
+	if _, markupErr := n.bot.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+		ChatID:      giveaway.Group.TelegramID,
+		MessageID:   int(giveaway.TelegramMessageID),
+		ReplyMarkup: keyboard,
+	}); markupErr != nil {
+		return fmt.Errorf("failed to edit giveaway post: %w", errors.Join(err, markupErr))
+	}
+
+	return nil
+}
+
+// notifyPrivately tells the winner and the organizer of an anonymous giveaway
+// about the result, since the group only sees the winning ticket.
+func (n *Notify) notifyPrivately(ctx context.Context, winner giveaways.Winner) {
+	logger := n.logger.With(zap.Int64("giveaway_id", winner.Giveaway.ID))
+
+	if _, err := n.bot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: winner.Participant.UserTelegramID,
+		Text: fmt.Sprintf(
+			bot.EscapeMarkdown("🎉 Поздравляем! Ваш билет №%s выиграл в розыгрыше группы «%s».\n"+
+				"Организатор свяжется с вами для вручения приза."),
+			bot.EscapeMarkdown(winner.Participant.Ticket()),
+			bot.EscapeMarkdown(winner.Giveaway.Group.Title),
+		),
+		ParseMode: models.ParseModeMarkdown,
+	}); err != nil {
+		logger.Error("failed to notify winner privately", zap.Error(err))
+	}
+
+	if winner.Giveaway.AdminTelegramID == 0 {
+		return
+	}
+
+	if _, err := n.bot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: winner.Giveaway.AdminTelegramID,
+		Text: fmt.Sprintf(
+			bot.EscapeMarkdown("🏆 Анонимный розыгрыш в группе «%s» завершён.\n"+
+				"Победитель: %s (билет №%s).\nСвяжитесь с ним для вручения приза."),
+			bot.EscapeMarkdown(winner.Giveaway.Group.Title),
+			markdown.UserMention(
+				winner.Participant.UserTelegramID,
+				winner.Participant.UserUsername,
+				winner.Participant.UserFirstName,
+			),
+			bot.EscapeMarkdown(winner.Participant.Ticket()),
+		),
+		ParseMode: models.ParseModeMarkdown,
+	}); err != nil {
+		logger.Error("failed to notify organizer", zap.Error(err))
+	}
+}
+
+func formatResult(winner giveaways.Winner) string {
+	if winner.Participant == nil {
+		switch {
+		case errors.Is(winner.Reason, giveaways.ErrCancelled):
+			return bot.EscapeMarkdown("❌ Розыгрыш отменён организатором.")
+		case errors.Is(winner.Reason, giveaways.ErrAllParticipantsExcluded):
+			return bot.EscapeMarkdown(
+				"🏆 Победитель: не выбран\n\nВсе участники недавно побеждали в розыгрышах группы и не могут выиграть повторно.",
+			)
+		case errors.Is(winner.Reason, giveaways.ErrAllParticipantsSuspicious):
+			return bot.EscapeMarkdown(
+				"🏆 Победитель: не выбран\n\nВсе участники исключены из розыгрыша проверкой на накрутку.",
+			)
+		case errors.Is(winner.Reason, giveaways.ErrNoCorrectAnswers):
+			return bot.EscapeMarkdown(
+				"🏆 Победитель: не выбран\n\nК сожалению, правильных ответов не оказалось.",
+			) + formatQuizAnswer(winner)
+		}
+
+		return bot.EscapeMarkdown("🏆 Победитель: не выбран\n\nК сожалению, участников оказалось недостаточно.")
+	}
+
+	var text string
+	if winner.Giveaway.IsAnonymous {
+		text = fmt.Sprintf(
+			bot.EscapeMarkdown("🏆 Выигрышный билет: №%s\n\n🎉Поздравляем!\nПобедитель получит личное сообщение от бота."),
+			bot.EscapeMarkdown(winner.Participant.Ticket()),
+		)
+	} else {
+		text = fmt.Sprintf(
+			bot.EscapeMarkdown("🏆 Победитель: %s\n\n🎉Поздравляем!\nСвяжитесь с администратором для получения приза."),
+			markdown.UserMention(
+				winner.Participant.UserTelegramID,
+				winner.Participant.UserUsername,
+				winner.Participant.UserFirstName,
+			),
+		)
+	}
+
+	if winner.ExcludedCount > 0 {
+		text += bot.EscapeMarkdown(fmt.Sprintf(
+			"\n\nℹ️ Не участвовали в розыгрыше из-за недавних побед: %d",
+			winner.ExcludedCount,
+		))
+	}
+
+	return text + formatQuizAnswer(winner)
+}
+
+// formatQuizAnswer returns the correct answer to the quiz and, for the closest guess, the answer of the winner.
+func formatQuizAnswer(winner giveaways.Winner) string {
+	quiz := winner.Giveaway.Quiz
+	if quiz == nil {
+		return ""
+	}
+
+	text := "\n\n✅ Правильный ответ: " + quiz.CorrectAnswer()
+	if quiz.Mode == giveaways.QuizClosest && winner.Answer != "" {
+		text += "\n🎯 Ответ победителя: " + winner.Answer
+	}
+
+	return bot.EscapeMarkdown(text)
+}
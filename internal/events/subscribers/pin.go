@@ -0,0 +1,52 @@
+package subscribers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/events"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/go-telegram/bot"
+	"go.uber.org/zap"
+)
+
+// Pin keeps the post of an active giveaway pinned in the group.
+type Pin struct {
+	base
+}
+
+func NewPin(bot *gotelegrambotfx.Bot, logger *zap.Logger) events.Subscriber {
+	return &Pin{
+		base: base{
+			bot:    bot,
+			logger: logger,
+		},
+	}
+}
+
+func (s *Pin) Name() string {
+	return "Pin"
+}
+
+func (s *Pin) Handle(ctx context.Context, event events.Event) error {
+	switch e := event.(type) {
+	case giveaways.GiveawayPublished:
+		if _, err := s.bot.PinChatMessage(ctx, &bot.PinChatMessageParams{
+			ChatID:              e.Giveaway.Group.TelegramID,
+			MessageID:           int(e.Giveaway.TelegramMessageID),
+			DisableNotification: false,
+		}); err != nil {
+			return fmt.Errorf("failed to pin message %d: %w", e.Giveaway.TelegramMessageID, err)
+		}
+	case giveaways.GiveawayClosed:
+		if _, err := s.bot.UnpinChatMessage(ctx, &bot.UnpinChatMessageParams{
+			ChatID:    e.Giveaway.Group.TelegramID,
+			MessageID: int(e.Giveaway.TelegramMessageID),
+		}); err != nil {
+			return fmt.Errorf("failed to unpin message %d: %w", e.Giveaway.TelegramMessageID, err)
+		}
+	}
+
+	return nil
+}
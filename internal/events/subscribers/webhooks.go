@@ -0,0 +1,82 @@
+package subscribers
+
+import (
+	"context"
+	"time"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/events"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/webhooks"
+)
+
+// Webhooks forwards giveaway lifecycle events to webhooks registered by group admins.
+type Webhooks struct {
+	webhooksSvc *webhooks.Service
+}
+
+func NewWebhooks(webhooksSvc *webhooks.Service) events.Subscriber {
+	return &Webhooks{
+		webhooksSvc: webhooksSvc,
+	}
+}
+
+func (s *Webhooks) Name() string {
+	return "Webhooks"
+}
+
+func (s *Webhooks) Handle(ctx context.Context, event events.Event) error {
+	var giveaway giveaways.Giveaway
+	payload := webhooks.Payload{OccurredAt: time.Now()} //nolint:exhaustruct // filled below
+
+	switch e := event.(type) {
+	case giveaways.GiveawayPublished:
+		giveaway = e.Giveaway
+		payload.Event = webhooks.EventGiveawayPublished
+	case giveaways.ParticipantJoined:
+		giveaway = e.Participation.Giveaway
+		payload.Event = webhooks.EventParticipantJoined
+		payload.Participant = newParticipantData(&e.Participation.Participant)
+		payload.Giveaway.ParticipantsCount = e.Participation.ParticipantsCount
+	case giveaways.GiveawayClosed:
+		giveaway = e.Giveaway
+		payload.Event = webhooks.EventGiveawayClosed
+	case giveaways.GiveawayFinished:
+		giveaway = e.Winner.Giveaway
+		payload.Event = webhooks.EventWinnerSelected
+		payload.Participant = newParticipantData(e.Winner.Participant)
+	case giveaways.GiveawayCancelled:
+		giveaway = e.Winner.Giveaway
+		payload.Event = webhooks.EventGiveawayCancelled
+		payload.Reason = e.Reason
+	default:
+		return nil
+	}
+
+	payload.Giveaway = webhooks.GiveawayData{
+		ID:                 giveaway.ID,
+		GroupTelegramID:    giveaway.Group.TelegramID,
+		GroupTitle:         giveaway.Group.Title,
+		MessageID:          giveaway.TelegramMessageID,
+		Description:        giveaway.Description,
+		IsAnonymous:        giveaway.IsAnonymous,
+		ApplicationEndDate: giveaway.ApplicationEndDate,
+		ResultsDate:        giveaway.ResultsDate,
+		ParticipantsCount:  payload.Giveaway.ParticipantsCount,
+	}
+
+	return s.webhooksSvc.Enqueue(ctx, giveaway.GroupID, payload)
+}
+
+func newParticipantData(participant *giveaways.Participant) *webhooks.ParticipantData {
+	if participant == nil {
+		return nil
+	}
+
+	return &webhooks.ParticipantData{
+		TelegramID: participant.UserTelegramID,
+		Username:   participant.UserUsername,
+		FirstName:  participant.UserFirstName,
+		Ticket:     participant.Ticket(),
+		Tickets:    participant.Tickets,
+	}
+}
@@ -0,0 +1,88 @@
+package fraud
+
+import "time"
+
+// Reason identifies a signal that contributed to the fraud score.
+type Reason string
+
+const (
+	ReasonNoUsername Reason = "no_username"
+	// ReasonNewAccount is no longer scored: Telegram doesn't expose the account age and the time the bot
+	// first saw the user flagged regular users. It is kept to describe flags raised before.
+	ReasonNewAccount  Reason = "new_account"
+	ReasonBurst       Reason = "burst"
+	ReasonNamePattern Reason = "name_pattern"
+)
+
+// String returns a human-readable description of the reason.
+func (r Reason) String() string {
+	switch r {
+	case ReasonNoUsername:
+		return "no username"
+	case ReasonNewAccount:
+		return "recently seen for the first time"
+	case ReasonBurst:
+		return "joined right after publication"
+	case ReasonNamePattern:
+		return "name shared with other participants"
+	}
+
+	return string(r)
+}
+
+// Status is the admin decision on a flag.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusConfirmed Status = "confirmed"
+	StatusDismissed Status = "dismissed"
+)
+
+// Candidate is a giveaway participant to be scored.
+type Candidate struct {
+	UserID    int64
+	Username  string
+	FirstName string
+	LastName  string
+
+	JoinedAt time.Time
+}
+
+// Flag is a participant whose score reached the review threshold.
+type Flag struct {
+	ID int64
+
+	GiveawayID int64
+	GroupID    int64
+	UserID     int64
+
+	UserTelegramID int64
+	UserUsername   string
+	UserFirstName  string
+
+	Score   int
+	Reasons []Reason
+	Status  Status
+
+	ReviewedBy int64
+	ReviewedAt time.Time
+
+	CreatedAt time.Time
+}
+
+// IsExcluded returns true if the participant must not take part in the draw.
+// Confirmed flags are always excluded, pending ones only when the score reaches
+// the auto-exclude threshold. A zero threshold disables auto-exclusion.
+func (f *Flag) IsExcluded(autoExcludeThreshold int) bool {
+	switch f.Status {
+	case StatusConfirmed:
+		return true
+	case StatusDismissed:
+		return false
+	case StatusPending:
+		return autoExcludeThreshold > 0 && f.Score >= autoExcludeThreshold
+	}
+
+	return false
+}
@@ -0,0 +1,7 @@
+package fraud
+
+import "errors"
+
+var (
+	ErrNotFound = errors.New("fraud flag not found")
+)
@@ -0,0 +1,74 @@
+package fraud
+
+import (
+	"strings"
+	"time"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/users"
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+)
+
+type flagModel struct {
+	bun.BaseModel `bun:"table:fraud_flags,alias:ff"`
+
+	ID         int64     `bun:"id,pk,autoincrement"`
+	GiveawayID int64     `bun:"giveaway_id,notnull"`
+	GroupID    int64     `bun:"group_id,notnull"`
+	UserID     int64     `bun:"user_id,notnull"`
+	Score      int       `bun:"score,notnull"`
+	Reasons    string    `bun:"reasons,notnull"`
+	Status     Status    `bun:"status,notnull,default:'pending'"`
+	ReviewedBy int64     `bun:"reviewed_by,nullzero"`
+	ReviewedAt time.Time `bun:"reviewed_at,nullzero"`
+	CreatedAt  time.Time `bun:"created_at,scanonly"`
+	UpdatedAt  time.Time `bun:"updated_at,scanonly"`
+
+	User *users.UserModel `bun:"u,rel:belongs-to,join:user_id=id"`
+}
+
+func newFlagModel(giveawayID, groupID, userID int64, score int, reasons []Reason) *flagModel {
+	//nolint:exhaustruct // partial constructor
+	return &flagModel{
+		GiveawayID: giveawayID,
+		GroupID:    groupID,
+		UserID:     userID,
+		Score:      score,
+		Reasons: strings.Join(
+			lo.Map(reasons, func(item Reason, _ int) string { return string(item) }),
+			",",
+		),
+		Status: StatusPending,
+	}
+}
+
+func (m *flagModel) toFlag() *Flag {
+	user := m.User
+	if user == nil {
+		user = new(users.UserModel)
+	}
+
+	return &Flag{
+		ID: m.ID,
+
+		GiveawayID: m.GiveawayID,
+		GroupID:    m.GroupID,
+		UserID:     m.UserID,
+
+		UserTelegramID: user.TelegramUserID,
+		UserUsername:   user.Username,
+		UserFirstName:  user.FirstName,
+
+		Score: m.Score,
+		Reasons: lo.Map(
+			strings.Split(m.Reasons, ","),
+			func(item string, _ int) Reason { return Reason(item) },
+		),
+		Status: m.Status,
+
+		ReviewedBy: m.ReviewedBy,
+		ReviewedAt: m.ReviewedAt,
+
+		CreatedAt: m.CreatedAt,
+	}
+}
@@ -0,0 +1,105 @@
+package fraud
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+)
+
+type Repository struct {
+	db *bun.DB
+}
+
+func NewRepository(db *bun.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// Upsert stores flags, refreshing scores of existing ones without touching review decisions.
+func (r *Repository) Upsert(ctx context.Context, flags []*flagModel) error {
+	if len(flags) == 0 {
+		return nil
+	}
+
+	if _, err := r.db.NewInsert().
+		Model(&flags).
+		On("DUPLICATE KEY UPDATE").
+		Set("score = VALUES(score)").
+		Set("reasons = VALUES(reasons)").
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to upsert fraud flags: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) ListByGiveaway(ctx context.Context, giveawayID int64) ([]Flag, error) {
+	flags := make([]flagModel, 0)
+	if err := r.db.NewSelect().
+		Model(&flags).
+		Relation("User").
+		Where("ff.giveaway_id = ?", giveawayID).
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get fraud flags: %w", err)
+	}
+
+	return lo.Map(flags, func(item flagModel, _ int) Flag { return *item.toFlag() }), nil
+}
+
+// ListPending returns flags awaiting review for giveaways of the groups in the given statuses.
+func (r *Repository) ListPending(ctx context.Context, groupIDs []int64, giveawayStatuses []string) ([]Flag, error) {
+	if len(groupIDs) == 0 {
+		return []Flag{}, nil
+	}
+
+	flags := make([]flagModel, 0)
+	if err := r.db.NewSelect().
+		Model(&flags).
+		Relation("User").
+		Join("JOIN giveaways AS ga ON ga.id = ff.giveaway_id").
+		Where("ff.group_id IN (?)", bun.In(groupIDs)).
+		Where("ff.status = ?", StatusPending).
+		Where("ga.status IN (?)", bun.In(giveawayStatuses)).
+		Order("ga.results_date ASC", "ff.score DESC").
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get pending fraud flags: %w", err)
+	}
+
+	return lo.Map(flags, func(item flagModel, _ int) Flag { return *item.toFlag() }), nil
+}
+
+func (r *Repository) GetByID(ctx context.Context, id int64) (*Flag, error) {
+	flag := new(flagModel)
+	if err := r.db.NewSelect().
+		Model(flag).
+		Relation("User").
+		Where("ff.id = ?", id).
+		Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get fraud flag: %w", err)
+	}
+
+	return flag.toFlag(), nil
+}
+
+func (r *Repository) UpdateStatus(ctx context.Context, id int64, status Status, reviewerID int64) error {
+	if _, err := r.db.NewUpdate().
+		Model((*flagModel)(nil)).
+		Set("status = ?", status).
+		Set("reviewed_by = ?", reviewerID).
+		Set("reviewed_at = ?", time.Now()).
+		Where("id = ?", id).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to update fraud flag: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package fraud
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/samber/lo"
+)
+
+const (
+	maxScore = 100
+
+	weightNoUsername  = 20
+	weightBurst       = 25
+	weightNamePattern = 25
+
+	// namePatternMinSize is the number of participants sharing a name pattern
+	// from which the pattern is considered suspicious.
+	namePatternMinSize = 3
+)
+
+type scored struct {
+	Score   int
+	Reasons []Reason
+}
+
+// score evaluates candidates against the fraud signals.
+// Participants without any signal are omitted from the result.
+func score(publishedAt time.Time, candidates []Candidate, settings Settings) map[int64]scored {
+	patterns := lo.CountValuesBy(candidates, func(item Candidate) string {
+		return namePattern(item.FirstName, item.LastName)
+	})
+
+	result := make(map[int64]scored)
+	for _, c := range candidates {
+		s := scored{Score: 0, Reasons: nil}
+
+		if c.Username == "" {
+			s.Score += weightNoUsername
+			s.Reasons = append(s.Reasons, ReasonNoUsername)
+		}
+
+		if !publishedAt.IsZero() && c.JoinedAt.Sub(publishedAt) < settings.BurstWindow {
+			s.Score += weightBurst
+			s.Reasons = append(s.Reasons, ReasonBurst)
+		}
+
+		if pattern := namePattern(c.FirstName, c.LastName); pattern != "" && patterns[pattern] >= namePatternMinSize {
+			s.Score += weightNamePattern
+			s.Reasons = append(s.Reasons, ReasonNamePattern)
+		}
+
+		if s.Score > 0 {
+			s.Score = min(s.Score, maxScore)
+			result[c.UserID] = s
+		}
+	}
+
+	return result
+}
+
+// namePattern reduces the name to its letters, so that "Anna_123" and "anna 456" match.
+func namePattern(firstName, lastName string) string {
+	return strings.Map(
+		func(r rune) rune {
+			if !unicode.IsLetter(r) {
+				return -1
+			}
+			return unicode.ToLower(r)
+		},
+		firstName+lastName,
+	)
+}
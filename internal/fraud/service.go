@@ -0,0 +1,125 @@
+package fraud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/actions"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+type Service struct {
+	flags *Repository
+
+	actionsSvc *actions.Service
+
+	logger *zap.Logger
+}
+
+func NewService(flags *Repository, actionsSvc *actions.Service, logger *zap.Logger) *Service {
+	return &Service{
+		flags: flags,
+
+		actionsSvc: actionsSvc,
+
+		logger: logger,
+	}
+}
+
+// Evaluate scores giveaway participants, stores flags for those reaching the review threshold
+// and returns all flags of the giveaway including earlier review decisions.
+func (s *Service) Evaluate(
+	ctx context.Context,
+	giveawayID, groupID int64,
+	publishedAt time.Time,
+	candidates []Candidate,
+	settings Settings,
+) ([]Flag, error) {
+	scores := score(publishedAt, candidates, settings)
+
+	models := make([]*flagModel, 0, len(scores))
+	for userID, item := range scores {
+		if item.Score < settings.ReviewThreshold {
+			continue
+		}
+		models = append(models, newFlagModel(giveawayID, groupID, userID, item.Score, item.Reasons))
+	}
+
+	if err := s.flags.Upsert(ctx, models); err != nil {
+		return nil, err
+	}
+
+	flags, err := s.flags.ListByGiveaway(ctx, giveawayID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug("participants evaluated",
+		zap.Int64("giveaway_id", giveawayID),
+		zap.Int("candidates", len(candidates)),
+		zap.Int("flagged", len(flags)),
+	)
+
+	return flags, nil
+}
+
+// Excluded returns IDs of users who must not take part in the draw.
+func Excluded(flags []Flag, settings Settings) map[int64]struct{} {
+	excluded := make(map[int64]struct{})
+	for _, flag := range flags {
+		if flag.IsExcluded(settings.AutoExcludeThreshold) {
+			excluded[flag.UserID] = struct{}{}
+		}
+	}
+
+	return excluded
+}
+
+// ListPending returns flags awaiting review in the groups for giveaways in the given statuses.
+func (s *Service) ListPending(ctx context.Context, groupIDs []int64, giveawayStatuses []string) ([]Flag, error) {
+	return s.flags.ListPending(ctx, groupIDs, giveawayStatuses)
+}
+
+func (s *Service) GetByID(ctx context.Context, id int64) (*Flag, error) {
+	return s.flags.GetByID(ctx, id)
+}
+
+// Review records the admin decision on the flag.
+func (s *Service) Review(ctx context.Context, id, reviewerID int64, confirm bool) error {
+	flag, err := s.flags.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	status, kind := StatusDismissed, actions.KindFraudDismissed
+	if confirm {
+		status, kind = StatusConfirmed, actions.KindFraudConfirmed
+	}
+
+	if updErr := s.flags.UpdateStatus(ctx, id, status, reviewerID); updErr != nil {
+		return updErr
+	}
+
+	s.actionsSvc.Log(ctx, actions.Action{
+		Kind:       kind,
+		UserID:     reviewerID,
+		GroupID:    flag.GroupID,
+		GiveawayID: flag.GiveawayID,
+		Description: fmt.Sprintf(
+			"Flag of user %d with score %d (%s) %s",
+			flag.UserID,
+			flag.Score,
+			strings.Join(lo.Map(flag.Reasons, func(item Reason, _ int) string { return string(item) }), ", "),
+			status,
+		),
+		Payload: &actions.Payload{
+			TargetUserID: flag.UserID,
+			Score:        flag.Score,
+		},
+	})
+
+	return nil
+}
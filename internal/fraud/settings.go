@@ -0,0 +1,94 @@
+package fraud
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/settings"
+)
+
+type Settings struct {
+	ReviewThreshold      int
+	AutoExcludeThreshold int
+	BurstWindow          time.Duration
+}
+
+func NewSettings(dict map[string]string) (Settings, error) {
+	s := DefaultSettings()
+
+	for key, target := range map[string]*int{
+		"fraud.review_threshold":       &s.ReviewThreshold,
+		"fraud.auto_exclude_threshold": &s.AutoExcludeThreshold,
+	} {
+		if v := dict[key]; v != "" {
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return s, fmt.Errorf("failed to parse %s setting: %w", key, err)
+			}
+			*target = int(n)
+		}
+	}
+
+	if d := dict["fraud.burst_window"]; d != "" {
+		duration, err := settings.ParseDuration(d)
+		if err != nil {
+			return s, fmt.Errorf("failed to parse burst window: %w", err)
+		}
+		s.BurstWindow = duration.Duration
+	}
+
+	return s, nil
+}
+
+func DefaultSettings() Settings {
+	//nolint:mnd //default values
+	return Settings{
+		ReviewThreshold:      50,
+		AutoExcludeThreshold: 0,
+		BurstWindow:          5 * time.Second,
+	}
+}
+
+func SettingDefinitions() []settings.SettingDefinition {
+	thresholdValidation := &settings.SettingValidation{
+		MinValue: settings.Ptr(float64(0)),
+		MaxValue: settings.Ptr(float64(maxScore)),
+		Required: false,
+	}
+
+	//nolint:exhaustruct,mnd //default values
+	return []settings.SettingDefinition{
+		{
+			Key:          "fraud.review_threshold",
+			Category:     "🛡 Anti-fraud",
+			Label:        "Review Threshold",
+			Description:  "Fraud score (0-100) from which participants are flagged for review",
+			Type:         settings.Number,
+			DefaultValue: "50",
+			Validation:   thresholdValidation,
+		},
+		{
+			Key:          "fraud.auto_exclude_threshold",
+			Category:     "🛡 Anti-fraud",
+			Label:        "Auto-exclude Threshold",
+			Description:  "Fraud score (0-100) from which unreviewed participants are excluded from the draw (0 to disable)",
+			Type:         settings.Number,
+			DefaultValue: "0",
+			Validation:   thresholdValidation,
+		},
+		{
+			Key:          "fraud.burst_window",
+			Category:     "🛡 Anti-fraud",
+			Label:        "Burst Window",
+			Description:  "Participants joining within this period after publication are considered suspicious",
+			Type:         settings.Duration,
+			DefaultValue: "00:00:05",
+			Validation: &settings.SettingValidation{
+				MinValue: settings.Ptr(float64(0)),
+				MaxValue: settings.Ptr(float64(time.Hour.Seconds())), // Maximum 1 hour
+				Required: false,
+			},
+		},
+	}
+}
@@ -0,0 +1,10 @@
+package fsm
+
+import "time"
+
+type Config struct {
+	// TTL is the time of inactivity after which a conversation expires.
+	TTL time.Duration
+	// DraftsTTL is the time after which expired drafts are removed.
+	DraftsTTL time.Duration
+}
@@ -0,0 +1,37 @@
+package fsm
+
+import (
+	"strings"
+	"time"
+)
+
+// Flow describes a multi-step conversation that is kept as a draft when it expires.
+type Flow struct {
+	// Prefix is the common prefix of the flow state names.
+	Prefix string
+	// Title is shown to the user, e.g. "giveaway".
+	Title string
+}
+
+type flows []Flow
+
+func (f flows) find(name string) (Flow, bool) {
+	for _, flow := range f {
+		if strings.HasPrefix(name, flow.Prefix) {
+			return flow, true
+		}
+	}
+
+	return Flow{}, false
+}
+
+// Draft is an expired state of a flow saved for resume.
+type Draft struct {
+	ID     int64
+	UserID int64
+
+	State State
+	Flow  Flow
+
+	CreatedAt time.Time
+}
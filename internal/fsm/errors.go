@@ -0,0 +1,9 @@
+package fsm
+
+import "errors"
+
+var (
+	ErrStateNotFound = errors.New("state not found")
+	ErrDraftNotFound = errors.New("draft not found")
+	ErrConflict      = errors.New("state was changed concurrently")
+)
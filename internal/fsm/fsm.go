@@ -9,6 +9,54 @@ import (
 type State struct {
 	Name string            `json:"name"`
 	Data map[string]string `json:"data"`
+
+	// version is the stored version of the state, zero for a new one.
+	version int64
+	// snapshot is the fingerprint of the state as it was loaded or stored.
+	snapshot string
+}
+
+// newState returns an empty state, which is not stored yet.
+func newState() *State {
+	return &State{
+		Name: "",
+		Data: map[string]string{},
+
+		version:  0,
+		snapshot: "",
+	}
+}
+
+// Changed reports whether the state was modified since it was loaded or stored.
+func (s *State) Changed() bool {
+	return s.fingerprint() != s.snapshot
+}
+
+// Restore replaces the name and data with the ones of other, keeping the stored version.
+func (s *State) Restore(other State) {
+	s.Name = other.Name
+	s.Data = other.Data
+}
+
+// IsEmpty reports whether the state has neither a name nor data.
+func (s *State) IsEmpty() bool {
+	return s.Name == "" && len(s.Data) == 0
+}
+
+func (s *State) fingerprint() string {
+	if s.IsEmpty() {
+		return ""
+	}
+
+	// Map keys are sorted, so equal states have equal fingerprints
+	b, _ := json.Marshal(s)
+
+	return string(b)
+}
+
+func (s *State) commit(version int64) {
+	s.version = version
+	s.snapshot = s.fingerprint()
 }
 
 func (s *State) SetName(name string) {
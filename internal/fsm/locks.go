@@ -0,0 +1,48 @@
+package fsm
+
+import "sync"
+
+// userLocks serializes work on states of the same user within the process.
+type userLocks struct {
+	mux   sync.Mutex
+	locks map[int64]*userLock
+}
+
+type userLock struct {
+	sync.Mutex
+
+	refs int
+}
+
+func newUserLocks() *userLocks {
+	return &userLocks{
+		mux:   sync.Mutex{},
+		locks: make(map[int64]*userLock),
+	}
+}
+
+// lock blocks until the user is free and returns the function releasing the lock.
+func (l *userLocks) lock(userID int64) func() {
+	l.mux.Lock()
+	lock, ok := l.locks[userID]
+	if !ok {
+		lock = new(userLock)
+		l.locks[userID] = lock
+	}
+	lock.refs++
+	l.mux.Unlock()
+
+	lock.Lock()
+
+	return func() {
+		lock.Unlock()
+
+		l.mux.Lock()
+		defer l.mux.Unlock()
+
+		lock.refs--
+		if lock.refs == 0 {
+			delete(l.locks, userID)
+		}
+	}
+}
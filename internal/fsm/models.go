@@ -0,0 +1,84 @@
+package fsm
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type stateModel struct {
+	bun.BaseModel `bun:"table:fsm_states,alias:fs"`
+
+	UserID    int64             `bun:"telegram_user_id,pk"`
+	Name      string            `bun:"name,notnull"`
+	Data      map[string]string `bun:"data,type:json,notnull"`
+	Version   int64             `bun:"version,notnull"`
+	ExpiresAt time.Time         `bun:"expires_at,notnull"`
+	CreatedAt time.Time         `bun:"created_at,scanonly"`
+	UpdatedAt time.Time         `bun:"updated_at,scanonly"`
+}
+
+func newStateModel(userID int64, state *State, expiresAt time.Time) *stateModel {
+	data := state.Data
+	if data == nil {
+		data = map[string]string{}
+	}
+
+	//nolint:exhaustruct // partial constructor
+	return &stateModel{
+		UserID:    userID,
+		Name:      state.Name,
+		Data:      data,
+		Version:   state.version + 1,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func (m *stateModel) toState() *State {
+	//nolint:exhaustruct // version and snapshot are set by commit
+	state := &State{
+		Name: m.Name,
+		Data: m.Data,
+	}
+	state.commit(m.Version)
+
+	return state
+}
+
+type draftModel struct {
+	bun.BaseModel `bun:"table:fsm_drafts,alias:fd"`
+
+	ID         int64             `bun:"id,pk,autoincrement"`
+	UserID     int64             `bun:"telegram_user_id,notnull"`
+	Name       string            `bun:"name,notnull"`
+	Data       map[string]string `bun:"data,type:json,notnull"`
+	NotifiedAt *time.Time        `bun:"notified_at"`
+	CreatedAt  time.Time         `bun:"created_at,scanonly"`
+}
+
+func newDraftModel(state *stateModel) *draftModel {
+	//nolint:exhaustruct // partial constructor
+	return &draftModel{
+		UserID: state.UserID,
+		Name:   state.Name,
+		Data:   state.Data,
+	}
+}
+
+func (m *draftModel) toDraft(flow Flow) Draft {
+	return Draft{
+		ID:     m.ID,
+		UserID: m.UserID,
+
+		State: State{
+			Name: m.Name,
+			Data: m.Data,
+
+			version:  0,
+			snapshot: "",
+		},
+		Flow: flow,
+
+		CreatedAt: m.CreatedAt,
+	}
+}
@@ -1,10 +1,6 @@
 package fsm
 
 import (
-	"fmt"
-
-	"github.com/go-core-fx/cachefx"
-	"github.com/go-core-fx/cachefx/cache"
 	"github.com/go-core-fx/logger"
 	"go.uber.org/fx"
 )
@@ -13,14 +9,7 @@ func Module() fx.Option {
 	return fx.Module(
 		"fsm",
 		logger.WithNamedLogger("fsm"),
-		fx.Provide(func(factory cachefx.Factory) (cache.Cache, error) {
-			storage, err := factory.New("fsm")
-			if err != nil {
-				return nil, fmt.Errorf("create cache: %w", err)
-			}
-
-			return storage, nil
-		}, fx.Private),
-		fx.Provide(NewService),
+		fx.Provide(NewRepository, fx.Private),
+		fx.Provide(fx.Annotate(NewService, fx.ParamTags(``, `group:"flows"`))),
 	)
 }
@@ -0,0 +1,229 @@
+package fsm
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type Repository struct {
+	db *bun.DB
+}
+
+func NewRepository(db *bun.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// Get returns the state of the user, including an expired one.
+func (r *Repository) Get(ctx context.Context, userID int64) (*stateModel, error) {
+	state := new(stateModel)
+	if err := r.db.NewSelect().
+		Model(state).
+		Where("fs.telegram_user_id = ?", userID).
+		Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrStateNotFound
+		}
+		return nil, fmt.Errorf("failed to get state: %w", err)
+	}
+
+	return state, nil
+}
+
+// Insert creates the first version of the state.
+// It returns ErrConflict if the state was created concurrently.
+func (r *Repository) Insert(ctx context.Context, state *stateModel) error {
+	res, err := r.db.NewInsert().
+		Model(state).
+		Ignore().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to insert state: %w", err)
+	}
+
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return ErrConflict
+	}
+
+	return nil
+}
+
+// Update replaces the previous version of the state.
+// It returns ErrConflict if the stored version differs.
+func (r *Repository) Update(ctx context.Context, state *stateModel) error {
+	res, err := r.db.NewUpdate().
+		Model(state).
+		Column("name", "data", "version", "expires_at").
+		WherePK().
+		Where("version = ?", state.Version-1).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update state: %w", err)
+	}
+
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return ErrConflict
+	}
+
+	return nil
+}
+
+// Delete removes the given version of the state.
+// It returns ErrConflict if the stored version differs.
+func (r *Repository) Delete(ctx context.Context, userID, version int64) error {
+	res, err := r.db.NewDelete().
+		Model((*stateModel)(nil)).
+		Where("telegram_user_id = ?", userID).
+		Where("version = ?", version).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete state: %w", err)
+	}
+
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return ErrConflict
+	}
+
+	return nil
+}
+
+// ListExpired returns states expired before now.
+func (r *Repository) ListExpired(ctx context.Context, now time.Time, limit int) ([]stateModel, error) {
+	states := make([]stateModel, 0)
+	if err := r.db.NewSelect().
+		Model(&states).
+		Where("fs.expires_at <= ?", now).
+		Order("fs.expires_at ASC").
+		Limit(limit).
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to list expired states: %w", err)
+	}
+
+	return states, nil
+}
+
+// Expire removes the expired state and saves it as a draft if keep is true.
+// It does nothing if the state was updated after it had been loaded.
+func (r *Repository) Expire(ctx context.Context, state *stateModel, keep bool) error {
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewDelete().
+			Model((*stateModel)(nil)).
+			Where("telegram_user_id = ?", state.UserID).
+			Where("version = ?", state.Version).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to delete state: %w", err)
+		}
+
+		if rows, _ := res.RowsAffected(); rows == 0 || !keep {
+			return nil
+		}
+
+		if _, err = tx.NewInsert().
+			Model(newDraftModel(state)).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("failed to create draft: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to expire state: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetDraft(ctx context.Context, userID, id int64) (*draftModel, error) {
+	draft := new(draftModel)
+	if err := r.db.NewSelect().
+		Model(draft).
+		Where("fd.id = ?", id).
+		Where("fd.telegram_user_id = ?", userID).
+		Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDraftNotFound
+		}
+		return nil, fmt.Errorf("failed to get draft: %w", err)
+	}
+
+	return draft, nil
+}
+
+func (r *Repository) ListDrafts(ctx context.Context, userID int64) ([]draftModel, error) {
+	drafts := make([]draftModel, 0)
+	if err := r.db.NewSelect().
+		Model(&drafts).
+		Where("fd.telegram_user_id = ?", userID).
+		Order("fd.id DESC").
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to list drafts: %w", err)
+	}
+
+	return drafts, nil
+}
+
+// ListUnnotified returns drafts the users were not told about yet.
+func (r *Repository) ListUnnotified(ctx context.Context, limit int) ([]draftModel, error) {
+	drafts := make([]draftModel, 0)
+	if err := r.db.NewSelect().
+		Model(&drafts).
+		Where("fd.notified_at IS NULL").
+		Order("fd.id ASC").
+		Limit(limit).
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to list unnotified drafts: %w", err)
+	}
+
+	return drafts, nil
+}
+
+func (r *Repository) MarkNotified(ctx context.Context, id int64, now time.Time) error {
+	if _, err := r.db.NewUpdate().
+		Model((*draftModel)(nil)).
+		Set("notified_at = ?", now).
+		Where("id = ?", id).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to mark draft as notified: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteDraft(ctx context.Context, userID, id int64) error {
+	res, err := r.db.NewDelete().
+		Model((*draftModel)(nil)).
+		Where("id = ?", id).
+		Where("telegram_user_id = ?", userID).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete draft: %w", err)
+	}
+
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return ErrDraftNotFound
+	}
+
+	return nil
+}
+
+// PruneDrafts removes drafts created before the given time.
+func (r *Repository) PruneDrafts(ctx context.Context, before time.Time) (int64, error) {
+	res, err := r.db.NewDelete().
+		Model((*draftModel)(nil)).
+		Where("created_at < ?", before).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune drafts: %w", err)
+	}
+
+	rows, _ := res.RowsAffected()
+
+	return rows, nil
+}
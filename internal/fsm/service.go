@@ -4,53 +4,208 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strconv"
+	"time"
 
-	"github.com/go-core-fx/cachefx/cache"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/metrics"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 )
 
+// expireBatchSize limits the number of states expired in a single run.
+const expireBatchSize = 100
+
 type Service struct {
-	storage *cache.Typed[*State]
+	config Config
+	flows  flows
+
+	repo  *Repository
+	locks *userLocks
 
 	logger *zap.Logger
 }
 
-func NewService(storage cache.Cache, logger *zap.Logger) *Service {
+func NewService(config Config, flows []Flow, repo *Repository, logger *zap.Logger) *Service {
 	return &Service{
-		storage: cache.NewTyped[*State](storage),
-		logger:  logger,
+		config: config,
+		flows:  flows,
+
+		repo:  repo,
+		locks: newUserLocks(),
+
+		logger: logger,
 	}
 }
 
+// Lock serializes handling of updates of the user and returns the function releasing the lock.
+// Concurrent changes from other instances are still detected by Set.
+func (s *Service) Lock(userID int64) func() {
+	return s.locks.lock(userID)
+}
+
 func (s *Service) Get(ctx context.Context, userID int64) (*State, error) {
-	item, err := s.storage.Get(ctx, strconv.FormatInt(userID, 10))
-	if errors.Is(err, cache.ErrKeyNotFound) {
-		return &State{Name: "", Data: map[string]string{}}, nil
+	item, err := s.repo.Get(ctx, userID)
+	if errors.Is(err, ErrStateNotFound) {
+		metrics.FSMLookup(false)
+		return newState(), nil
 	}
 
 	if err != nil {
 		return nil, fmt.Errorf("get state: %w", err)
 	}
 
-	return item, nil
+	// The state may expire between runs of the scheduler, so it is expired on access too
+	if !item.ExpiresAt.After(time.Now()) {
+		metrics.FSMLookup(false)
+		if expErr := s.expire(ctx, item); expErr != nil {
+			return nil, expErr
+		}
+
+		return newState(), nil
+	}
+
+	metrics.FSMLookup(true)
+
+	return item.toState(), nil
 }
 
+// Set stores the state and extends its TTL. A state without a name and data is deleted.
+// It returns ErrConflict if the state was changed since it had been loaded.
 func (s *Service) Set(ctx context.Context, userID int64, state *State) error {
-	if err := s.storage.Set(ctx, strconv.FormatInt(userID, 10), state); err != nil {
+	if state.IsEmpty() {
+		return s.Delete(ctx, userID, state)
+	}
+
+	item := newStateModel(userID, state, time.Now().Add(s.config.TTL))
+
+	var err error
+	if state.version == 0 {
+		err = s.repo.Insert(ctx, item)
+	} else {
+		err = s.repo.Update(ctx, item)
+	}
+	if err != nil {
 		return fmt.Errorf("set state: %w", err)
 	}
 
+	state.commit(item.Version)
+
 	return nil
 }
 
-func (s *Service) Delete(ctx context.Context, userID int64) error {
-	if err := s.storage.Delete(ctx, strconv.FormatInt(userID, 10)); err != nil {
-		if errors.Is(err, cache.ErrKeyNotFound) {
-			return nil
+// Delete removes the stored state.
+// It returns ErrConflict if the state was changed since it had been loaded.
+func (s *Service) Delete(ctx context.Context, userID int64, state *State) error {
+	if state.version != 0 {
+		if err := s.repo.Delete(ctx, userID, state.version); err != nil {
+			return fmt.Errorf("delete state: %w", err)
 		}
-		return fmt.Errorf("delete state: %w", err)
+	}
+
+	state.Clear()
+	state.commit(0)
+
+	return nil
+}
+
+// Expire removes expired states, saving states of flows as drafts, and prunes old drafts.
+func (s *Service) Expire(ctx context.Context) error {
+	items, err := s.repo.ListExpired(ctx, time.Now(), expireBatchSize)
+	if err != nil {
+		return fmt.Errorf("list expired states: %w", err)
+	}
+
+	for i := range items {
+		if expErr := s.expire(ctx, &items[i]); expErr != nil {
+			return expErr
+		}
+	}
+
+	if s.config.DraftsTTL > 0 {
+		pruned, pruneErr := s.repo.PruneDrafts(ctx, time.Now().Add(-s.config.DraftsTTL))
+		if pruneErr != nil {
+			return fmt.Errorf("prune drafts: %w", pruneErr)
+		}
+
+		if pruned > 0 {
+			s.logger.Info("old drafts pruned", zap.Int64("count", pruned))
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) expire(ctx context.Context, item *stateModel) error {
+	_, keep := s.flows.find(item.Name)
+	if err := s.repo.Expire(ctx, item, keep); err != nil {
+		return fmt.Errorf("expire state: %w", err)
+	}
+
+	s.logger.Info("state expired",
+		zap.Int64("user_id", item.UserID),
+		zap.String("state", item.Name),
+		zap.Bool("draft", keep),
+	)
+
+	return nil
+}
+
+// Unnotified returns drafts the users were not told about yet.
+func (s *Service) Unnotified(ctx context.Context) ([]Draft, error) {
+	drafts, err := s.repo.ListUnnotified(ctx, expireBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("list unnotified drafts: %w", err)
+	}
+
+	return lo.Map(drafts, func(item draftModel, _ int) Draft { return s.toDraft(&item) }), nil
+}
+
+func (s *Service) MarkNotified(ctx context.Context, draftID int64) error {
+	if err := s.repo.MarkNotified(ctx, draftID, time.Now()); err != nil {
+		return fmt.Errorf("mark notified: %w", err)
 	}
 
 	return nil
 }
+
+// Drafts returns saved drafts of the user, newest first.
+func (s *Service) Drafts(ctx context.Context, userID int64) ([]Draft, error) {
+	drafts, err := s.repo.ListDrafts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list drafts: %w", err)
+	}
+
+	return lo.Map(drafts, func(item draftModel, _ int) Draft { return s.toDraft(&item) }), nil
+}
+
+// TakeDraft removes the draft of the user and returns it, so the caller can restore its state.
+func (s *Service) TakeDraft(ctx context.Context, userID, draftID int64) (*Draft, error) {
+	item, err := s.repo.GetDraft(ctx, userID, draftID)
+	if err != nil {
+		return nil, fmt.Errorf("get draft: %w", err)
+	}
+
+	if delErr := s.repo.DeleteDraft(ctx, userID, draftID); delErr != nil {
+		return nil, fmt.Errorf("delete draft: %w", delErr)
+	}
+
+	draft := s.toDraft(item)
+
+	return &draft, nil
+}
+
+func (s *Service) DiscardDraft(ctx context.Context, userID, draftID int64) error {
+	if err := s.repo.DeleteDraft(ctx, userID, draftID); err != nil {
+		return fmt.Errorf("delete draft: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) toDraft(item *draftModel) Draft {
+	flow, ok := s.flows.find(item.Name)
+	if !ok {
+		flow = Flow{Prefix: item.Name, Title: item.Name}
+	}
+
+	return item.toDraft(flow)
+}
@@ -0,0 +1,68 @@
+package giveaways
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	// NoChallengeAnswer is the Answer of a challenge without a pending question.
+	NoChallengeAnswer = -1
+
+	// challengeTTL is how long an unanswered challenge is kept. Lockouts are kept until they end.
+	challengeTTL = time.Hour
+)
+
+// Challenge is the captcha a user answers in the private chat to confirm the participation in a giveaway.
+type Challenge struct {
+	GiveawayID int64
+	UserID     int64
+	// Answer is the index of the correct option, NoChallengeAnswer when no question is pending.
+	Answer int
+	// Attempts is the number of wrong answers since the last lockout.
+	Attempts int
+	// LockedUntil is the end of the lockout after too many wrong answers, zero if the user isn't locked out.
+	LockedUntil time.Time
+}
+
+// IsLocked reports whether the user can't get a new question at the moment.
+func (c *Challenge) IsLocked(now time.Time) bool {
+	return now.Before(c.LockedUntil)
+}
+
+// GetChallenge returns the challenge of the user for the giveaway, a new one without a question if there is none.
+func (s *Service) GetChallenge(ctx context.Context, giveawayID, userID int64) (*Challenge, error) {
+	challenge, err := s.giveaways.GetChallenge(ctx, giveawayID, userID, time.Now())
+	if errors.Is(err, ErrChallengeNotFound) {
+		return &Challenge{
+			GiveawayID:  giveawayID,
+			UserID:      userID,
+			Answer:      NoChallengeAnswer,
+			Attempts:    0,
+			LockedUntil: time.Time{},
+		}, nil
+	}
+
+	return challenge, err
+}
+
+// SetChallenge stores the challenge until it expires or, for lockouts, until the lockout ends.
+func (s *Service) SetChallenge(ctx context.Context, challenge Challenge) error {
+	expiresAt := time.Now().Add(challengeTTL)
+	if challenge.LockedUntil.After(expiresAt) {
+		expiresAt = challenge.LockedUntil
+	}
+
+	return s.giveaways.SetChallenge(ctx, challenge, expiresAt)
+}
+
+// DeleteChallenge removes the challenge once the user answered it.
+func (s *Service) DeleteChallenge(ctx context.Context, giveawayID, userID int64) error {
+	return s.giveaways.DeleteChallenge(ctx, giveawayID, userID)
+}
+
+// PruneChallenges removes expired challenges and returns their number.
+func (s *Service) PruneChallenges(ctx context.Context) (int64, error) {
+	return s.giveaways.PruneChallenges(ctx, time.Now())
+}
@@ -5,11 +5,68 @@ import (
 	"time"
 
 	"github.com/capcom6/lucky-pick-tg-bot/internal/groups"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/users"
 )
 
 type GiveawayBase struct {
 }
 
+// TicketRules define bonus tickets a participant gets on top of the base one.
+type TicketRules struct {
+	// ReferralBonus is granted for each referred user who joins the same giveaway.
+	ReferralBonus int
+	// MembershipBonus is granted when the user joined the group at least MembershipDays ago.
+	// Only the joins the bot has seen count, see groups.Service.MemberSince.
+	MembershipDays  int
+	MembershipBonus int
+	// LoyaltyBonus is granted for each finished giveaway of the group the user took part in
+	// without winning since their last win.
+	LoyaltyBonus int
+}
+
+// IsEmpty returns true if no bonus tickets are granted.
+func (r TicketRules) IsEmpty() bool {
+	return r.ReferralBonus == 0 && (r.MembershipDays == 0 || r.MembershipBonus == 0) && r.LoyaltyBonus == 0
+}
+
+// CooldownRules restrict recent winners of the group from winning again.
+type CooldownRules struct {
+	// Days excludes users who won a giveaway of the group within the last Days days.
+	Days int
+	// Giveaways excludes winners of the last Giveaways finished giveaways of the group.
+	Giveaways int
+	// MonthlyCap excludes users who already won MonthlyCap giveaways of the group this month.
+	MonthlyCap int
+}
+
+// IsEmpty returns true if no cool-down is applied.
+func (r CooldownRules) IsEmpty() bool {
+	return r.Days == 0 && r.Giveaways == 0 && r.MonthlyCap == 0
+}
+
+// Decision is the result of reviewing a giveaway submitted for approval.
+type Decision string
+
+const (
+	DecisionApproved         Decision = "approved"
+	DecisionRejected         Decision = "rejected"
+	DecisionChangesRequested Decision = "changes_requested"
+)
+
+// Status returns the status of the giveaway after the decision. Drafts sent back for changes wait
+// for the author to revise and resubmit them.
+func (d Decision) Status() Status {
+	switch d {
+	case DecisionApproved:
+		return StatusScheduled
+	case DecisionChangesRequested:
+		return StatusChangesRequested
+	case DecisionRejected:
+	}
+
+	return StatusCancelled
+}
+
 type GiveawayDraft struct {
 	GroupID            int64
 	AdminUserID        int64
@@ -19,6 +76,10 @@ type GiveawayDraft struct {
 	ApplicationEndDate time.Time
 	ResultsDate        time.Time
 	IsAnonymous        bool
+	TicketRules        TicketRules
+	Draw               DrawRules
+	// Quiz is the question participants answer to enter the giveaway, nil for regular giveaways.
+	Quiz *Quiz
 }
 
 type GiveawayPrepared struct {
@@ -34,7 +95,12 @@ type Giveaway struct {
 
 	Group groups.GroupWithSettings
 
+	AdminTelegramID int64
+	AdminUsername   string
+	AdminFirstName  string
+
 	TelegramMessageID int64
+	PublishedAt       time.Time
 
 	WinnerUserID int64
 	Status       Status
@@ -51,15 +117,42 @@ type Participant struct {
 	UserUsername   string
 	UserFirstName  string
 
+	Tickets int
+
 	JoinedAt time.Time
 }
 
+// Ticket returns the participant's ticket number. It identifies the entry
+// without revealing the user, so it is used to announce anonymous winners.
+func (p *Participant) Ticket() string {
+	return fmt.Sprintf("%06d", p.ID)
+}
+
+// Participation is the result of joining a giveaway.
+type Participation struct {
+	Giveaway    Giveaway
+	Participant Participant
+
+	ParticipantsCount int
+}
+
 type Winner struct {
 	Giveaway    Giveaway
 	Participant *Participant
+	// Answer is the answer of the winner to the quiz, empty for regular giveaways.
+	Answer string
+	// Reason is why no winner was drawn, e.g. ErrAllParticipantsExcluded. It's nil when there is a winner.
+	Reason error
+	// ExcludedCount is the number of participants excluded from the draw by the cool-down rules.
+	ExcludedCount int
 }
 
 func newGiveaway(item GiveawayModel, group groups.GroupWithSettings) *Giveaway {
+	admin := item.Admin
+	if admin == nil {
+		admin = new(users.UserModel)
+	}
+
 	return &Giveaway{
 		GiveawayDraft: GiveawayDraft{
 			GroupID:            item.GroupID,
@@ -70,13 +163,29 @@ func newGiveaway(item GiveawayModel, group groups.GroupWithSettings) *Giveaway {
 			ApplicationEndDate: item.ApplicationEndDate,
 			ResultsDate:        item.ResultsDate,
 			IsAnonymous:        item.IsAnonymous,
+			TicketRules: TicketRules{
+				ReferralBonus:   item.ReferralBonus,
+				MembershipDays:  item.MembershipDays,
+				MembershipBonus: item.MembershipBonus,
+				LoyaltyBonus:    item.LoyaltyBonus,
+			},
+			Draw: DrawRules{
+				Strategy: item.DrawStrategy,
+				Param:    item.DrawParam,
+			},
+			Quiz: item.quiz(),
 		},
 
 		ID: item.ID,
 
 		Group: group,
 
+		AdminTelegramID: admin.TelegramUserID,
+		AdminUsername:   admin.Username,
+		AdminFirstName:  admin.FirstName,
+
 		TelegramMessageID: item.TelegramMessageID,
+		PublishedAt:       item.PublishedAt,
 
 		WinnerUserID: item.WinnerUserID,
 		Status:       item.Status,
@@ -114,6 +223,8 @@ func newParticipant(item *ParticipantModel) *Participant {
 		UserUsername:   item.User.Username,
 		UserFirstName:  item.User.FirstName,
 
+		Tickets: item.Tickets,
+
 		JoinedAt: item.JoinedAt,
 	}
 }
@@ -0,0 +1,157 @@
+package giveaways
+
+import (
+	"cmp"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+// DefaultDrawStrategy is the strategy of giveaways created before the strategies were introduced.
+const DefaultDrawStrategy = "weighted"
+
+// DrawStrategy selects the winner of a giveaway. Strategies are provided to the fx group "draw_strategies"
+// and chosen per giveaway by name.
+type DrawStrategy interface {
+	// Name identifies the strategy in the database and callback data, keep it short.
+	Name() string
+	// Label is the name of the strategy shown to admins.
+	Label() string
+	// Describe returns the rule shown to participants in the giveaway post.
+	Describe(param int) string
+	// Param describes the numeric parameter of the strategy, nil if there is none.
+	Param() *DrawParam
+	// Draw picks the winner, it returns ErrNotEnoughParticipants if nobody can win.
+	Draw(ctx context.Context, draw Draw) (*Participant, error)
+}
+
+// DrawParam describes the numeric parameter of a strategy.
+type DrawParam struct {
+	Label string
+	Min   int
+	Max   int
+}
+
+// Draw is the input of a strategy.
+type Draw struct {
+	GiveawayID int64
+	Param      int
+	// Participants are all participants in the order they joined. Strategies picking by the position count them,
+	// so excluded participants don't shift others into the picked positions.
+	Participants []Participant
+	// Candidates are the participants allowed to win in the order they joined.
+	Candidates []Participant
+}
+
+// Eligible returns the participants allowed to win, i.e. found among the candidates.
+func (d *Draw) Eligible(participants []Participant) []Participant {
+	ids := make(map[int64]struct{}, len(d.Candidates))
+	for _, candidate := range d.Candidates {
+		ids[candidate.ID] = struct{}{}
+	}
+
+	eligible := make([]Participant, 0, len(participants))
+	for _, participant := range participants {
+		if _, ok := ids[participant.ID]; ok {
+			eligible = append(eligible, participant)
+		}
+	}
+
+	return eligible
+}
+
+// DrawRules are the strategy of a giveaway and its parameter.
+type DrawRules struct {
+	Strategy string
+	Param    int
+}
+
+// PickRandom returns a uniformly random candidate.
+func PickRandom(candidates []Participant) (*Participant, error) {
+	if len(candidates) == 0 {
+		return nil, ErrNotEnoughParticipants
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(candidates))))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate random number: %w", err)
+	}
+
+	return &candidates[n.Int64()], nil
+}
+
+// DrawStrategies returns the registered strategies in the order of registration.
+func (s *Service) DrawStrategies() []DrawStrategy {
+	return slices.Clone(s.strategies)
+}
+
+// DrawStrategy returns the strategy by name.
+func (s *Service) DrawStrategy(name string) (DrawStrategy, error) {
+	for _, strategy := range s.strategies {
+		if strategy.Name() == name {
+			return strategy, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownStrategy, name)
+}
+
+// DescribeDraw returns the rule of the draw shown to participants, empty for an unknown strategy.
+func (s *Service) DescribeDraw(rules DrawRules) string {
+	strategy, err := s.DrawStrategy(rules.Strategy)
+	if err != nil {
+		return ""
+	}
+
+	return strategy.Describe(rules.Param)
+}
+
+func (s *Service) validateDraw(rules DrawRules) error {
+	strategy, err := s.DrawStrategy(rules.Strategy)
+	if err != nil {
+		return err
+	}
+
+	if param := strategy.Param(); param != nil && (rules.Param < param.Min || rules.Param > param.Max) {
+		return fmt.Errorf("%w: %s must be from %d to %d", ErrInvalidDrawParam, param.Label, param.Min, param.Max)
+	}
+
+	return nil
+}
+
+// draw picks the winner among the candidates with the strategy of the giveaway.
+func (s *Service) draw(
+	ctx context.Context,
+	giveaway *GiveawayModel,
+	candidates []*ParticipantModel,
+) (*Participant, error) {
+	strategy, err := s.DrawStrategy(giveaway.DrawStrategy)
+	if err != nil {
+		return nil, err
+	}
+
+	winner, err := strategy.Draw(ctx, Draw{
+		GiveawayID:   giveaway.ID,
+		Param:        giveaway.DrawParam,
+		Participants: inJoinOrder(giveaway.Participants),
+		Candidates:   inJoinOrder(candidates),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", strategy.Name(), err)
+	}
+
+	return winner, nil
+}
+
+// inJoinOrder returns the participants sorted in the order they joined.
+func inJoinOrder(items []*ParticipantModel) []Participant {
+	ordered := make([]Participant, 0, len(items))
+	for _, item := range items {
+		ordered = append(ordered, *newParticipant(item))
+	}
+	slices.SortFunc(ordered, func(a, b Participant) int { return cmp.Compare(a.ID, b.ID) })
+
+	return ordered
+}
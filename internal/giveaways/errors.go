@@ -3,7 +3,25 @@ package giveaways
 import "errors"
 
 var (
-	ErrLLMFailed             = errors.New("llm failed")
-	ErrNotEnoughParticipants = errors.New("not enough participants")
-	ErrNotFound              = errors.New("giveaway not found")
+	ErrLLMFailed                 = errors.New("llm failed")
+	ErrNotEnoughParticipants     = errors.New("not enough participants")
+	ErrAllParticipantsExcluded   = errors.New("all participants excluded by winner cool-down")
+	ErrAllParticipantsSuspicious = errors.New("all participants excluded as suspicious")
+	ErrNotFound                  = errors.New("giveaway not found")
+	ErrParticipantNotFound       = errors.New("participant not found")
+	ErrNotPendingApproval        = errors.New("giveaway is not pending approval")
+	ErrNotChangesRequested       = errors.New("giveaway is not sent back for changes")
+	ErrInvalidTransition         = errors.New("invalid giveaway status transition")
+	ErrNotStarted                = errors.New("giveaway has not started yet")
+	ErrClosed                    = errors.New("giveaway applications are closed")
+	ErrCancelled                 = errors.New("giveaway is cancelled")
+	ErrUnknownStrategy           = errors.New("unknown draw strategy")
+	ErrInvalidDrawParam          = errors.New("invalid draw strategy parameter")
+	ErrInvalidQuiz               = errors.New("invalid quiz")
+	ErrNotQuiz                   = errors.New("giveaway has no quiz")
+	ErrQuizRequired              = errors.New("giveaway requires answering the quiz")
+	ErrAlreadyAnswered           = errors.New("quiz is already answered")
+	ErrInvalidAnswer             = errors.New("invalid quiz answer")
+	ErrNoCorrectAnswers          = errors.New("no correct quiz answers")
+	ErrChallengeNotFound         = errors.New("challenge not found")
 )
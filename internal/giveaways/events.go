@@ -0,0 +1,86 @@
+package giveaways
+
+// GiveawayCreated is published when a giveaway is scheduled.
+type GiveawayCreated struct {
+	ID       int64
+	Giveaway GiveawayPrepared
+}
+
+func (GiveawayCreated) Name() string {
+	return "giveaway.created"
+}
+
+// GiveawaySubmitted is published when a draft is submitted for approval.
+type GiveawaySubmitted struct {
+	ID       int64
+	Giveaway GiveawayPrepared
+	// Revised is set when the draft was sent back for changes and is submitted again.
+	Revised bool
+}
+
+func (GiveawaySubmitted) Name() string {
+	return "giveaway.submitted"
+}
+
+// GiveawayReviewed is published when a giveaway pending approval is approved, rejected or sent back for changes.
+type GiveawayReviewed struct {
+	Giveaway   Giveaway
+	ReviewerID int64
+	Decision   Decision
+	Comment    string
+}
+
+func (GiveawayReviewed) Name() string {
+	return "giveaway.reviewed"
+}
+
+// GiveawayPublished is published when the giveaway post is sent to the group.
+type GiveawayPublished struct {
+	Giveaway Giveaway
+}
+
+func (GiveawayPublished) Name() string {
+	return "giveaway.published"
+}
+
+// ParticipantJoined is published when a user joins a giveaway for the first time.
+type ParticipantJoined struct {
+	Participation Participation
+}
+
+func (ParticipantJoined) Name() string {
+	return "giveaway.participant_joined"
+}
+
+// GiveawayClosed is published when applications to the giveaway are closed.
+type GiveawayClosed struct {
+	Giveaway          Giveaway
+	ParticipantsCount int
+}
+
+func (GiveawayClosed) Name() string {
+	return "giveaway.closed"
+}
+
+// GiveawayFinished is published when the winner of the giveaway is selected.
+type GiveawayFinished struct {
+	Winner Winner
+}
+
+func (GiveawayFinished) Name() string {
+	return "giveaway.finished"
+}
+
+// GiveawayCancelled is published when the giveaway ends without a winner or is cancelled by an organizer.
+type GiveawayCancelled struct {
+	Winner Winner
+	Reason string
+	// From is the status the giveaway was cancelled in.
+	From Status
+	// ActorID is the user who cancelled the giveaway, zero when it's cancelled by the draw.
+	ActorID int64
+}
+
+func (GiveawayCancelled) Name() string {
+	return "giveaway.cancelled"
+}
@@ -9,11 +9,16 @@ import (
 	"strings"
 	"time"
 
+	"github.com/capcom6/lucky-pick-tg-bot/internal/metrics"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/tracing"
 	"github.com/revrost/go-openrouter"
 	"github.com/revrost/go-openrouter/jsonschema"
 	"go.uber.org/zap"
 )
 
+// llmFeature labels LLM metrics and spans of the package.
+const llmFeature = "giveaway_description"
+
 const (
 	DescriptionGenerationPrompt = `Ты - администратор Telegram-группы розыгрышей.
 Твоя задача: придумывать веселое описание товара на фотографии длиной не более 150 слов.
@@ -109,16 +114,22 @@ func (l *LLM) MakeDescription(
 		},
 	}
 
-	res, err := l.client.CreateChatCompletion(ctx, request)
+	started := time.Now()
+	spanCtx, span := tracing.StartLLM(ctx, llmFeature, l.config.LLMModel)
+	res, err := l.client.CreateChatCompletion(spanCtx, request)
+	tracing.EndLLM(span, res.Usage, err)
+	metrics.ObserveLLM(llmFeature, l.config.LLMModel, started, res.Usage, err)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate description: %w", err)
 	}
 
 	if len(res.Choices) == 0 {
+		metrics.LLMFailed(llmFeature, l.config.LLMModel)
 		return "", fmt.Errorf("%w: no choices returned", ErrLLMFailed)
 	}
 
 	if jsonErr := json.Unmarshal([]byte(res.Choices[0].Message.Content.Text), answer); jsonErr != nil {
+		metrics.LLMFailed(llmFeature, l.config.LLMModel)
 		return "", fmt.Errorf("failed to unmarshal answer: %w", jsonErr)
 	}
 
@@ -1,6 +1,7 @@
 package giveaways
 
 import (
+	"strings"
 	"time"
 
 	"github.com/capcom6/lucky-pick-tg-bot/internal/groups"
@@ -11,11 +12,15 @@ import (
 type Status string
 
 const (
-	StatusScheduled Status = "scheduled"
-	StatusActive    Status = "active"
-	StatusClosed    Status = "closed"
-	StatusFinished  Status = "finished"
-	StatusCancelled Status = "cancelled"
+	// StatusPendingApproval is a draft submitted by an editor and waiting for an owner or manager.
+	StatusPendingApproval Status = "pending_approval"
+	// StatusChangesRequested is a draft sent back to its author, who revises and resubmits it.
+	StatusChangesRequested Status = "changes_requested"
+	StatusScheduled        Status = "scheduled"
+	StatusActive           Status = "active"
+	StatusClosed           Status = "closed"
+	StatusFinished         Status = "finished"
+	StatusCancelled        Status = "cancelled"
 )
 
 type GiveawayModel struct {
@@ -32,8 +37,18 @@ type GiveawayModel struct {
 	ApplicationEndDate  time.Time `bun:"application_end_date,notnull"`
 	ResultsDate         time.Time `bun:"results_date,notnull"`
 	IsAnonymous         bool      `bun:"is_anonymous,notnull"`
-
-	TelegramMessageID int64 `bun:"telegram_message_id,nullzero"`
+	ReferralBonus       int       `bun:"referral_bonus,notnull"`
+	MembershipDays      int       `bun:"membership_days,notnull"`
+	MembershipBonus     int       `bun:"membership_bonus,notnull"`
+	LoyaltyBonus        int       `bun:"loyalty_bonus,notnull"`
+	DrawStrategy        string    `bun:"draw_strategy,notnull"`
+	DrawParam           int       `bun:"draw_param,notnull"`
+	QuizMode            QuizMode  `bun:"quiz_mode,nullzero"`
+	QuizQuestion        string    `bun:"quiz_question,notnull"`
+	QuizAnswers         string    `bun:"quiz_answers,notnull"`
+
+	TelegramMessageID int64     `bun:"telegram_message_id,nullzero"`
+	PublishedAt       time.Time `bun:"published_at,nullzero"`
 
 	WinnerUserID int64  `bun:"winner_user_id,nullzero"`
 	Status       Status `bun:"status,notnull,default:'scheduled'"`
@@ -42,12 +57,13 @@ type GiveawayModel struct {
 	UpdatedAt time.Time `bun:"updated_at,scanonly"`
 
 	Group        *groups.GroupModel  `bun:"g,rel:belongs-to,join:group_id=id"`
+	Admin        *users.UserModel    `bun:"au,rel:belongs-to,join:admin_user_id=id"`
 	Participants []*ParticipantModel `bun:"gap,rel:has-many,join:id=giveaway_id"`
 }
 
-func newGiveawayModel(giveaway GiveawayPrepared) *GiveawayModel {
+func newGiveawayModel(giveaway GiveawayPrepared, status Status) *GiveawayModel {
 	//nolint:exhaustruct // partial constructor
-	return &GiveawayModel{
+	model := &GiveawayModel{
 		GroupID:             giveaway.GroupID,
 		AdminUserID:         giveaway.AdminUserID,
 		PhotoFileID:         giveaway.PhotoFileID,
@@ -57,41 +73,143 @@ func newGiveawayModel(giveaway GiveawayPrepared) *GiveawayModel {
 		ApplicationEndDate:  giveaway.ApplicationEndDate,
 		ResultsDate:         giveaway.ResultsDate,
 		IsAnonymous:         giveaway.IsAnonymous,
-		Status:              StatusScheduled,
+		ReferralBonus:       giveaway.TicketRules.ReferralBonus,
+		MembershipDays:      giveaway.TicketRules.MembershipDays,
+		MembershipBonus:     giveaway.TicketRules.MembershipBonus,
+		LoyaltyBonus:        giveaway.TicketRules.LoyaltyBonus,
+		DrawStrategy:        giveaway.Draw.Strategy,
+		DrawParam:           giveaway.Draw.Param,
+		Status:              status,
+	}
+
+	if quiz := giveaway.Quiz; quiz != nil {
+		model.QuizMode = quiz.Mode
+		model.QuizQuestion = quiz.Question
+		model.QuizAnswers = strings.Join(quiz.Answers, "\n")
+	}
+
+	return model
+}
+
+// quiz returns the quiz of the giveaway, nil if it has none.
+func (m *GiveawayModel) quiz() *Quiz {
+	if m.QuizMode == "" {
+		return nil
+	}
+
+	return &Quiz{
+		Question: m.QuizQuestion,
+		Mode:     m.QuizMode,
+		Answers:  strings.Split(m.QuizAnswers, "\n"),
 	}
 }
 
-func NewPublishGiveaway(id, messageID int64) *GiveawayModel {
+// StatusChangeModel is a record of the status history of a giveaway.
+type StatusChangeModel struct {
+	bun.BaseModel `bun:"table:giveaway_status_history,alias:gsh"`
+
+	ID          int64  `bun:"id,pk,autoincrement"`
+	GiveawayID  int64  `bun:"giveaway_id,notnull"`
+	FromStatus  Status `bun:"from_status,nullzero"`
+	ToStatus    Status `bun:"to_status,notnull"`
+	ActorUserID int64  `bun:"actor_user_id,nullzero"`
+	Reason      string `bun:"reason,notnull"`
+
+	CreatedAt time.Time `bun:"created_at,scanonly"`
+}
+
+// maxReasonLength is the size of the reason column in characters.
+const maxReasonLength = 255
+
+func newStatusChangeModel(giveawayID int64, from, to Status, actorUserID int64, reason string) *StatusChangeModel {
+	if r := []rune(reason); len(r) > maxReasonLength {
+		reason = string(r[:maxReasonLength])
+	}
+
 	//nolint:exhaustruct // partial constructor
-	return &GiveawayModel{
-		ID:                id,
-		TelegramMessageID: messageID,
-		Status:            StatusActive,
+	return &StatusChangeModel{
+		GiveawayID:  giveawayID,
+		FromStatus:  from,
+		ToStatus:    to,
+		ActorUserID: actorUserID,
+		Reason:      reason,
 	}
 }
 
-func NewCloseGiveaway(id int64) *GiveawayModel {
+// ReminderModel is a record of a reminder sent to the group of a giveaway.
+type ReminderModel struct {
+	bun.BaseModel `bun:"table:giveaway_reminders,alias:grm"`
+
+	ID            int64        `bun:"id,pk,autoincrement"`
+	GiveawayID    int64        `bun:"giveaway_id,notnull"`
+	Kind          ReminderKind `bun:"kind,notnull"`
+	OffsetMinutes int          `bun:"offset_minutes,notnull"`
+
+	CreatedAt time.Time `bun:"created_at,scanonly"`
+}
+
+func newReminderModel(giveawayID int64, kind ReminderKind, offset time.Duration) *ReminderModel {
 	//nolint:exhaustruct // partial constructor
-	return &GiveawayModel{
-		ID:     id,
-		Status: StatusClosed,
+	return &ReminderModel{
+		GiveawayID:    giveawayID,
+		Kind:          kind,
+		OffsetMinutes: int(offset / time.Minute),
 	}
 }
 
-func NewFinishGiveaway(id, winnerID int64) *GiveawayModel {
+// QuizAnswerModel is the answer of a user to the quiz of a giveaway.
+type QuizAnswerModel struct {
+	bun.BaseModel `bun:"table:giveaway_quiz_answers,alias:gqa"`
+
+	ID         int64  `bun:"id,pk,autoincrement"`
+	GiveawayID int64  `bun:"giveaway_id,notnull"`
+	UserID     int64  `bun:"user_id,notnull"`
+	Answer     string `bun:"answer,notnull"`
+
+	CreatedAt time.Time `bun:"created_at,scanonly"`
+}
+
+func newQuizAnswerModel(giveawayID, userID int64, answer string) *QuizAnswerModel {
 	//nolint:exhaustruct // partial constructor
-	return &GiveawayModel{
-		ID:           id,
-		WinnerUserID: winnerID,
-		Status:       StatusFinished,
+	return &QuizAnswerModel{
+		GiveawayID: giveawayID,
+		UserID:     userID,
+		Answer:     answer,
 	}
 }
 
-func NewCancelGiveaway(id int64) *GiveawayModel {
+// challengeModel is the pending captcha of a user for a giveaway.
+type challengeModel struct {
+	bun.BaseModel `bun:"table:giveaway_challenges,alias:gch"`
+
+	ID          int64     `bun:"id,pk,autoincrement"`
+	GiveawayID  int64     `bun:"giveaway_id,notnull"`
+	UserID      int64     `bun:"user_id,notnull"`
+	Answer      int       `bun:"answer,notnull"`
+	Attempts    int       `bun:"attempts,notnull"`
+	LockedUntil time.Time `bun:"locked_until,nullzero"`
+	ExpiresAt   time.Time `bun:"expires_at,notnull"`
+}
+
+func newChallengeModel(challenge Challenge, expiresAt time.Time) *challengeModel {
 	//nolint:exhaustruct // partial constructor
-	return &GiveawayModel{
-		ID:     id,
-		Status: StatusCancelled,
+	return &challengeModel{
+		GiveawayID:  challenge.GiveawayID,
+		UserID:      challenge.UserID,
+		Answer:      challenge.Answer,
+		Attempts:    challenge.Attempts,
+		LockedUntil: challenge.LockedUntil,
+		ExpiresAt:   expiresAt,
+	}
+}
+
+func (m *challengeModel) toChallenge() *Challenge {
+	return &Challenge{
+		GiveawayID:  m.GiveawayID,
+		UserID:      m.UserID,
+		Answer:      m.Answer,
+		Attempts:    m.Attempts,
+		LockedUntil: m.LockedUntil,
 	}
 }
 
@@ -101,16 +219,18 @@ type ParticipantModel struct {
 	ID         int64 `bun:"id,pk,autoincrement"`
 	GiveawayID int64 `bun:"giveaway_id,notnull"`
 	UserID     int64 `bun:"user_id,notnull"`
+	Tickets    int   `bun:"tickets,notnull"`
 
 	JoinedAt time.Time `bun:"joined_at,scanonly"`
 
 	User *users.UserModel `bun:"u,rel:belongs-to,join:user_id=id"`
 }
 
-func NewParticipantModel(giveawayID, userID int64) *ParticipantModel {
+func NewParticipantModel(giveawayID, userID int64, tickets int) *ParticipantModel {
 	//nolint:exhaustruct // partial constructor
 	return &ParticipantModel{
 		GiveawayID: giveawayID,
 		UserID:     userID,
+		Tickets:    tickets,
 	}
 }
@@ -12,7 +12,14 @@ func Module() fx.Option {
 		logger.WithNamedLogger("giveaways"),
 		fx.Provide(NewRepository, fx.Private),
 		fx.Provide(NewLLM, fx.Private),
-		fx.Provide(NewService),
+		fx.Provide(fx.Annotate(NewUniformStrategy, fx.ResultTags(`group:"draw_strategies"`))),
+		fx.Provide(fx.Annotate(NewWeightedStrategy, fx.ResultTags(`group:"draw_strategies"`))),
+		fx.Provide(fx.Annotate(NewFirstStrategy, fx.ResultTags(`group:"draw_strategies"`))),
+		fx.Provide(fx.Annotate(NewNthStrategy, fx.ResultTags(`group:"draw_strategies"`))),
+		fx.Provide(fx.Annotate(
+			NewService,
+			fx.ParamTags(``, ``, ``, ``, ``, `group:"draw_strategies"`),
+		)),
 		fx.Invoke(func(settingsSvc *settings.Service) {
 			for _, v := range SettingDefinitions() {
 				settingsSvc.RegisterDefinition(v)
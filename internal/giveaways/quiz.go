@@ -0,0 +1,384 @@
+package giveaways
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/samber/lo"
+)
+
+// QuizPrefix is the `/start` payload prefix of the deep links answering the quiz of a giveaway.
+const QuizPrefix = "quiz_"
+
+const (
+	// MaxQuizQuestionLength is the longest question in characters.
+	MaxQuizQuestionLength = 255
+	// MaxQuizAnswerLength is the longest answer of a participant in characters.
+	MaxQuizAnswerLength = 255
+
+	maxQuizAnswersLength = 1024
+)
+
+// QuizMode is the way the answers of a quiz are checked.
+type QuizMode string
+
+const (
+	// QuizExact accepts the answers matching one of the accepted ones exactly.
+	QuizExact QuizMode = "exact"
+	// QuizCaseInsensitive accepts the answers matching one of the accepted ones ignoring the case.
+	QuizCaseInsensitive QuizMode = "case_insensitive"
+	// QuizClosest accepts the numbers closest to the correct one.
+	QuizClosest QuizMode = "closest"
+	// QuizRange accepts the numbers within the bounds.
+	QuizRange QuizMode = "range"
+)
+
+// QuizModes returns the supported modes in the order they are offered to admins.
+func QuizModes() []QuizMode {
+	return []QuizMode{QuizExact, QuizCaseInsensitive, QuizClosest, QuizRange}
+}
+
+// Label returns the name of the mode shown to admins.
+func (m QuizMode) Label() string {
+	switch m {
+	case QuizExact:
+		return "✍️ Exact answer"
+	case QuizCaseInsensitive:
+		return "🔡 Answer in any case"
+	case QuizClosest:
+		return "🎯 Closest number wins"
+	case QuizRange:
+		return "📏 Number in range"
+	}
+
+	return string(m)
+}
+
+// IsNumeric reports whether the mode expects numbers as answers.
+func (m QuizMode) IsNumeric() bool {
+	return m == QuizClosest || m == QuizRange
+}
+
+// Quiz is the question participants answer to enter the giveaway. The draw runs among the correct answers.
+type Quiz struct {
+	Question string
+	Mode     QuizMode
+	// Answers are the accepted answers of the text modes, the correct number of QuizClosest
+	// or the lower and upper bounds of QuizRange.
+	Answers []string
+}
+
+// ParseQuizAnswers parses the accepted answers entered by an admin: one answer per line for the text modes,
+// a number for QuizClosest and two numbers separated by a space for QuizRange.
+func ParseQuizAnswers(mode QuizMode, text string) ([]string, error) {
+	var answers []string
+	switch mode {
+	case QuizExact, QuizCaseInsensitive:
+		for line := range strings.SplitSeq(text, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				answers = append(answers, line)
+			}
+		}
+	case QuizClosest, QuizRange:
+		answers = strings.Fields(text)
+	default:
+		return nil, fmt.Errorf("%w: unknown mode %q", ErrInvalidQuiz, mode)
+	}
+
+	quiz := Quiz{Question: "?", Mode: mode, Answers: answers}
+	if err := quiz.validateAnswers(); err != nil {
+		return nil, err
+	}
+
+	return answers, nil
+}
+
+// Validate checks the quiz is complete and its answers match the mode.
+func (q *Quiz) Validate() error {
+	question := strings.TrimSpace(q.Question)
+	if question == "" {
+		return fmt.Errorf("%w: empty question", ErrInvalidQuiz)
+	}
+	if utf8.RuneCountInString(question) > MaxQuizQuestionLength {
+		return fmt.Errorf("%w: question is longer than %d characters", ErrInvalidQuiz, MaxQuizQuestionLength)
+	}
+
+	return q.validateAnswers()
+}
+
+func (q *Quiz) validateAnswers() error {
+	if utf8.RuneCountInString(strings.Join(q.Answers, "\n")) > maxQuizAnswersLength {
+		return fmt.Errorf("%w: answers are longer than %d characters", ErrInvalidQuiz, maxQuizAnswersLength)
+	}
+
+	switch q.Mode {
+	case QuizExact, QuizCaseInsensitive:
+		if len(q.Answers) == 0 {
+			return fmt.Errorf("%w: no accepted answers", ErrInvalidQuiz)
+		}
+	case QuizClosest:
+		if len(q.Answers) != 1 {
+			return fmt.Errorf("%w: a single number expected", ErrInvalidQuiz)
+		}
+		if _, err := parseQuizNumber(q.Answers[0]); err != nil {
+			return err
+		}
+	case QuizRange:
+		bounds, err := q.bounds()
+		if err != nil {
+			return err
+		}
+		if bounds[0] > bounds[1] {
+			return fmt.Errorf("%w: the lower bound is greater than the upper one", ErrInvalidQuiz)
+		}
+	default:
+		return fmt.Errorf("%w: unknown mode %q", ErrInvalidQuiz, q.Mode)
+	}
+
+	return nil
+}
+
+// ValidateAnswer checks the answer of a participant can be accepted, i.e. it's a number for the numeric modes.
+// It doesn't tell whether the answer is correct.
+func (q *Quiz) ValidateAnswer(answer string) error {
+	if answer == "" || utf8.RuneCountInString(answer) > MaxQuizAnswerLength {
+		return ErrInvalidAnswer
+	}
+
+	if q.Mode.IsNumeric() {
+		if _, err := parseQuizNumber(answer); err != nil {
+			return ErrInvalidAnswer
+		}
+	}
+
+	return nil
+}
+
+// CorrectAnswer returns the correct answer as shown to participants after the draw.
+func (q *Quiz) CorrectAnswer() string {
+	switch q.Mode {
+	case QuizRange:
+		if len(q.Answers) == 2 { //nolint:mnd // lower and upper bounds
+			return fmt.Sprintf("от %s до %s", q.Answers[0], q.Answers[1])
+		}
+	case QuizExact, QuizCaseInsensitive, QuizClosest:
+	}
+
+	return strings.Join(q.Answers, " / ")
+}
+
+// isCorrect reports whether the answer is accepted by the text and range modes.
+func (q *Quiz) isCorrect(answer string) bool {
+	answer = strings.TrimSpace(answer)
+
+	switch q.Mode {
+	case QuizExact:
+		for _, accepted := range q.Answers {
+			if answer == accepted {
+				return true
+			}
+		}
+	case QuizCaseInsensitive:
+		for _, accepted := range q.Answers {
+			if strings.EqualFold(answer, accepted) {
+				return true
+			}
+		}
+	case QuizRange:
+		bounds, err := q.bounds()
+		if err != nil {
+			return false
+		}
+		value, err := parseQuizNumber(answer)
+		return err == nil && value >= bounds[0] && value <= bounds[1]
+	case QuizClosest:
+	}
+
+	return false
+}
+
+// distance returns how far the answer is from the correct number of QuizClosest, false if it's not a number.
+func (q *Quiz) distance(answer string) (float64, bool) {
+	if len(q.Answers) != 1 {
+		return 0, false
+	}
+
+	correct, err := parseQuizNumber(q.Answers[0])
+	if err != nil {
+		return 0, false
+	}
+
+	value, err := parseQuizNumber(answer)
+	if err != nil {
+		return 0, false
+	}
+
+	return math.Abs(value - correct), true
+}
+
+func (q *Quiz) bounds() ([2]float64, error) {
+	if len(q.Answers) != 2 { //nolint:mnd // lower and upper bounds
+		return [2]float64{}, fmt.Errorf("%w: two numbers expected", ErrInvalidQuiz)
+	}
+
+	lower, err := parseQuizNumber(q.Answers[0])
+	if err != nil {
+		return [2]float64{}, err
+	}
+
+	upper, err := parseQuizNumber(q.Answers[1])
+	if err != nil {
+		return [2]float64{}, err
+	}
+
+	return [2]float64{lower, upper}, nil
+}
+
+// parseQuizNumber parses a number accepting both the point and the comma as the decimal separator.
+func parseQuizNumber(s string) (float64, error) {
+	value, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(s), ",", "."), 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("%w: %q is not a number", ErrInvalidQuiz, s)
+	}
+
+	return value, nil
+}
+
+// QuizCode returns the `/start` payload of the deep link answering the quiz of the giveaway.
+func QuizCode(giveawayID int64) string {
+	return QuizPrefix + strconv.FormatInt(giveawayID, 36)
+}
+
+// ParseQuizCode returns the ID of the giveaway of the quiz deep link.
+func ParseQuizCode(code string) (int64, error) {
+	if !strings.HasPrefix(code, QuizPrefix) {
+		return 0, fmt.Errorf("%w: missing prefix", ErrNotFound)
+	}
+
+	id, err := strconv.ParseInt(strings.TrimPrefix(code, QuizPrefix), 36, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%w: invalid code %q", ErrNotFound, code)
+	}
+
+	return id, nil
+}
+
+// GetQuiz returns the giveaway if the user can answer its quiz now.
+func (s *Service) GetQuiz(ctx context.Context, giveawayID, userID int64) (*Giveaway, error) {
+	item, err := s.giveaways.GetByID(ctx, giveawayID)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.QuizMode == "" {
+		return nil, ErrNotQuiz
+	}
+
+	if statusErr := canParticipate(item, time.Now()); statusErr != nil {
+		return nil, statusErr
+	}
+
+	// Answering enters the giveaway, so participants have answered already
+	entered, err := s.IsParticipant(ctx, giveawayID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if entered {
+		return nil, ErrAlreadyAnswered
+	}
+
+	group, err := s.groupsSvc.GetByID(ctx, item.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+
+	return newGiveaway(*item, *group), nil
+}
+
+// AnswerQuiz records the answer of the user and enters them into the giveaway. Every user answers once,
+// the answer can't be changed once they entered. Until then, e.g. when entering failed, the next answer
+// replaces the recorded one, so the user can retry. Whether it is correct is revealed only with the results.
+func (s *Service) AnswerQuiz(ctx context.Context, giveawayID, userID int64, answer string) (*Participation, error) {
+	item, err := s.giveaways.GetByID(ctx, giveawayID)
+	if err != nil {
+		return nil, err
+	}
+
+	quiz := item.quiz()
+	if quiz == nil {
+		return nil, ErrNotQuiz
+	}
+
+	if statusErr := canParticipate(item, time.Now()); statusErr != nil {
+		return nil, statusErr
+	}
+
+	answer = strings.TrimSpace(answer)
+	if validErr := quiz.ValidateAnswer(answer); validErr != nil {
+		return nil, validErr
+	}
+
+	entered, err := s.IsParticipant(ctx, giveawayID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if entered {
+		return nil, ErrAlreadyAnswered
+	}
+
+	if setErr := s.giveaways.SetQuizAnswer(ctx, newQuizAnswerModel(giveawayID, userID, answer)); setErr != nil {
+		return nil, setErr
+	}
+
+	return s.participate(ctx, item, userID)
+}
+
+// quizCandidates keeps the candidates who answered correctly or, for QuizClosest, gave the closest guesses.
+// It also returns the answers by user ID.
+func (s *Service) quizCandidates(
+	ctx context.Context,
+	giveawayID int64,
+	quiz *Quiz,
+	candidates []*ParticipantModel,
+) ([]*ParticipantModel, map[int64]string, error) {
+	items, err := s.giveaways.ListQuizAnswers(ctx, giveawayID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	answers := lo.SliceToMap(items, func(item QuizAnswerModel) (int64, string) { return item.UserID, item.Answer })
+
+	if quiz.Mode != QuizClosest {
+		return lo.Filter(candidates, func(item *ParticipantModel, _ int) bool {
+			answer, ok := answers[item.UserID]
+			return ok && quiz.isCorrect(answer)
+		}), answers, nil
+	}
+
+	// Ties are resolved by the draw strategy
+	best := math.Inf(1)
+	closest := make([]*ParticipantModel, 0)
+	for _, candidate := range candidates {
+		answer, ok := answers[candidate.UserID]
+		if !ok {
+			continue
+		}
+
+		d, ok := quiz.distance(answer)
+		switch {
+		case !ok:
+		case d < best:
+			best = d
+			closest = []*ParticipantModel{candidate}
+		case d == best:
+			closest = append(closest, candidate)
+		}
+	}
+
+	return closest, answers, nil
+}
@@ -0,0 +1,161 @@
+package giveaways
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ReminderKind is the deadline a reminder is sent before.
+type ReminderKind string
+
+const (
+	ReminderApplicationEnd ReminderKind = "application_end"
+	ReminderResults        ReminderKind = "results"
+)
+
+// Reminder is a message to the group about an upcoming deadline of the giveaway.
+type Reminder struct {
+	Giveaway Giveaway
+	Kind     ReminderKind
+	// Offset is the configured time before the deadline.
+	Offset time.Duration
+	// Deadline is the end of applications or the results date, depending on the kind.
+	Deadline time.Time
+
+	// claimed are the offsets recorded by the claim, ReleaseReminder removes exactly them.
+	claimed []time.Duration
+}
+
+// ClaimReminders returns the reminders due at the moment and records them, so they are never sent twice.
+// When several offsets of the same deadline are due at once, e.g. after a downtime, all of them are recorded
+// but only the closest to the deadline is returned, the farther ones are skipped. The reminder is returned
+// only if its closest offset wasn't recorded before.
+func (s *Service) ClaimReminders(ctx context.Context, now time.Time) ([]Reminder, error) {
+	items, err := s.giveaways.ListInProgress(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	grps, err := s.selectGroups(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+
+	reminders := make([]Reminder, 0)
+	for _, item := range items {
+		logger := s.logger.With(zap.Int64("giveaway_id", item.ID))
+
+		g, ok := grps[item.GroupID]
+		if !ok {
+			logger.Error("group not found", zap.Int64("group_id", item.GroupID))
+			continue
+		}
+
+		settings, setErr := NewSettings(g.Settings)
+		if setErr != nil {
+			logger.Error("failed to parse group settings", zap.Error(setErr))
+			continue
+		}
+
+		giveaway := newGiveaway(item, g)
+
+		deadlines := map[ReminderKind]time.Time{
+			ReminderResults: giveaway.ResultsDate,
+		}
+		offsets := map[ReminderKind][]time.Duration{
+			ReminderResults: {settings.Reminders.Results},
+		}
+		if giveaway.Status == StatusActive {
+			deadlines[ReminderApplicationEnd] = giveaway.ApplicationEndDate
+			offsets[ReminderApplicationEnd] = settings.Reminders.ApplicationEnd
+		}
+
+		for kind, deadline := range deadlines {
+			reminder, claimed, claimErr := s.claimReminder(ctx, giveaway, kind, deadline, offsets[kind], now)
+			if claimErr != nil {
+				logger.Error("failed to claim reminder", zap.String("kind", string(kind)), zap.Error(claimErr))
+				continue
+			}
+			if claimed {
+				reminders = append(reminders, reminder)
+			}
+		}
+	}
+
+	return reminders, nil
+}
+
+// ReleaseReminder removes the records made by the claim of a reminder which failed to be sent,
+// so it's retried on the next run.
+func (s *Service) ReleaseReminder(ctx context.Context, reminder Reminder) error {
+	for _, offset := range reminder.claimed {
+		if err := s.giveaways.DeleteReminder(ctx, newReminderModel(reminder.Giveaway.ID, reminder.Kind, offset)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) claimReminder(
+	ctx context.Context,
+	giveaway *Giveaway,
+	kind ReminderKind,
+	deadline time.Time,
+	offsets []time.Duration,
+	now time.Time,
+) (Reminder, bool, error) {
+	due := dueOffsets(deadline, offsets, now)
+	if len(due) == 0 {
+		return Reminder{}, false, nil
+	}
+
+	claimed := make([]time.Duration, 0, len(due))
+	for _, offset := range due {
+		created, err := s.giveaways.AddReminder(ctx, newReminderModel(giveaway.ID, kind, offset))
+		if err != nil {
+			return Reminder{}, false, err
+		}
+		if created {
+			claimed = append(claimed, offset)
+		}
+	}
+
+	// The closest offset goes last, it is the one reported. If it was recorded before, the reminder is
+	// already sent and the newly recorded farther offsets are just skipped.
+	closest := due[len(due)-1]
+	if !slices.Contains(claimed, closest) {
+		return Reminder{}, false, nil
+	}
+
+	return Reminder{
+		Giveaway: *giveaway,
+		Kind:     kind,
+		Offset:   closest,
+		Deadline: deadline,
+
+		claimed: claimed,
+	}, true, nil
+}
+
+// dueOffsets returns the positive offsets passed before the deadline, from the farthest to the closest.
+func dueOffsets(deadline time.Time, offsets []time.Duration, now time.Time) []time.Duration {
+	if !now.Before(deadline) {
+		return nil
+	}
+
+	due := make([]time.Duration, 0, len(offsets))
+	for _, offset := range offsets {
+		if offset > 0 && !now.Before(deadline.Add(-offset)) {
+			due = append(due, offset)
+		}
+	}
+
+	slices.SortFunc(due, func(a, b time.Duration) int { return cmp.Compare(b, a) })
+
+	return slices.Compact(due)
+}
@@ -2,7 +2,10 @@ package giveaways
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/uptrace/bun"
 )
@@ -26,6 +29,7 @@ func (r *Repository) ListByIDs(ctx context.Context, giveawayIDs []int64) ([]Give
 	if err := r.db.NewSelect().
 		Model(&giveaways).
 		Relation("Group").
+		Relation("Admin").
 		Where("ga.id IN (?)", bun.In(giveawayIDs)).
 		Scan(ctx); err != nil {
 		return nil, fmt.Errorf("failed to get giveaways by IDs: %w", err)
@@ -34,11 +38,13 @@ func (r *Repository) ListByIDs(ctx context.Context, giveawayIDs []int64) ([]Give
 	return giveaways, nil
 }
 
+// ListReadyToPublish returns the scheduled giveaways due to publish. Drafts pending approval are never selected.
 func (r *Repository) ListReadyToPublish(ctx context.Context) ([]GiveawayModel, error) {
 	giveaways := make([]GiveawayModel, 0)
 	if err := r.db.NewSelect().
 		Model(&giveaways).
 		Relation("Group").
+		Relation("Admin").
 		Where("ga.status = ?", StatusScheduled).
 		Where("ga.publish_date <= NOW()").
 		Where("g.is_active = ?", true).
@@ -49,11 +55,32 @@ func (r *Repository) ListReadyToPublish(ctx context.Context) ([]GiveawayModel, e
 	return giveaways, nil
 }
 
+// ListOpen returns the giveaways of the groups that are not finished or cancelled yet.
+func (r *Repository) ListOpen(ctx context.Context, groupIDs []int64) ([]GiveawayModel, error) {
+	giveaways := make([]GiveawayModel, 0)
+	if len(groupIDs) == 0 {
+		return giveaways, nil
+	}
+
+	if err := r.db.NewSelect().
+		Model(&giveaways).
+		Relation("Admin").
+		Where("ga.group_id IN (?)", bun.In(groupIDs)).
+		Where("ga.status NOT IN (?)", bun.In([]Status{StatusFinished, StatusCancelled})).
+		Order("ga.publish_date").
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get open giveaways: %w", err)
+	}
+
+	return giveaways, nil
+}
+
 func (r *Repository) ListActive(ctx context.Context) ([]GiveawayModel, error) {
 	giveaways := make([]GiveawayModel, 0)
 	if err := r.db.NewSelect().
 		Model(&giveaways).
 		Relation("Group").
+		Relation("Admin").
 		Where("ga.status = ?", StatusActive).
 		Where("ga.application_end_date > NOW()").
 		Where("g.is_active = ?", true).
@@ -69,6 +96,7 @@ func (r *Repository) ListApplicationFinished(ctx context.Context) ([]GiveawayMod
 	if err := r.db.NewSelect().
 		Model(&giveaways).
 		Relation("Group").
+		Relation("Admin").
 		Where("ga.status = ?", StatusActive).
 		Where("ga.application_end_date <= NOW()").
 		Where("g.is_active = ?", true).
@@ -84,6 +112,7 @@ func (r *Repository) ListResultsWait(ctx context.Context) ([]GiveawayModel, erro
 	if err := r.db.NewSelect().
 		Model(&giveaways).
 		Relation("Group").
+		Relation("Admin").
 		Relation("Participants").
 		Relation("Participants.User").
 		Where("ga.status = ?", StatusClosed).
@@ -96,57 +125,419 @@ func (r *Repository) ListResultsWait(ctx context.Context) ([]GiveawayModel, erro
 	return giveaways, nil
 }
 
+// ListInProgress returns published giveaways with the results yet to be announced.
+func (r *Repository) ListInProgress(ctx context.Context) ([]GiveawayModel, error) {
+	giveaways := make([]GiveawayModel, 0)
+	if err := r.db.NewSelect().
+		Model(&giveaways).
+		Relation("Group").
+		Relation("Admin").
+		Where("ga.status IN (?)", bun.In([]Status{StatusActive, StatusClosed})).
+		Where("ga.results_date > NOW()").
+		Where("g.is_active = ?", true).
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get giveaways in progress: %w", err)
+	}
+
+	return giveaways, nil
+}
+
+// ListWinnersSince returns finished giveaways of the group with results announced since the given time.
+func (r *Repository) ListWinnersSince(ctx context.Context, groupID int64, since time.Time) ([]GiveawayModel, error) {
+	giveaways := make([]GiveawayModel, 0)
+	if err := r.db.NewSelect().
+		Model(&giveaways).
+		Column("ga.id", "ga.winner_user_id", "ga.results_date").
+		Where("ga.group_id = ?", groupID).
+		Where("ga.status = ?", StatusFinished).
+		Where("ga.winner_user_id IS NOT NULL").
+		Where("ga.results_date >= ?", since).
+		Order("ga.results_date DESC").
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get recent winners: %w", err)
+	}
+
+	return giveaways, nil
+}
+
+// ListLastWinners returns the last finished giveaways of the group.
+func (r *Repository) ListLastWinners(ctx context.Context, groupID int64, limit int) ([]GiveawayModel, error) {
+	giveaways := make([]GiveawayModel, 0, limit)
+	if err := r.db.NewSelect().
+		Model(&giveaways).
+		Column("ga.id", "ga.winner_user_id", "ga.results_date").
+		Where("ga.group_id = ?", groupID).
+		Where("ga.status = ?", StatusFinished).
+		Where("ga.winner_user_id IS NOT NULL").
+		Order("ga.results_date DESC").
+		Limit(limit).
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get last winners: %w", err)
+	}
+
+	return giveaways, nil
+}
+
 func (r *Repository) GetByID(ctx context.Context, giveawayID int64) (*GiveawayModel, error) {
 	giveaway := new(GiveawayModel)
 	if err := r.db.NewSelect().
 		Model(giveaway).
 		Relation("Group").
+		Relation("Admin").
 		Where("ga.id = ?", giveawayID).
-		Scan(ctx); err != nil {
+		Scan(ctx); errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: ID %d", ErrNotFound, giveawayID)
+	} else if err != nil {
 		return nil, fmt.Errorf("failed to get giveaway by ID: %w", err)
 	}
 
 	return giveaway, nil
 }
 
-func (r *Repository) Update(ctx context.Context, giveaway *GiveawayModel) error {
-	_, err := r.db.NewUpdate().
-		Model(giveaway).
-		OmitZero().
-		WherePK().
+// Transition updates the giveaway if it is still in the status `from` and records the change in the history.
+// ErrInvalidTransition is returned if the status was changed concurrently.
+func (r *Repository) Transition(
+	ctx context.Context,
+	update *GiveawayModel,
+	from Status,
+	change *StatusChangeModel,
+) error {
+	return r.transition(ctx, update, from, change, func(q *bun.UpdateQuery) *bun.UpdateQuery {
+		return q.OmitZero()
+	})
+}
+
+// Revise replaces the content of the giveaway along with the status change. Unlike Transition, zero values
+// are saved too, e.g. a removed quiz.
+func (r *Repository) Revise(
+	ctx context.Context,
+	update *GiveawayModel,
+	from Status,
+	change *StatusChangeModel,
+) error {
+	return r.transition(ctx, update, from, change, func(q *bun.UpdateQuery) *bun.UpdateQuery {
+		return q.Column(
+			"photo_file_id",
+			"description",
+			"original_description",
+			"publish_date",
+			"application_end_date",
+			"results_date",
+			"is_anonymous",
+			"referral_bonus",
+			"membership_days",
+			"membership_bonus",
+			"loyalty_bonus",
+			"draw_strategy",
+			"draw_param",
+			"quiz_mode",
+			"quiz_question",
+			"quiz_answers",
+			"status",
+		)
+	})
+}
+
+// transition applies the update if the giveaway is still in the from status and records the change.
+func (r *Repository) transition(
+	ctx context.Context,
+	update *GiveawayModel,
+	from Status,
+	change *StatusChangeModel,
+	columns func(*bun.UpdateQuery) *bun.UpdateQuery,
+) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewUpdate().
+			Model(update).
+			Apply(columns).
+			WherePK().
+			Where("status = ?", from).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to update giveaway: %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %w", err)
+		}
+		if affected == 0 {
+			return fmt.Errorf("%w: giveaway %d is no longer %s", ErrInvalidTransition, update.ID, from)
+		}
+
+		if _, err = tx.NewInsert().Model(change).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to record status change: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *Repository) AddParticipant(ctx context.Context, participant *ParticipantModel) (bool, error) {
+	res, err := r.db.NewInsert().
+		Ignore().
+		Model(participant).
 		Exec(ctx)
 
 	if err != nil {
-		return fmt.Errorf("failed to update giveaway: %w", err)
+		return false, fmt.Errorf("failed to add participant: %w", err)
 	}
 
-	return nil
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return affected > 0, nil
 }
 
-func (r *Repository) AddParticipant(ctx context.Context, participant *ParticipantModel) error {
-	_, err := r.db.NewInsert().
+// AddReminder records the reminder, it returns false if the reminder is already recorded.
+func (r *Repository) AddReminder(ctx context.Context, reminder *ReminderModel) (bool, error) {
+	res, err := r.db.NewInsert().
 		Ignore().
-		Model(participant).
-		Returning("*").
+		Model(reminder).
 		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to add reminder: %w", err)
+	}
 
+	affected, err := res.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to add participant: %w", err)
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// DeleteReminder removes the record of the reminder, so it can be sent again.
+func (r *Repository) DeleteReminder(ctx context.Context, reminder *ReminderModel) error {
+	if _, err := r.db.NewDelete().
+		Model((*ReminderModel)(nil)).
+		Where("giveaway_id = ?", reminder.GiveawayID).
+		Where("kind = ?", reminder.Kind).
+		Where("offset_minutes = ?", reminder.OffsetMinutes).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete reminder: %w", err)
 	}
 
 	return nil
 }
 
-func (r *Repository) Create(ctx context.Context, giveaway GiveawayPrepared) error {
-	model := newGiveawayModel(giveaway)
+// AddTickets grants extra tickets to the user if they participate in the giveaway.
+func (r *Repository) AddTickets(ctx context.Context, giveawayID, userID int64, tickets int) error {
+	_, err := r.db.NewUpdate().
+		Model((*ParticipantModel)(nil)).
+		Set("tickets = tickets + ?", tickets).
+		Where("giveaway_id = ?", giveawayID).
+		Where("user_id = ?", userID).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to add tickets: %w", err)
+	}
 
-	_, err := r.db.NewInsert().
+	return nil
+}
+
+// CountLossStreak returns the number of finished giveaways of the group the user took part in
+// without winning since their last win.
+func (r *Repository) CountLossStreak(ctx context.Context, groupID, userID int64) (int, error) {
+	lastWin := r.db.NewSelect().
+		Model((*GiveawayModel)(nil)).
+		ColumnExpr("MAX(results_date)").
+		Where("group_id = ?", groupID).
+		Where("winner_user_id = ?", userID).
+		Where("status = ?", StatusFinished)
+
+	count, err := r.db.NewSelect().
+		Model((*ParticipantModel)(nil)).
+		Join("JOIN giveaways AS ga ON ga.id = gap.giveaway_id").
+		Where("ga.group_id = ?", groupID).
+		Where("gap.user_id = ?", userID).
+		Where("ga.status = ?", StatusFinished).
+		Where("ga.winner_user_id <> ?", userID).
+		Where("ga.results_date > COALESCE((?), '1970-01-01')", lastWin).
+		Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count loss streak: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountReferrals returns the number of participants of the giveaway referred by the user.
+func (r *Repository) CountReferrals(ctx context.Context, giveawayID, referrerID int64) (int, error) {
+	count, err := r.db.NewSelect().
+		Model((*ParticipantModel)(nil)).
+		Join("JOIN users AS u ON u.id = gap.user_id").
+		Where("gap.giveaway_id = ?", giveawayID).
+		Where("u.referrer_id = ?", referrerID).
+		Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count referrals: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *Repository) GetParticipant(ctx context.Context, giveawayID, userID int64) (*ParticipantModel, error) {
+	participant := new(ParticipantModel)
+	if err := r.db.NewSelect().
+		Model(participant).
+		Relation("User").
+		Where("gap.giveaway_id = ?", giveawayID).
+		Where("gap.user_id = ?", userID).
+		Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrParticipantNotFound
+		}
+		return nil, fmt.Errorf("failed to get participant: %w", err)
+	}
+
+	return participant, nil
+}
+
+func (r *Repository) ListParticipants(ctx context.Context, giveawayID int64) ([]*ParticipantModel, error) {
+	participants := make([]*ParticipantModel, 0)
+	if err := r.db.NewSelect().
+		Model(&participants).
+		Relation("User").
+		Where("gap.giveaway_id = ?", giveawayID).
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to list participants: %w", err)
+	}
+
+	return participants, nil
+}
+
+func (r *Repository) CountParticipants(ctx context.Context, giveawayID int64) (int, error) {
+	count, err := r.db.NewSelect().
+		Model((*ParticipantModel)(nil)).
+		Where("giveaway_id = ?", giveawayID).
+		Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count participants: %w", err)
+	}
+
+	return count, nil
+}
+
+// SetQuizAnswer records the answer of the user, replacing the previous one.
+func (r *Repository) SetQuizAnswer(ctx context.Context, answer *QuizAnswerModel) error {
+	if _, err := r.db.NewInsert().
+		Model(answer).
+		On("DUPLICATE KEY UPDATE").
+		Set("answer = VALUES(answer)").
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to set quiz answer: %w", err)
+	}
+
+	return nil
+}
+
+// GetChallenge returns the challenge of the user for the giveaway unless it's expired.
+func (r *Repository) GetChallenge(ctx context.Context, giveawayID, userID int64, now time.Time) (*Challenge, error) {
+	model := new(challengeModel)
+	err := r.db.NewSelect().
 		Model(model).
+		Where("giveaway_id = ?", giveawayID).
+		Where("user_id = ?", userID).
+		Where("expires_at > ?", now).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrChallengeNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get challenge: %w", err)
+	}
+
+	return model.toChallenge(), nil
+}
+
+// SetChallenge creates or replaces the challenge of the user for the giveaway.
+func (r *Repository) SetChallenge(ctx context.Context, challenge Challenge, expiresAt time.Time) error {
+	if _, err := r.db.NewInsert().
+		Model(newChallengeModel(challenge, expiresAt)).
+		On("DUPLICATE KEY UPDATE").
+		Set("answer = VALUES(answer)").
+		Set("attempts = VALUES(attempts)").
+		Set("locked_until = VALUES(locked_until)").
+		Set("expires_at = VALUES(expires_at)").
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to set challenge: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteChallenge removes the challenge of the user for the giveaway.
+func (r *Repository) DeleteChallenge(ctx context.Context, giveawayID, userID int64) error {
+	if _, err := r.db.NewDelete().
+		Model((*challengeModel)(nil)).
+		Where("giveaway_id = ?", giveawayID).
+		Where("user_id = ?", userID).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete challenge: %w", err)
+	}
+
+	return nil
+}
+
+// PruneChallenges removes the challenges expired before the given time and returns their number.
+func (r *Repository) PruneChallenges(ctx context.Context, before time.Time) (int64, error) {
+	res, err := r.db.NewDelete().
+		Model((*challengeModel)(nil)).
+		Where("expires_at <= ?", before).
 		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune challenges: %w", err)
+	}
 
+	pruned, err := res.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to create giveaway: %w", err)
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
 	}
 
-	return nil
+	return pruned, nil
+}
+
+// ListQuizAnswers returns the answers to the quiz of the giveaway in the order they were given.
+func (r *Repository) ListQuizAnswers(ctx context.Context, giveawayID int64) ([]QuizAnswerModel, error) {
+	answers := make([]QuizAnswerModel, 0)
+	if err := r.db.NewSelect().
+		Model(&answers).
+		Where("giveaway_id = ?", giveawayID).
+		Order("id").
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to list quiz answers: %w", err)
+	}
+
+	return answers, nil
+}
+
+// Create inserts the giveaway in the status and records it as the first entry of the history.
+func (r *Repository) Create(
+	ctx context.Context,
+	giveaway GiveawayPrepared,
+	status Status,
+	reason string,
+) (int64, error) {
+	model := newGiveawayModel(giveaway, status)
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(model).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to create giveaway: %w", err)
+		}
+
+		change := newStatusChangeModel(model.ID, "", status, giveaway.AdminUserID, reason)
+		if _, err := tx.NewInsert().Model(change).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to record status change: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return model.ID, nil
 }
@@ -2,24 +2,32 @@ package giveaways
 
 import (
 	"context"
-	"crypto/rand"
 	"errors"
 	"fmt"
-	"math/big"
 	"time"
 
-	"github.com/capcom6/lucky-pick-tg-bot/internal/actions"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/events"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/fraud"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/groups"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/users"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
 )
 
+// cancelReason is the reason recorded for giveaways cancelled by an organizer.
+const cancelReason = "cancelled by organizer"
+
 type Service struct {
 	giveaways *Repository
 
-	llmSvc     *LLM
-	groupsSvc  *groups.Service
-	actionsSvc *actions.Service
+	llmSvc    *LLM
+	groupsSvc *groups.Service
+	usersSvc  *users.Service
+	fraudSvc  *fraud.Service
+
+	strategies []DrawStrategy
+
+	bus *events.Bus
 
 	logger *zap.Logger
 }
@@ -28,15 +36,23 @@ func NewService(
 	giveaways *Repository,
 	llmSvc *LLM,
 	groupsSvc *groups.Service,
-	actionsSvc *actions.Service,
+	usersSvc *users.Service,
+	fraudSvc *fraud.Service,
+	strategies []DrawStrategy,
+	bus *events.Bus,
 	logger *zap.Logger,
 ) *Service {
 	return &Service{
 		giveaways: giveaways,
 
-		llmSvc:     llmSvc,
-		groupsSvc:  groupsSvc,
-		actionsSvc: actionsSvc,
+		llmSvc:    llmSvc,
+		groupsSvc: groupsSvc,
+		usersSvc:  usersSvc,
+		fraudSvc:  fraudSvc,
+
+		strategies: strategies,
+
+		bus: bus,
 
 		logger: logger,
 	}
@@ -57,7 +73,156 @@ func (s *Service) GenerateDescription(
 }
 
 func (s *Service) Create(ctx context.Context, giveaway GiveawayPrepared) error {
-	return s.giveaways.Create(ctx, giveaway)
+	if err := s.validate(giveaway.GiveawayDraft); err != nil {
+		return err
+	}
+
+	id, err := s.giveaways.Create(ctx, giveaway, StatusScheduled, "scheduled")
+	if err != nil {
+		return err
+	}
+
+	s.bus.Publish(ctx, GiveawayCreated{ID: id, Giveaway: giveaway})
+
+	return nil
+}
+
+// Submit creates the giveaway pending approval. It is not published until approved.
+func (s *Service) Submit(ctx context.Context, giveaway GiveawayPrepared) error {
+	if err := s.validate(giveaway.GiveawayDraft); err != nil {
+		return err
+	}
+
+	id, err := s.giveaways.Create(ctx, giveaway, StatusPendingApproval, "submitted for approval")
+	if err != nil {
+		return err
+	}
+
+	s.bus.Publish(ctx, GiveawaySubmitted{ID: id, Giveaway: giveaway, Revised: false})
+
+	return nil
+}
+
+// Resubmit replaces the giveaway sent back for changes with the revised draft and submits it for approval again.
+// Only the author can revise the giveaway, the group can't be changed.
+func (s *Service) Resubmit(ctx context.Context, id int64, giveaway GiveawayPrepared) error {
+	if err := s.validate(giveaway.GiveawayDraft); err != nil {
+		return err
+	}
+
+	item, err := s.giveaways.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if item.AdminUserID != giveaway.AdminUserID || item.GroupID != giveaway.GroupID {
+		return ErrNotFound
+	}
+	if item.Status != StatusChangesRequested {
+		return ErrNotChangesRequested
+	}
+
+	update := newGiveawayModel(giveaway, StatusPendingApproval)
+	update.ID = id
+	if trErr := checkTransition(item.Status, update); trErr != nil {
+		return trErr
+	}
+
+	if revErr := s.giveaways.Revise(
+		ctx,
+		update,
+		item.Status,
+		newStatusChangeModel(id, item.Status, StatusPendingApproval, giveaway.AdminUserID, "resubmitted for approval"),
+	); revErr != nil {
+		return revErr
+	}
+
+	s.bus.Publish(ctx, GiveawaySubmitted{ID: id, Giveaway: giveaway, Revised: true})
+
+	return nil
+}
+
+// Review applies the decision to the giveaway pending approval.
+func (s *Service) Review(
+	ctx context.Context,
+	id, reviewerID int64,
+	decision Decision,
+	comment string,
+) (*Giveaway, error) {
+	item, err := s.giveaways.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.Status != StatusPendingApproval {
+		return nil, ErrNotPendingApproval
+	}
+
+	reason := string(decision)
+	if comment != "" {
+		reason += ": " + comment
+	}
+	if trErr := s.transition(ctx, item, decision.Status(), reviewerID, reason, nil); trErr != nil {
+		return nil, trErr
+	}
+
+	giveaway, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.bus.Publish(ctx, GiveawayReviewed{
+		Giveaway:   *giveaway,
+		ReviewerID: reviewerID,
+		Decision:   decision,
+		Comment:    comment,
+	})
+
+	return giveaway, nil
+}
+
+func (s *Service) GetByID(ctx context.Context, id int64) (*Giveaway, error) {
+	item, err := s.giveaways.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := s.groupsSvc.GetByID(ctx, item.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+
+	return newGiveaway(*item, *group), nil
+}
+
+// IsParticipant returns true if the user has already joined the giveaway.
+func (s *Service) IsParticipant(ctx context.Context, giveawayID, userID int64) (bool, error) {
+	_, err := s.giveaways.GetParticipant(ctx, giveawayID, userID)
+	if errors.Is(err, ErrParticipantNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// CountParticipants returns the number of participants of the giveaway.
+func (s *Service) CountParticipants(ctx context.Context, giveawayID int64) (int, error) {
+	return s.giveaways.CountParticipants(ctx, giveawayID)
+}
+
+// ListParticipants returns the participants of the giveaway.
+func (s *Service) ListParticipants(ctx context.Context, giveawayID int64) ([]Participant, error) {
+	items, err := s.giveaways.ListParticipants(ctx, giveawayID)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(items, func(item *ParticipantModel, _ int) Participant {
+		return *newParticipant(item)
+	}), nil
 }
 
 func (s *Service) ListByIDs(ctx context.Context, giveawayIDs []int64) ([]Giveaway, error) {
@@ -137,8 +302,61 @@ func (s *Service) ListWinners(ctx context.Context) ([]Winner, error) {
 			continue
 		}
 
-		logger.Debug("starting winner selection")
-		winner, winErr := s.randomWinner(ctx, &giveaway)
+		settings, setErr := NewSettings(g.Settings)
+		if setErr != nil {
+			logger.Error("failed to parse group settings", zap.Error(setErr))
+			continue
+		}
+
+		excluded, exclErr := s.excludedWinners(ctx, giveaway.GroupID, settings.Cooldown)
+		if exclErr != nil {
+			logger.Error("failed to get excluded winners", zap.Error(exclErr))
+			continue
+		}
+
+		fraudSettings, setErr := fraud.NewSettings(g.Settings)
+		if setErr != nil {
+			logger.Error("failed to parse fraud settings", zap.Error(setErr))
+			continue
+		}
+
+		flags, fraudErr := s.screen(ctx, &giveaway, giveaway.Participants, fraudSettings)
+		if fraudErr != nil {
+			logger.Error("failed to screen participants", zap.Error(fraudErr))
+			continue
+		}
+		suspicious := fraud.Excluded(flags, fraudSettings)
+
+		eligible := lo.Filter(giveaway.Participants, func(item *ParticipantModel, _ int) bool {
+			_, ok := excluded[item.UserID]
+			return !ok
+		})
+		excludedCount := len(giveaway.Participants) - len(eligible)
+		candidates := lo.Filter(eligible, func(item *ParticipantModel, _ int) bool {
+			_, ok := suspicious[item.UserID]
+			return !ok
+		})
+		suspiciousCount := len(eligible) - len(candidates)
+
+		logger.Debug("starting winner selection",
+			zap.Int("excluded_count", excludedCount),
+			zap.Int("suspicious_count", suspiciousCount),
+		)
+
+		// Quiz answers are checked only among the remaining candidates, so a quiz giveaway without them
+		// is reported as not having enough participants rather than correct answers
+		quizzed := len(candidates)
+		answers := map[int64]string{}
+		if quiz := giveaway.quiz(); quiz != nil {
+			var quizErr error
+			candidates, answers, quizErr = s.quizCandidates(ctx, giveaway.ID, quiz, candidates)
+			if quizErr != nil {
+				logger.Error("failed to check quiz answers", zap.Error(quizErr))
+				continue
+			}
+		}
+
+		winner, winErr := s.draw(ctx, &giveaway, candidates)
 		if winErr != nil && !errors.Is(winErr, ErrNotEnoughParticipants) {
 			logger.Error("failed to generate random winner",
 				zap.Int("participants_count", len(giveaway.Participants)),
@@ -146,136 +364,487 @@ func (s *Service) ListWinners(ctx context.Context) ([]Winner, error) {
 			)
 			continue
 		}
+		switch {
+		case winErr == nil:
+		case len(eligible) == 0 && excludedCount > 0:
+			winErr = ErrAllParticipantsExcluded
+		case len(eligible) == suspiciousCount && suspiciousCount > 0:
+			winErr = ErrAllParticipantsSuspicious
+		case giveaway.QuizMode != "" && quizzed > 0:
+			winErr = ErrNoCorrectAnswers
+		}
 
-		var newStatus *GiveawayModel
-		var actionType, actionDesc string
+		var apply func(*GiveawayModel)
+		newStatus, reason := StatusCancelled, ""
 		if winErr != nil {
-			newStatus = NewCancelGiveaway(giveaway.ID)
-			actionType = "giveaway.cancelled"
-			actionDesc = fmt.Sprintf("Cancel giveaway: %s", winErr.Error())
+			reason = winErr.Error()
 		} else {
 			logger.Debug(
 				"winner selected successfully",
 				zap.Int64("giveaway_id", giveaway.ID),
 				zap.Int64("winner_user_id", winner.UserID),
 			)
-			newStatus = NewFinishGiveaway(giveaway.ID, winner.UserID)
-			actionType = "giveaway.finished"
-			actionDesc = "Finish giveaway"
+			newStatus, reason = StatusFinished, "winner selected"
+			apply = func(m *GiveawayModel) { m.WinnerUserID = winner.UserID }
 		}
 
-		if updErr := s.giveaways.Update(
-			ctx,
-			newStatus,
-		); updErr != nil {
+		if trErr := s.transition(ctx, &giveaway, newStatus, 0, reason, apply); trErr != nil {
 			logger.Error("failed to update giveaway",
-				zap.Error(updErr),
+				zap.Error(trErr),
 			)
 			continue
 		}
 		logger.Debug(
 			"giveaway updated successfully",
 			zap.Int64("giveaway_id", giveaway.ID),
-			zap.String("status", string(newStatus.Status)),
+			zap.String("status", string(newStatus)),
 		)
 
-		s.actionsSvc.LogAction(
-			ctx,
-			actionType,
-			newStatus.WinnerUserID,
-			giveaway.ID,
-			actionDesc,
-		)
-
-		winners = append(winners, Winner{
+		result := Winner{
 			Giveaway:    *newGiveaway(giveaway, g),
-			Participant: newParticipant(winner),
-		})
+			Participant: winner,
+			Answer:      "",
+			Reason:      winErr,
+
+			ExcludedCount: excludedCount,
+		}
+
+		if winner != nil {
+			result.Answer = answers[winner.UserID]
+		}
+
+		if winErr != nil {
+			s.bus.Publish(ctx, GiveawayCancelled{Winner: result, Reason: winErr.Error(), From: StatusClosed, ActorID: 0})
+		} else {
+			s.bus.Publish(ctx, GiveawayFinished{Winner: result})
+		}
+
+		winners = append(winners, result)
 	}
 
 	return winners, nil
 }
 
+// ListOpen returns the giveaways of the groups that are not finished or cancelled yet,
+// in the order they are published.
+func (s *Service) ListOpen(ctx context.Context, groupIDs []int64) ([]Giveaway, error) {
+	items, err := s.giveaways.ListOpen(ctx, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	grps, err := s.selectGroups(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapGiveaways(items, grps)
+}
+
+// Cancel cancels the giveaway on behalf of the user. Finished and cancelled giveaways can't be cancelled,
+// checkTransition reports ErrInvalidTransition for them.
+func (s *Service) Cancel(ctx context.Context, id, actorUserID int64) error {
+	item, err := s.giveaways.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	group, err := s.groupsSvc.GetByID(ctx, item.GroupID)
+	if err != nil {
+		return fmt.Errorf("failed to get group: %w", err)
+	}
+
+	from := item.Status
+	if trErr := s.transition(ctx, item, StatusCancelled, actorUserID, cancelReason, nil); trErr != nil {
+		return trErr
+	}
+
+	s.bus.Publish(ctx, GiveawayCancelled{
+		Winner: Winner{
+			Giveaway:    *newGiveaway(*item, *group),
+			Participant: nil,
+			Answer:      "",
+			Reason:      ErrCancelled,
+
+			ExcludedCount: 0,
+		},
+		Reason:  cancelReason,
+		From:    from,
+		ActorID: actorUserID,
+	})
+
+	return nil
+}
+
 func (s *Service) Published(ctx context.Context, id, messageID int64) error {
-	if err := s.giveaways.Update(
-		ctx,
-		NewPublishGiveaway(
-			id,
-			messageID,
-		),
-	); err != nil {
+	item, err := s.giveaways.GetByID(ctx, id)
+	if err != nil {
 		return err
 	}
 
-	// Log the action
-	s.actionsSvc.LogAction(
+	if trErr := s.transition(
 		ctx,
-		"giveaway.published",
+		item,
+		StatusActive,
 		0,
-		id,
-		fmt.Sprintf("Publish giveaway with message ID %d", messageID),
-	)
+		fmt.Sprintf("published with message %d", messageID),
+		func(m *GiveawayModel) {
+			m.TelegramMessageID = messageID
+			m.PublishedAt = time.Now()
+		},
+	); trErr != nil {
+		return trErr
+	}
+
+	giveaway, err := s.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	s.bus.Publish(ctx, GiveawayPublished{Giveaway: *giveaway})
 
 	return nil
 }
 
 func (s *Service) Close(ctx context.Context, id int64) error {
-	if err := s.giveaways.Update(
-		ctx,
-		NewCloseGiveaway(
-			id,
-		),
-	); err != nil {
+	item, err := s.giveaways.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if trErr := s.transition(ctx, item, StatusClosed, 0, "applications ended", nil); trErr != nil {
+		return trErr
+	}
+
+	giveaway, err := s.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.giveaways.CountParticipants(ctx, id)
+	if err != nil {
 		return err
 	}
 
-	// Log the action
-	s.actionsSvc.LogAction(ctx, "giveaway.closed", 0, id, "Close giveaway")
+	s.bus.Publish(ctx, GiveawayClosed{Giveaway: *giveaway, ParticipantsCount: count})
 
 	return nil
 }
 
-func (s *Service) Participate(ctx context.Context, giveawayID int64, userID int64) error {
+// Participate enters the user into the giveaway. Quiz giveaways are entered by answering, see AnswerQuiz.
+func (s *Service) Participate(ctx context.Context, giveawayID int64, userID int64) (*Participation, error) {
 	giveaway, err := s.giveaways.GetByID(ctx, giveawayID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	now := time.Now()
+	if statusErr := canParticipate(giveaway, time.Now()); statusErr != nil {
+		return nil, statusErr
+	}
 
-	if giveaway.PublishDate.After(now) {
-		return ErrNotFound
+	if giveaway.QuizMode != "" {
+		return nil, ErrQuizRequired
+	}
+
+	return s.participate(ctx, giveaway, userID)
+}
+
+func (s *Service) participate(ctx context.Context, giveaway *GiveawayModel, userID int64) (*Participation, error) {
+	giveawayID := giveaway.ID
+
+	tickets, err := s.countTickets(ctx, giveaway, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.giveaways.AddParticipant(ctx, NewParticipantModel(giveawayID, userID, tickets))
+	if err != nil {
+		return nil, err
+	}
+
+	if created && giveaway.ReferralBonus > 0 {
+		s.rewardReferrer(ctx, giveaway, userID)
+	}
+
+	participant, err := s.giveaways.GetParticipant(ctx, giveawayID, userID)
+	if err != nil {
+		return nil, err
 	}
 
+	count, err := s.giveaways.CountParticipants(ctx, giveawayID)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := s.groupsSvc.GetByID(ctx, giveaway.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+
+	participation := &Participation{
+		Giveaway:          *newGiveaway(*giveaway, *group),
+		Participant:       *newParticipant(participant),
+		ParticipantsCount: count,
+	}
+
+	if created {
+		s.bus.Publish(ctx, ParticipantJoined{Participation: *participation})
+	}
+
+	return participation, nil
+}
+
+// validate checks the rules of the giveaway before it is stored.
+func (s *Service) validate(giveaway GiveawayDraft) error {
+	if giveaway.Quiz != nil {
+		if err := giveaway.Quiz.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return s.validateDraw(giveaway.Draw)
+}
+
+// canParticipate returns why the giveaway doesn't accept applications now, nil if it does.
+func canParticipate(giveaway *GiveawayModel, now time.Time) error {
+	switch giveaway.Status {
+	case StatusPendingApproval, StatusChangesRequested, StatusScheduled:
+		return ErrNotStarted
+	case StatusClosed, StatusFinished:
+		return ErrClosed
+	case StatusCancelled:
+		return ErrCancelled
+	case StatusActive:
+	}
+
+	if giveaway.PublishDate.After(now) {
+		return ErrNotStarted
+	}
 	if giveaway.ApplicationEndDate.Before(now) {
-		return ErrNotFound
+		return ErrClosed
 	}
 
-	if addErr := s.giveaways.AddParticipant(ctx, NewParticipantModel(giveawayID, userID)); addErr != nil {
-		return addErr
+	return nil
+}
+
+// transition moves the giveaway to the status and records the change. apply sets the fields changed along with
+// the status. The update is applied only if the status wasn't changed since the giveaway was loaded.
+func (s *Service) transition(
+	ctx context.Context,
+	giveaway *GiveawayModel,
+	to Status,
+	actorUserID int64,
+	reason string,
+	apply func(*GiveawayModel),
+) error {
+	//nolint:exhaustruct // partial update
+	update := &GiveawayModel{ID: giveaway.ID, Status: to}
+	if apply != nil {
+		apply(update)
 	}
 
-	// Log the action
-	s.actionsSvc.LogAction(ctx, "giveaway.participated", userID, giveawayID, "Participate in giveaway")
+	if err := checkTransition(giveaway.Status, update); err != nil {
+		return err
+	}
+
+	if err := s.giveaways.Transition(
+		ctx,
+		update,
+		giveaway.Status,
+		newStatusChangeModel(giveaway.ID, giveaway.Status, to, actorUserID, reason),
+	); err != nil {
+		return err
+	}
+
+	from := giveaway.Status
+	giveaway.Status = to
+	if apply != nil {
+		apply(giveaway)
+	}
+
+	s.logger.Debug("giveaway status changed",
+		zap.Int64("giveaway_id", giveaway.ID),
+		zap.String("from", string(from)),
+		zap.String("to", string(to)),
+	)
 
 	return nil
 }
 
-func (s *Service) randomWinner(_ context.Context, giveaway *GiveawayModel) (*ParticipantModel, error) {
-	if len(giveaway.Participants) == 0 {
-		return nil, ErrNotEnoughParticipants
+// ListPendingFlags returns flags awaiting review for giveaways of the groups that are not drawn yet.
+func (s *Service) ListPendingFlags(ctx context.Context, groupIDs []int64) ([]fraud.Flag, error) {
+	flags, err := s.fraudSvc.ListPending(ctx, groupIDs, []string{string(StatusActive), string(StatusClosed)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending flags: %w", err)
+	}
+
+	return flags, nil
+}
+
+// ScreenParticipants runs the fraud scoring for the giveaway and returns flags awaiting review.
+func (s *Service) ScreenParticipants(ctx context.Context, giveawayID int64) ([]fraud.Flag, error) {
+	giveaway, err := s.giveaways.GetByID(ctx, giveawayID)
+	if err != nil {
+		return nil, err
+	}
+
+	participants, err := s.giveaways.ListParticipants(ctx, giveawayID)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := s.groupsSvc.GetByID(ctx, giveaway.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+
+	settings, err := fraud.NewSettings(group.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fraud settings: %w", err)
+	}
+
+	flags, err := s.screen(ctx, giveaway, participants, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Filter(flags, func(item fraud.Flag, _ int) bool {
+		return item.Status == fraud.StatusPending
+	}), nil
+}
+
+func (s *Service) screen(
+	ctx context.Context,
+	giveaway *GiveawayModel,
+	participants []*ParticipantModel,
+	settings fraud.Settings,
+) ([]fraud.Flag, error) {
+	candidates := lo.Map(participants, func(item *ParticipantModel, _ int) fraud.Candidate {
+		user := item.User
+		if user == nil {
+			user = new(users.UserModel)
+		}
+
+		return fraud.Candidate{
+			UserID:    item.UserID,
+			Username:  user.Username,
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+			JoinedAt:  item.JoinedAt,
+		}
+	})
+
+	flags, err := s.fraudSvc.Evaluate(ctx, giveaway.ID, giveaway.GroupID, giveaway.PublishedAt, candidates, settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate participants: %w", err)
+	}
+
+	return flags, nil
+}
+
+// countTickets calculates the number of tickets the user gets when joining the giveaway.
+func (s *Service) countTickets(ctx context.Context, giveaway *GiveawayModel, userID int64) (int, error) {
+	tickets := 1
+
+	if giveaway.MembershipDays > 0 && giveaway.MembershipBonus > 0 {
+		memberSince, err := s.groupsSvc.MemberSince(ctx, giveaway.GroupID, userID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get membership: %w", err)
+		}
+
+		membership := time.Duration(giveaway.MembershipDays) * 24 * time.Hour
+		if !memberSince.IsZero() && time.Since(memberSince) >= membership {
+			tickets += giveaway.MembershipBonus
+		}
 	}
 
-	if len(giveaway.Participants) == 1 {
-		return giveaway.Participants[0], nil
+	if giveaway.LoyaltyBonus > 0 {
+		streak, err := s.giveaways.CountLossStreak(ctx, giveaway.GroupID, userID)
+		if err != nil {
+			return 0, err
+		}
+
+		tickets += streak * giveaway.LoyaltyBonus
 	}
 
-	idx, err := rand.Int(rand.Reader, big.NewInt(int64(len(giveaway.Participants))))
+	if giveaway.ReferralBonus > 0 {
+		referrals, err := s.giveaways.CountReferrals(ctx, giveaway.ID, userID)
+		if err != nil {
+			return 0, err
+		}
+
+		tickets += referrals * giveaway.ReferralBonus
+	}
+
+	return tickets, nil
+}
+
+// rewardReferrer grants the referral bonus to the referrer of the user
+// if the referrer takes part in the same giveaway.
+func (s *Service) rewardReferrer(ctx context.Context, giveaway *GiveawayModel, userID int64) {
+	logger := s.logger.With(zap.Int64("giveaway_id", giveaway.ID), zap.Int64("user_id", userID))
+
+	user, err := s.usersSvc.GetByID(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate random number: %w", err)
+		logger.Error("failed to get user", zap.Error(err))
+		return
+	}
+
+	if user.ReferrerID == 0 {
+		return
+	}
+
+	if addErr := s.giveaways.AddTickets(ctx, giveaway.ID, user.ReferrerID, giveaway.ReferralBonus); addErr != nil {
+		logger.Error("failed to reward referrer", zap.Int64("referrer_id", user.ReferrerID), zap.Error(addErr))
+	}
+}
+
+// excludedWinners returns IDs of users who can't win in the group due to the cool-down rules.
+func (s *Service) excludedWinners(ctx context.Context, groupID int64, rules CooldownRules) (map[int64]struct{}, error) {
+	excluded := make(map[int64]struct{})
+	if rules.IsEmpty() {
+		return excluded, nil
+	}
+
+	now := time.Now()
+
+	if rules.Days > 0 {
+		recent, err := s.giveaways.ListWinnersSince(ctx, groupID, now.AddDate(0, 0, -rules.Days))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range recent {
+			excluded[item.WinnerUserID] = struct{}{}
+		}
+	}
+
+	if rules.Giveaways > 0 {
+		last, err := s.giveaways.ListLastWinners(ctx, groupID, rules.Giveaways)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range last {
+			excluded[item.WinnerUserID] = struct{}{}
+		}
+	}
+
+	if rules.MonthlyCap > 0 {
+		monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		thisMonth, err := s.giveaways.ListWinnersSince(ctx, groupID, monthStart)
+		if err != nil {
+			return nil, err
+		}
+
+		for userID, wins := range lo.CountValuesBy(thisMonth, func(item GiveawayModel) int64 { return item.WinnerUserID }) {
+			if wins >= rules.MonthlyCap {
+				excluded[userID] = struct{}{}
+			}
+		}
 	}
 
-	return giveaway.Participants[idx.Int64()], nil
+	return excluded, nil
 }
 
 func (s *Service) selectGroups(ctx context.Context, items []GiveawayModel) (map[int64]groups.GroupWithSettings, error) {
@@ -3,18 +3,42 @@ package giveaways
 import (
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/capcom6/lucky-pick-tg-bot/internal/settings"
 )
 
 type Settings struct {
-	LLMDescription bool
+	LLMDescription  bool
+	TicketRules     TicketRules
+	Cooldown        CooldownRules
+	Captcha         bool
+	CaptchaAttempts int
+	CaptchaLockout  time.Duration
+	Reminders       ReminderRules
+	LiveDraw        LiveDrawRules
 }
 
-func NewSettings(settings map[string]string) (Settings, error) {
+// LiveDrawRules configures the show played in the group before the winner is announced.
+type LiveDrawRules struct {
+	Enabled bool
+	// Dice rolls a Telegram dice before the reveal, just for fun.
+	Dice bool
+}
+
+// ReminderRules configures the messages sent to the group before the deadlines of a giveaway.
+type ReminderRules struct {
+	// ApplicationEnd are the offsets before the end of applications to remind at.
+	ApplicationEnd []time.Duration
+	// Results is the offset before the results to announce them at, zero to disable.
+	Results time.Duration
+}
+
+func NewSettings(dict map[string]string) (Settings, error) {
 	s := DefaultSettings()
 
-	if d := settings["giveaways.llm_description"]; d != "" {
+	if d := dict["giveaways.llm_description"]; d != "" {
 		description, err := strconv.ParseBool(d)
 		if err != nil {
 			return s, fmt.Errorf("failed to parse giveaways.llm_description setting: %w", err)
@@ -22,16 +46,126 @@ func NewSettings(settings map[string]string) (Settings, error) {
 		s.LLMDescription = description
 	}
 
+	if c := dict["giveaways.captcha"]; c != "" {
+		captcha, err := strconv.ParseBool(c)
+		if err != nil {
+			return s, fmt.Errorf("failed to parse giveaways.captcha setting: %w", err)
+		}
+		s.Captcha = captcha
+	}
+
+	for key, target := range map[string]*bool{
+		"giveaways.live_draw.enabled": &s.LiveDraw.Enabled,
+		"giveaways.live_draw.dice":    &s.LiveDraw.Dice,
+	} {
+		if v := dict[key]; v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return s, fmt.Errorf("failed to parse %s setting: %w", key, err)
+			}
+			*target = b
+		}
+	}
+
+	if d := dict["giveaways.captcha_lockout"]; d != "" {
+		lockout, err := settings.ParseDuration(d)
+		if err != nil {
+			return s, fmt.Errorf("failed to parse giveaways.captcha_lockout setting: %w", err)
+		}
+		s.CaptchaLockout = lockout.Duration
+	}
+
+	if r := dict["giveaways.reminders.application_end"]; strings.TrimSpace(r) != "" {
+		offsets, err := parseMinutesList(r)
+		if err != nil {
+			return s, fmt.Errorf("failed to parse giveaways.reminders.application_end setting: %w", err)
+		}
+		s.Reminders.ApplicationEnd = offsets
+	}
+
+	if r := dict["giveaways.reminders.results"]; r != "" {
+		minutes, err := strconv.ParseFloat(r, 64)
+		if err != nil {
+			return s, fmt.Errorf("failed to parse giveaways.reminders.results setting: %w", err)
+		}
+		s.Reminders.Results = time.Duration(minutes) * time.Minute
+	}
+
+	for key, target := range map[string]*int{
+		"giveaways.captcha_attempts":         &s.CaptchaAttempts,
+		"giveaways.tickets.referral_bonus":   &s.TicketRules.ReferralBonus,
+		"giveaways.tickets.membership_days":  &s.TicketRules.MembershipDays,
+		"giveaways.tickets.membership_bonus": &s.TicketRules.MembershipBonus,
+		"giveaways.tickets.loyalty_bonus":    &s.TicketRules.LoyaltyBonus,
+		"giveaways.cooldown.days":            &s.Cooldown.Days,
+		"giveaways.cooldown.giveaways":       &s.Cooldown.Giveaways,
+		"giveaways.cooldown.monthly_cap":     &s.Cooldown.MonthlyCap,
+	} {
+		if v := dict[key]; v != "" {
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return s, fmt.Errorf("failed to parse %s setting: %w", key, err)
+			}
+			*target = int(n)
+		}
+	}
+
 	return s, nil
 }
 
 func DefaultSettings() Settings {
+	//nolint:mnd //default values
 	return Settings{
 		LLMDescription: false,
+		TicketRules: TicketRules{
+			ReferralBonus:   0,
+			MembershipDays:  0,
+			MembershipBonus: 0,
+			LoyaltyBonus:    0,
+		},
+		Cooldown: CooldownRules{
+			Days:       0,
+			Giveaways:  0,
+			MonthlyCap: 0,
+		},
+		Captcha:         false,
+		CaptchaAttempts: 3,
+		CaptchaLockout:  10 * time.Minute,
+		Reminders: ReminderRules{
+			ApplicationEnd: nil,
+			Results:        0,
+		},
+		LiveDraw: LiveDrawRules{
+			Enabled: false,
+			Dice:    false,
+		},
 	}
 }
 
+// parseMinutesList parses a comma-separated list of minutes, e.g. "60, 10".
+func parseMinutesList(value string) ([]time.Duration, error) {
+	parts := strings.Split(value, ",")
+	offsets := make([]time.Duration, 0, len(parts))
+	for _, part := range parts {
+		minutes, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid minutes %q: %w", part, err)
+		}
+		if minutes > 0 {
+			offsets = append(offsets, time.Duration(minutes)*time.Minute)
+		}
+	}
+
+	return offsets, nil
+}
+
 func SettingDefinitions() []settings.SettingDefinition {
+	limitValidation := &settings.SettingValidation{
+		MinValue: settings.Ptr(float64(0)),
+		MaxValue: settings.Ptr(float64(100)), //nolint:mnd // reasonable limit
+		Required: false,
+	}
+
 	//nolint:exhaustruct //default values
 	return []settings.SettingDefinition{
 		{
@@ -42,5 +176,152 @@ func SettingDefinitions() []settings.SettingDefinition {
 			Type:         settings.Boolean,
 			DefaultValue: "false",
 		},
+		{
+			Key:          "giveaways.captcha",
+			Category:     "🎯 Giveaways",
+			Label:        "Participation Challenge",
+			Description:  "Ask users to solve a simple challenge in private chat before their entry is recorded",
+			Type:         settings.Boolean,
+			DefaultValue: "false",
+		},
+		{
+			Key:          "giveaways.captcha_attempts",
+			Category:     "🎯 Giveaways",
+			Label:        "Challenge Attempts",
+			Description:  "Number of wrong answers before the user is locked out",
+			Type:         settings.Number,
+			DefaultValue: "3",
+			Validation: &settings.SettingValidation{
+				MinValue: settings.Ptr(float64(1)),
+				MaxValue: settings.Ptr(float64(10)), //nolint:mnd // reasonable limit
+				Required: false,
+			},
+		},
+		{
+			Key:          "giveaways.captcha_lockout",
+			Category:     "🎯 Giveaways",
+			Label:        "Challenge Lockout",
+			Description:  "Time before a locked out user can try the challenge again",
+			Type:         settings.Duration,
+			DefaultValue: "00:10:00",
+			Validation: &settings.SettingValidation{
+				MinValue: settings.Ptr(float64(time.Minute.Seconds())),    // Minimum 1 minute
+				MaxValue: settings.Ptr(float64(24 * time.Hour.Seconds())), // Maximum 24 hours
+				Required: false,
+			},
+		},
+		{
+			Key:          "giveaways.reminders.application_end",
+			Category:     "⏰ Reminders",
+			Label:        "Application End Reminders",
+			Description:  "Minutes before the end of applications to remind the group, comma-separated, e.g. 60, 10",
+			Type:         settings.Text,
+			DefaultValue: "",
+			Validation: &settings.SettingValidation{
+				MaxLength: settings.Ptr(50), //nolint:mnd // reasonable limit
+				Pattern:   settings.Ptr(`^\d+(\s*,\s*\d+)*$`),
+				Required:  false,
+			},
+		},
+		{
+			Key:          "giveaways.reminders.results",
+			Category:     "⏰ Reminders",
+			Label:        "Results Announcement",
+			Description:  "Minutes before the results to announce them in the group (0 to disable)",
+			Type:         settings.Number,
+			DefaultValue: "0",
+			Validation: &settings.SettingValidation{
+				MinValue: settings.Ptr(float64(0)),
+				MaxValue: settings.Ptr(float64(1440)), //nolint:mnd // one day
+				Required: false,
+			},
+		},
+		{
+			Key:          "giveaways.tickets.referral_bonus",
+			Category:     "🎟 Tickets",
+			Label:        "Referral Bonus",
+			Description:  "Bonus tickets for each invited friend who joins the same giveaway",
+			Type:         settings.Number,
+			DefaultValue: "0",
+			Validation:   limitValidation,
+		},
+		{
+			Key:          "giveaways.tickets.membership_days",
+			Category:     "🎟 Tickets",
+			Label:        "Membership Days",
+			Description:  "Days since joining the group required for the membership bonus",
+			Type:         settings.Number,
+			DefaultValue: "0",
+			Validation: &settings.SettingValidation{
+				MinValue: settings.Ptr(float64(0)),
+				MaxValue: settings.Ptr(float64(3650)), //nolint:mnd // ten years
+				Required: false,
+			},
+		},
+		{
+			Key:          "giveaways.tickets.membership_bonus",
+			Category:     "🎟 Tickets",
+			Label:        "Membership Bonus",
+			Description:  "Bonus tickets for long-standing members of the group",
+			Type:         settings.Number,
+			DefaultValue: "0",
+			Validation:   limitValidation,
+		},
+		{
+			Key:          "giveaways.tickets.loyalty_bonus",
+			Category:     "🎟 Tickets",
+			Label:        "Loyalty Bonus",
+			Description:  "Bonus tickets for each giveaway of the group joined without winning since the last win",
+			Type:         settings.Number,
+			DefaultValue: "0",
+			Validation:   limitValidation,
+		},
+		{
+			Key:          "giveaways.cooldown.days",
+			Category:     "🏆 Winners",
+			Label:        "Cool-down Days",
+			Description:  "Exclude users who won a giveaway of the group within this many days (0 to disable)",
+			Type:         settings.Number,
+			DefaultValue: "0",
+			Validation: &settings.SettingValidation{
+				MinValue: settings.Ptr(float64(0)),
+				MaxValue: settings.Ptr(float64(365)), //nolint:mnd // one year
+				Required: false,
+			},
+		},
+		{
+			Key:          "giveaways.cooldown.giveaways",
+			Category:     "🏆 Winners",
+			Label:        "Cool-down Giveaways",
+			Description:  "Exclude winners of this many last giveaways of the group (0 to disable)",
+			Type:         settings.Number,
+			DefaultValue: "0",
+			Validation:   limitValidation,
+		},
+		{
+			Key:          "giveaways.cooldown.monthly_cap",
+			Category:     "🏆 Winners",
+			Label:        "Monthly Win Cap",
+			Description:  "Maximum number of wins per user in the group per calendar month (0 for unlimited)",
+			Type:         settings.Number,
+			DefaultValue: "0",
+			Validation:   limitValidation,
+		},
+		{
+			Key:          "giveaways.live_draw.enabled",
+			Category:     "🏆 Winners",
+			Label:        "Live Draw",
+			Description:  "Play a countdown and shuffle through the participants in the group before announcing the winner",
+			Type:         settings.Boolean,
+			DefaultValue: "false",
+		},
+		{
+			Key:          "giveaways.live_draw.dice",
+			Category:     "🏆 Winners",
+			Label:        "Live Draw Dice",
+			Description:  "Roll a dice during the live draw, just for fun",
+			Type:         settings.Boolean,
+			DefaultValue: "false",
+		},
 	}
 }
@@ -0,0 +1,55 @@
+package giveaways
+
+import (
+	"fmt"
+
+	"github.com/samber/lo"
+)
+
+// transitions lists the statuses a giveaway can move to from each status.
+// Finished and cancelled giveaways are final.
+//
+//nolint:gochecknoglobals // static table
+var transitions = map[Status][]Status{
+	StatusPendingApproval:  {StatusScheduled, StatusChangesRequested, StatusCancelled},
+	StatusChangesRequested: {StatusPendingApproval, StatusCancelled},
+	StatusScheduled:        {StatusActive, StatusCancelled},
+	StatusActive:           {StatusClosed, StatusCancelled},
+	StatusClosed:           {StatusFinished, StatusCancelled},
+}
+
+// guards check the fields set along with the status.
+//
+//nolint:gochecknoglobals // static table
+var guards = map[Status]func(update *GiveawayModel) error{
+	StatusActive: func(update *GiveawayModel) error {
+		if update.TelegramMessageID == 0 {
+			return fmt.Errorf("%w: published giveaway has no message", ErrInvalidTransition)
+		}
+		return nil
+	},
+	StatusFinished: func(update *GiveawayModel) error {
+		if update.WinnerUserID == 0 {
+			return fmt.Errorf("%w: finished giveaway has no winner", ErrInvalidTransition)
+		}
+		return nil
+	},
+}
+
+// CanTransitionTo reports whether a giveaway in the status can move to the other one.
+func (s Status) CanTransitionTo(to Status) bool {
+	return lo.Contains(transitions[s], to)
+}
+
+// checkTransition returns ErrInvalidTransition if the update can't be applied to the giveaway.
+func checkTransition(from Status, update *GiveawayModel) error {
+	if !from.CanTransitionTo(update.Status) {
+		return fmt.Errorf("%w: from %s to %s", ErrInvalidTransition, from, update.Status)
+	}
+
+	if guard, ok := guards[update.Status]; ok {
+		return guard(update)
+	}
+
+	return nil
+}
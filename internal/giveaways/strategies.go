@@ -0,0 +1,150 @@
+package giveaways
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// UniformStrategy gives every candidate the same chance regardless of the tickets.
+type UniformStrategy struct{}
+
+func NewUniformStrategy() DrawStrategy {
+	return UniformStrategy{}
+}
+
+func (UniformStrategy) Name() string {
+	return "uniform"
+}
+
+func (UniformStrategy) Label() string {
+	return "🎲 Uniform random"
+}
+
+func (UniformStrategy) Describe(int) string {
+	return "случайный выбор, у всех участников равные шансы"
+}
+
+func (UniformStrategy) Param() *DrawParam {
+	return nil
+}
+
+func (UniformStrategy) Draw(_ context.Context, draw Draw) (*Participant, error) {
+	return PickRandom(draw.Candidates)
+}
+
+// WeightedStrategy gives every candidate a chance proportional to their tickets.
+type WeightedStrategy struct{}
+
+func NewWeightedStrategy() DrawStrategy {
+	return WeightedStrategy{}
+}
+
+func (WeightedStrategy) Name() string {
+	return DefaultDrawStrategy
+}
+
+func (WeightedStrategy) Label() string {
+	return "🎟 Weighted by tickets"
+}
+
+func (WeightedStrategy) Describe(int) string {
+	return "случайный выбор, шансы растут с числом билетов"
+}
+
+func (WeightedStrategy) Param() *DrawParam {
+	return nil
+}
+
+func (WeightedStrategy) Draw(_ context.Context, draw Draw) (*Participant, error) {
+	candidates := draw.Candidates
+	if len(candidates) == 0 {
+		return nil, ErrNotEnoughParticipants
+	}
+
+	total := int64(0)
+	for _, p := range candidates {
+		total += int64(max(p.Tickets, 1))
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(total))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate random number: %w", err)
+	}
+
+	pick := n.Int64()
+	for i := range candidates {
+		pick -= int64(max(candidates[i].Tickets, 1))
+		if pick < 0 {
+			return &candidates[i], nil
+		}
+	}
+
+	return &candidates[len(candidates)-1], nil
+}
+
+// FirstStrategy picks a random winner among the first N participants allowed to win.
+type FirstStrategy struct{}
+
+func NewFirstStrategy() DrawStrategy {
+	return FirstStrategy{}
+}
+
+func (FirstStrategy) Name() string {
+	return "first"
+}
+
+func (FirstStrategy) Label() string {
+	return "🥇 First participants"
+}
+
+func (FirstStrategy) Describe(param int) string {
+	return fmt.Sprintf("случайный выбор среди первых %d участников", param)
+}
+
+func (FirstStrategy) Param() *DrawParam {
+	//nolint:mnd // reasonable limits
+	return &DrawParam{Label: "Number of first participants", Min: 1, Max: 100000}
+}
+
+func (FirstStrategy) Draw(_ context.Context, draw Draw) (*Participant, error) {
+	return PickRandom(draw.Eligible(draw.Participants[:min(draw.Param, len(draw.Participants))]))
+}
+
+// NthStrategy picks a random winner among every Nth participant allowed to win.
+type NthStrategy struct{}
+
+func NewNthStrategy() DrawStrategy {
+	return NthStrategy{}
+}
+
+func (NthStrategy) Name() string {
+	return "nth"
+}
+
+func (NthStrategy) Label() string {
+	return "🔢 Every Nth participant"
+}
+
+func (NthStrategy) Describe(param int) string {
+	return fmt.Sprintf("случайный выбор среди каждого %d-го участника", param)
+}
+
+func (NthStrategy) Param() *DrawParam {
+	//nolint:mnd // reasonable limits
+	return &DrawParam{Label: "N, the step between eligible participants", Min: 2, Max: 1000}
+}
+
+func (NthStrategy) Draw(_ context.Context, draw Draw) (*Participant, error) {
+	if draw.Param < 1 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidDrawParam, draw.Param)
+	}
+
+	picked := make([]Participant, 0, len(draw.Participants)/draw.Param)
+	for i := draw.Param - 1; i < len(draw.Participants); i += draw.Param {
+		picked = append(picked, draw.Participants[i])
+	}
+
+	return PickRandom(draw.Eligible(picked))
+}
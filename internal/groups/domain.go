@@ -15,7 +15,8 @@ type GroupDraft struct {
 type Group struct {
 	GroupDraft
 
-	ID int64
+	ID       int64
+	IsActive bool
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
@@ -28,6 +29,7 @@ func newGroup(model *GroupModel) *Group {
 			Title:      model.Title,
 		},
 		ID:        model.ID,
+		IsActive:  model.IsActive,
 		CreatedAt: model.CreatedAt,
 		UpdatedAt: model.UpdatedAt,
 	}
@@ -49,6 +51,42 @@ func newGroupWithSettings(model *GroupModel) *GroupWithSettings {
 	}
 }
 
+// Role is the status of a group admin in Telegram.
+type Role string
+
+const (
+	RoleOwner Role = "owner"
+	RoleAdmin Role = "admin"
+)
+
+// Rights are the admin rights relevant to the bot.
+type Rights struct {
+	CanManageChat      bool
+	CanDeleteMessages  bool
+	CanRestrictMembers bool
+	CanPinMessages     bool
+	CanInviteUsers     bool
+	CanPromoteMembers  bool
+}
+
+// Admin is a group admin as reported by Telegram.
 type Admin struct {
 	UserID int64
+	Role   Role
+	Rights Rights
+}
+
+func newAdmin(model *adminModel) *Admin {
+	return &Admin{
+		UserID: model.UserID,
+		Role:   model.Role,
+		Rights: Rights{
+			CanManageChat:      model.CanManageChat,
+			CanDeleteMessages:  model.CanDeleteMessages,
+			CanRestrictMembers: model.CanRestrictMembers,
+			CanPinMessages:     model.CanPinMessages,
+			CanInviteUsers:     model.CanInviteUsers,
+			CanPromoteMembers:  model.CanPromoteMembers,
+		},
+	}
 }
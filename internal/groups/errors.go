@@ -3,5 +3,6 @@ package groups
 import "errors"
 
 var (
-	ErrNotFound = errors.New("group not found")
+	ErrNotFound      = errors.New("group not found")
+	ErrAdminNotFound = errors.New("group admin not found")
 )
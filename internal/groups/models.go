@@ -37,13 +37,33 @@ type adminModel struct {
 	ID      int64 `bun:"id,pk,autoincrement"`
 	GroupID int64 `bun:"group_id,notnull"`
 	UserID  int64 `bun:"user_id,notnull"`
+	Role    Role  `bun:"role,notnull"`
+
+	CanManageChat      bool `bun:"can_manage_chat,notnull"`
+	CanDeleteMessages  bool `bun:"can_delete_messages,notnull"`
+	CanRestrictMembers bool `bun:"can_restrict_members,notnull"`
+	CanPinMessages     bool `bun:"can_pin_messages,notnull"`
+	CanInviteUsers     bool `bun:"can_invite_users,notnull"`
+	CanPromoteMembers  bool `bun:"can_promote_members,notnull"`
+
+	SyncedAt time.Time `bun:"synced_at,notnull"`
 }
 
-func newAdminModel(groupID, userID int64) *adminModel {
+func newAdminModel(groupID int64, admin Admin, syncedAt time.Time) *adminModel {
 	//nolint:exhaustruct // partial constructor
 	return &adminModel{
 		GroupID: groupID,
-		UserID:  userID,
+		UserID:  admin.UserID,
+		Role:    admin.Role,
+
+		CanManageChat:      admin.Rights.CanManageChat,
+		CanDeleteMessages:  admin.Rights.CanDeleteMessages,
+		CanRestrictMembers: admin.Rights.CanRestrictMembers,
+		CanPinMessages:     admin.Rights.CanPinMessages,
+		CanInviteUsers:     admin.Rights.CanInviteUsers,
+		CanPromoteMembers:  admin.Rights.CanPromoteMembers,
+
+		SyncedAt: syncedAt,
 	}
 }
 
@@ -64,3 +84,21 @@ func newSettingsModel(groupID int64, key, value string) *settingsModel {
 		Value:   value,
 	}
 }
+
+type memberModel struct {
+	bun.BaseModel `bun:"table:group_members,alias:gm"`
+
+	ID       int64     `bun:"id,pk,autoincrement"`
+	GroupID  int64     `bun:"group_id,notnull"`
+	UserID   int64     `bun:"user_id,notnull"`
+	JoinedAt time.Time `bun:"joined_at,notnull"`
+}
+
+func newMemberModel(groupID, userID int64, joinedAt time.Time) *memberModel {
+	//nolint:exhaustruct // partial constructor
+	return &memberModel{
+		GroupID:  groupID,
+		UserID:   userID,
+		JoinedAt: joinedAt,
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/samber/lo"
 	"github.com/uptrace/bun"
@@ -20,22 +21,24 @@ func NewRepository(db *bun.DB) *Repository {
 	return &Repository{db: db}
 }
 
-// CreateOrUpdate implements Repository.
-func (r *Repository) CreateOrUpdate(ctx context.Context, group *GroupDraft, admins []Admin) error {
-	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
-		model := newGroupModel(group)
+// CreateOrUpdate creates the group or updates the existing one by Telegram ID.
+func (r *Repository) CreateOrUpdate(ctx context.Context, group *GroupDraft) (*GroupWithSettings, error) {
+	if _, err := r.db.NewInsert().
+		Model(newGroupModel(group)).
+		On("DUPLICATE KEY UPDATE").
+		Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to create or update group: %w", err)
+	}
 
-		if _, err := tx.NewInsert().
-			Model(model).
-			On("DUPLICATE KEY UPDATE").
-			Returning("*").
-			Exec(ctx); err != nil {
-			return fmt.Errorf("failed to create or update group: %w", err)
-		}
+	return r.GetByTelegramID(ctx, group.TelegramID)
+}
 
+// ReplaceAdmins replaces the admins of the group with the given list.
+func (r *Repository) ReplaceAdmins(ctx context.Context, groupID int64, admins []Admin, syncedAt time.Time) error {
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
 		if _, err := tx.NewDelete().
 			Model((*adminModel)(nil)).
-			Where("group_id = ?", model.ID).
+			Where("group_id = ?", groupID).
 			Exec(ctx); err != nil {
 			return fmt.Errorf("failed to delete group admins: %w", err)
 		}
@@ -47,26 +50,110 @@ func (r *Repository) CreateOrUpdate(ctx context.Context, group *GroupDraft, admi
 		adminModels := lo.Map(
 			admins,
 			func(admin Admin, _ int) *adminModel {
-				return newAdminModel(model.ID, admin.UserID)
+				return newAdminModel(groupID, admin, syncedAt)
 			},
 		)
 
 		if _, err := tx.NewInsert().
 			Model(&adminModels).
 			Exec(ctx); err != nil {
-			return fmt.Errorf("failed to create or update group admins: %w", err)
+			return fmt.Errorf("failed to insert group admins: %w", err)
 		}
 
 		return nil
 	})
 
 	if err != nil {
-		return fmt.Errorf("failed to create or update group: %w", err)
+		return fmt.Errorf("failed to replace group admins: %w", err)
+	}
+
+	return nil
+}
+
+// UpsertAdmin creates or updates a single admin of the group.
+func (r *Repository) UpsertAdmin(ctx context.Context, groupID int64, admin Admin, syncedAt time.Time) error {
+	if _, err := r.db.NewInsert().
+		Model(newAdminModel(groupID, admin, syncedAt)).
+		On("DUPLICATE KEY UPDATE").
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to upsert group admin: %w", err)
 	}
 
 	return nil
 }
 
+// DeleteAdmin removes the user from the admins of the group.
+func (r *Repository) DeleteAdmin(ctx context.Context, groupID, userID int64) error {
+	if _, err := r.db.NewDelete().
+		Model((*adminModel)(nil)).
+		Where("group_id = ?", groupID).
+		Where("user_id = ?", userID).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete group admin: %w", err)
+	}
+
+	return nil
+}
+
+// UpsertMember records the time the user joined the group, replacing the previous one.
+func (r *Repository) UpsertMember(ctx context.Context, groupID, userID int64, joinedAt time.Time) error {
+	if _, err := r.db.NewInsert().
+		Model(newMemberModel(groupID, userID, joinedAt)).
+		On("DUPLICATE KEY UPDATE").
+		Set("joined_at = VALUES(joined_at)").
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to upsert group member: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteMember removes the membership record of the user.
+func (r *Repository) DeleteMember(ctx context.Context, groupID, userID int64) error {
+	if _, err := r.db.NewDelete().
+		Model((*memberModel)(nil)).
+		Where("group_id = ?", groupID).
+		Where("user_id = ?", userID).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete group member: %w", err)
+	}
+
+	return nil
+}
+
+// GetMemberJoinedAt returns the time the user joined the group, or zero time if it's unknown.
+func (r *Repository) GetMemberJoinedAt(ctx context.Context, groupID, userID int64) (time.Time, error) {
+	model := new(memberModel)
+	err := r.db.NewSelect().
+		Model(model).
+		Where("group_id = ?", groupID).
+		Where("user_id = ?", userID).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get group member: %w", err)
+	}
+
+	return model.JoinedAt, nil
+}
+
+// SelectActive returns all active groups.
+func (r *Repository) SelectActive(ctx context.Context) ([]Group, error) {
+	var groups []GroupModel
+	if err := r.db.NewSelect().
+		Model(&groups).
+		Where("is_active = ?", true).
+		Order("id").
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to select active groups: %w", err)
+	}
+
+	return lo.Map(groups, func(m GroupModel, _ int) Group { return *newGroup(&m) }), nil
+}
+
 func (r *Repository) SelectByIDs(ctx context.Context, ids []int64) ([]GroupWithSettings, error) {
 	if len(ids) == 0 {
 		return []GroupWithSettings{}, nil
@@ -109,22 +196,36 @@ func (r *Repository) GetByUser(ctx context.Context, userID int64) ([]GroupWithSe
 		}), nil
 }
 
-func (r *Repository) IsAdmin(ctx context.Context, groupID int64, userID int64) (bool, error) {
-	var admin adminModel
+// GetAdmin returns the admin record of the user in the group.
+func (r *Repository) GetAdmin(ctx context.Context, groupID, userID int64) (*Admin, error) {
+	model := new(adminModel)
 	err := r.db.NewSelect().
-		Model(&admin).
+		Model(model).
 		Where("group_id = ?", groupID).
 		Where("user_id = ?", userID).
 		Scan(ctx)
 	if errors.Is(err, sql.ErrNoRows) {
-		return false, nil
+		return nil, ErrAdminNotFound
 	}
 
 	if err != nil {
-		return false, fmt.Errorf("failed to check if user is admin: %w", err)
+		return nil, fmt.Errorf("failed to get group admin: %w", err)
+	}
+
+	return newAdmin(model), nil
+}
+
+// SelectAdmins returns the admins of the group.
+func (r *Repository) SelectAdmins(ctx context.Context, groupID int64) ([]Admin, error) {
+	var models []adminModel
+	if err := r.db.NewSelect().
+		Model(&models).
+		Where("group_id = ?", groupID).
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to select group admins: %w", err)
 	}
 
-	return true, nil
+	return lo.Map(models, func(m adminModel, _ int) Admin { return *newAdmin(&m) }), nil
 }
 
 // GetByID returns a group by its ID.
@@ -146,6 +247,25 @@ func (r *Repository) GetByID(ctx context.Context, groupID int64) (*GroupWithSett
 	return newGroupWithSettings(group), nil
 }
 
+// GetByTelegramID returns a group by its Telegram ID.
+func (r *Repository) GetByTelegramID(ctx context.Context, telegramID int64) (*GroupWithSettings, error) {
+	group := new(GroupModel)
+	err := r.db.NewSelect().
+		Model(group).
+		Relation("Settings").
+		Where("telegram_group_id = ?", telegramID).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group by Telegram ID: %w", err)
+	}
+
+	return newGroupWithSettings(group), nil
+}
+
 // UpdateStatus updates the status of a group.
 func (r *Repository) UpdateStatus(ctx context.Context, telegramID int64, isActive bool) error {
 	_, err := r.db.NewUpdate().
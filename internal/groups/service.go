@@ -2,7 +2,9 @@ package groups
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/capcom6/lucky-pick-tg-bot/internal/actions"
 )
@@ -24,35 +26,114 @@ func NewService(groups *Repository, actionsSvc *actions.Service) *Service {
 }
 
 // CreateOrUpdate creates a new group record or updates an existing one.
-func (s *Service) CreateOrUpdate(ctx context.Context, group GroupDraft, admin Admin) error {
-	if err := s.groups.CreateOrUpdate(ctx, &group, []Admin{admin}); err != nil {
-		return fmt.Errorf("failed to create or update group: %w", err)
+// The admin who added the bot is stored right away, the others come with SyncAdmins.
+func (s *Service) CreateOrUpdate(ctx context.Context, group GroupDraft, admin Admin) (*GroupWithSettings, error) {
+	created, err := s.groups.CreateOrUpdate(ctx, &group)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create or update group: %w", err)
+	}
+
+	if adminErr := s.SetAdmin(ctx, created.ID, admin); adminErr != nil {
+		return nil, adminErr
 	}
 
 	// Log the action
-	s.actionsSvc.LogAction(
-		ctx,
-		"group.enabled",
-		admin.UserID,
-		0,
-		fmt.Sprintf("Enable group %q with telegram ID %d", group.Title, group.TelegramID),
-	)
+	s.actionsSvc.Log(ctx, actions.Action{
+		Kind:        actions.KindGroupEnabled,
+		UserID:      admin.UserID,
+		GroupID:     created.ID,
+		Description: fmt.Sprintf("Enable group %q with telegram ID %d", group.Title, group.TelegramID),
+	})
+
+	return created, nil
+}
+
+// SyncAdmins replaces the admins of the group with the list reported by Telegram.
+func (s *Service) SyncAdmins(ctx context.Context, groupID int64, admins []Admin) error {
+	if err := s.groups.ReplaceAdmins(ctx, groupID, admins, time.Now()); err != nil {
+		return fmt.Errorf("failed to sync admins: %w", err)
+	}
+
+	return nil
+}
+
+// SetAdmin creates or updates a single admin of the group.
+func (s *Service) SetAdmin(ctx context.Context, groupID int64, admin Admin) error {
+	if err := s.groups.UpsertAdmin(ctx, groupID, admin, time.Now()); err != nil {
+		return fmt.Errorf("failed to set admin: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveAdmin revokes admin access of the user to the group.
+func (s *Service) RemoveAdmin(ctx context.Context, groupID, userID int64) error {
+	if err := s.groups.DeleteAdmin(ctx, groupID, userID); err != nil {
+		return fmt.Errorf("failed to remove admin: %w", err)
+	}
 
 	return nil
 }
 
+// AddMember records that the user joined the group at the given time.
+func (s *Service) AddMember(ctx context.Context, groupID, userID int64, joinedAt time.Time) error {
+	if err := s.groups.UpsertMember(ctx, groupID, userID, joinedAt); err != nil {
+		return fmt.Errorf("failed to add member: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveMember forgets the membership of the user who left the group.
+func (s *Service) RemoveMember(ctx context.Context, groupID, userID int64) error {
+	if err := s.groups.DeleteMember(ctx, groupID, userID); err != nil {
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+
+	return nil
+}
+
+// MemberSince returns the time the user joined the group. It's zero for the members
+// who joined before the bot started tracking the group or whose join it missed.
+func (s *Service) MemberSince(ctx context.Context, groupID, userID int64) (time.Time, error) {
+	return s.groups.GetMemberJoinedAt(ctx, groupID, userID)
+}
+
+// SelectActive returns all active groups.
+func (s *Service) SelectActive(ctx context.Context) ([]Group, error) {
+	return s.groups.SelectActive(ctx)
+}
+
+// GetByTelegramID returns a group by its Telegram ID.
+func (s *Service) GetByTelegramID(ctx context.Context, telegramID int64) (*GroupWithSettings, error) {
+	return s.groups.GetByTelegramID(ctx, telegramID)
+}
+
 func (s *Service) SelectByIDs(ctx context.Context, ids []int64) ([]GroupWithSettings, error) {
 	return s.groups.SelectByIDs(ctx, ids)
 }
 
 // Disable implements Service.
 func (s *Service) Disable(ctx context.Context, telegramID int64) error {
-	if err := s.groups.UpdateStatus(ctx, telegramID, false); err != nil {
-		return fmt.Errorf("failed to disable group: %w", err)
+	// Nothing to disable if the group was never registered
+	group, err := s.groups.GetByTelegramID(ctx, telegramID)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get group: %w", err)
+	}
+
+	if updErr := s.groups.UpdateStatus(ctx, telegramID, false); updErr != nil {
+		return fmt.Errorf("failed to disable group: %w", updErr)
 	}
 
 	// Log the action
-	s.actionsSvc.LogAction(ctx, "group.disabled", 0, 0, fmt.Sprintf("Disable group with telegram ID %d", telegramID))
+	s.actionsSvc.Log(ctx, actions.Action{
+		Kind:        actions.KindGroupDisabled,
+		GroupID:     group.ID,
+		Description: fmt.Sprintf("Disable group with telegram ID %d", telegramID),
+	})
 
 	return nil
 }
@@ -62,9 +143,14 @@ func (s *Service) GetUserAdminGroups(ctx context.Context, userID int64) ([]Group
 	return s.groups.GetByUser(ctx, userID)
 }
 
-// IsAdmin returns true if the user is an admin of the group.
-func (s *Service) IsAdmin(ctx context.Context, groupID int64, userID int64) (bool, error) {
-	return s.groups.IsAdmin(ctx, groupID, userID)
+// GetAdmin returns the admin record of the user in the group, ErrAdminNotFound if the user is not an admin.
+func (s *Service) GetAdmin(ctx context.Context, groupID, userID int64) (*Admin, error) {
+	return s.groups.GetAdmin(ctx, groupID, userID)
+}
+
+// SelectAdmins returns the admins of the group.
+func (s *Service) SelectAdmins(ctx context.Context, groupID int64) ([]Admin, error) {
+	return s.groups.SelectAdmins(ctx, groupID)
 }
 
 // GetByID returns a group by its ID.
@@ -0,0 +1,113 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/scheduler"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/go-core-fx/cachefx/cache"
+	"github.com/uptrace/bun"
+)
+
+const (
+	// telegramCacheTTL limits how often getMe is called by probes.
+	telegramCacheTTL = time.Minute
+	// schedulerMaxAge is the longest allowed time since the last scheduler tick.
+	// Tasks run every minute, so it leaves room for a few slow ticks.
+	schedulerMaxAge = 5 * time.Minute
+
+	cacheProbeKey = "probe"
+)
+
+var errSchedulerStale = errors.New("scheduler is stale")
+
+type dbCheck struct {
+	db *bun.DB
+}
+
+func (c *dbCheck) Name() string {
+	return "database"
+}
+
+func (c *dbCheck) Check(ctx context.Context) error {
+	if err := c.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return nil
+}
+
+type cacheCheck struct {
+	cache cache.Cache
+}
+
+func (c *cacheCheck) Name() string {
+	return "cache"
+}
+
+func (c *cacheCheck) Check(ctx context.Context) error {
+	if err := c.cache.Set(ctx, cacheProbeKey, []byte(time.Now().Format(time.RFC3339)), cache.WithTTL(time.Minute)); err != nil {
+		return fmt.Errorf("failed to write to cache: %w", err)
+	}
+
+	if _, err := c.cache.Get(ctx, cacheProbeKey); err != nil {
+		return fmt.Errorf("failed to read from cache: %w", err)
+	}
+
+	return nil
+}
+
+// telegramCheck calls getMe and caches the result to avoid hitting the API on every probe.
+type telegramCheck struct {
+	bot *gotelegrambotfx.Bot
+
+	mux       sync.Mutex
+	checkedAt time.Time
+	err       error
+}
+
+func (c *telegramCheck) Name() string {
+	return "telegram"
+}
+
+func (c *telegramCheck) Check(ctx context.Context) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if time.Since(c.checkedAt) < telegramCacheTTL {
+		return c.err
+	}
+
+	c.err = nil
+	if _, err := c.bot.GetMe(ctx); err != nil {
+		c.err = fmt.Errorf("failed to call getMe: %w", err)
+	}
+	c.checkedAt = time.Now()
+
+	return c.err
+}
+
+type schedulerCheck struct {
+	scheduler *scheduler.Service
+}
+
+func (c *schedulerCheck) Name() string {
+	return "scheduler"
+}
+
+func (c *schedulerCheck) Check(_ context.Context) error {
+	lastTick := c.scheduler.LastTick()
+	if lastTick.IsZero() {
+		return fmt.Errorf("%w: not started", errSchedulerStale)
+	}
+
+	if age := time.Since(lastTick); age > schedulerMaxAge {
+		return fmt.Errorf("%w: last tick %s ago", errSchedulerStale, age.Truncate(time.Second))
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package health
+
+import "context"
+
+type Status string
+
+const (
+	StatusOK   Status = "ok"
+	StatusFail Status = "fail"
+)
+
+// Check verifies a single dependency of the application.
+type Check interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+type Component struct {
+	Status Status `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status     Status               `json:"status"`
+	Components map[string]Component `json:"components,omitempty"`
+}
@@ -0,0 +1,23 @@
+package health
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// Register exposes the liveness probe at /healthz and the readiness probe at /readyz.
+func Register(app *fiber.App, svc *Service) {
+	app.Get("/healthz", func(c *fiber.Ctx) error {
+		return c.JSON(Report{Status: StatusOK, Components: nil})
+	})
+
+	app.Get("/readyz", func(c *fiber.Ctx) error {
+		report := svc.Ready(c.UserContext())
+
+		status := fiber.StatusOK
+		if report.Status != StatusOK {
+			status = fiber.StatusServiceUnavailable
+		}
+
+		return c.Status(status).JSON(report)
+	})
+}
@@ -0,0 +1,38 @@
+package health
+
+import (
+	"fmt"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/scheduler"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/go-core-fx/cachefx"
+	"github.com/go-core-fx/logger"
+	"github.com/uptrace/bun"
+	"go.uber.org/fx"
+)
+
+func Module() fx.Option {
+	return fx.Module(
+		"health",
+		logger.WithNamedLogger("health"),
+		fx.Provide(
+			func(db *bun.DB, factory cachefx.Factory, bot *gotelegrambotfx.Bot, sched *scheduler.Service) ([]Check, error) {
+				storage, err := factory.New("health")
+				if err != nil {
+					return nil, fmt.Errorf("create cache: %w", err)
+				}
+
+				//nolint:exhaustruct // zero state is valid
+				return []Check{
+					&dbCheck{db: db},
+					&cacheCheck{cache: storage},
+					&telegramCheck{bot: bot},
+					&schedulerCheck{scheduler: sched},
+				}, nil
+			},
+			fx.Private,
+		),
+		fx.Provide(NewService),
+		fx.Invoke(Register),
+	)
+}
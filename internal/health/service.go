@@ -0,0 +1,60 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// checkTimeout limits a single readiness check.
+const checkTimeout = 5 * time.Second
+
+type Service struct {
+	checks []Check
+
+	logger *zap.Logger
+}
+
+func NewService(checks []Check, logger *zap.Logger) *Service {
+	return &Service{
+		checks: checks,
+
+		logger: logger,
+	}
+}
+
+// Ready runs all checks concurrently and reports the status of each component.
+func (s *Service) Ready(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	report := Report{
+		Status:     StatusOK,
+		Components: make(map[string]Component, len(s.checks)),
+	}
+
+	var mux sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range s.checks {
+		wg.Go(func() {
+			component := Component{Status: StatusOK, Error: ""}
+			if err := check.Check(ctx); err != nil {
+				s.logger.Warn("readiness check failed", zap.String("component", check.Name()), zap.Error(err))
+				component = Component{Status: StatusFail, Error: err.Error()}
+			}
+
+			mux.Lock()
+			defer mux.Unlock()
+
+			report.Components[check.Name()] = component
+			if component.Status != StatusOK {
+				report.Status = StatusFail
+			}
+		})
+	}
+	wg.Wait()
+
+	return report
+}
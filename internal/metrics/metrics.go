@@ -0,0 +1,169 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/revrost/go-openrouter"
+)
+
+const namespace = "luckypick"
+
+// Telegram API request outcomes.
+const (
+	OutcomeOK          = "ok"
+	OutcomeRateLimited = "rate_limited"
+	OutcomeRejected    = "rejected"
+	OutcomeError       = "error"
+)
+
+//nolint:gochecknoglobals // collectors are registered once in the default registry
+var (
+	telegramRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "telegram",
+		Name:      "requests_total",
+		Help:      "Telegram Bot API calls by method and outcome.",
+	}, []string{"method", "outcome"})
+	telegramRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "telegram",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of Telegram Bot API calls by method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	updateDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "bot",
+		Name:      "update_duration_seconds",
+		Help:      "Latency of update handling by handler.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"handler"})
+
+	taskDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "scheduler",
+		Name:      "task_duration_seconds",
+		Help:      "Duration of scheduler task runs.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"task"})
+	taskFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "scheduler",
+		Name:      "task_failures_total",
+		Help:      "Failed scheduler task runs.",
+	}, []string{"task"})
+
+	llmDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "llm",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of LLM calls by feature and model.",
+		Buckets:   []float64{0.5, 1, 2, 5, 10, 20, 30, 60},
+	}, []string{"feature", "model"})
+	llmTokens = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "llm",
+		Name:      "tokens_total",
+		Help:      "Tokens used by LLM calls by feature, model and type (prompt or completion).",
+	}, []string{"feature", "model", "type"})
+	llmErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "llm",
+		Name:      "errors_total",
+		Help:      "Failed LLM calls and unusable answers by feature and model.",
+	}, []string{"feature", "model"})
+
+	giveawayTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "giveaways",
+		Name:      "status_transitions_total",
+		Help:      "Giveaway status transitions.",
+	}, []string{"from", "to"})
+	participantsJoined = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "giveaways",
+		Name:      "participants_joined_total",
+		Help:      "Users joined giveaways.",
+	})
+
+	fsmLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "fsm",
+		Name:      "lookups_total",
+		Help:      "FSM state lookups by result (hit or miss).",
+	}, []string{"result"})
+)
+
+// ObserveTelegramRequest records a Telegram Bot API call.
+func ObserveTelegramRequest(method string, started time.Time, resp *http.Response, err error) {
+	outcome := OutcomeOK
+	switch {
+	case err != nil:
+		outcome = OutcomeError
+	case resp.StatusCode == http.StatusTooManyRequests:
+		outcome = OutcomeRateLimited
+	case resp.StatusCode != http.StatusOK:
+		outcome = OutcomeRejected
+	}
+
+	telegramRequests.WithLabelValues(method, outcome).Inc()
+	telegramRequestDuration.WithLabelValues(method).Observe(time.Since(started).Seconds())
+}
+
+// ObserveUpdate records handling of an update by the handler.
+func ObserveUpdate(handler string, started time.Time) {
+	updateDuration.WithLabelValues(handler).Observe(time.Since(started).Seconds())
+}
+
+// ObserveTask records a scheduler task run.
+func ObserveTask(task string, duration time.Duration, err error) {
+	taskDuration.WithLabelValues(task).Observe(duration.Seconds())
+	if err != nil {
+		taskFailures.WithLabelValues(task).Inc()
+	}
+}
+
+// ObserveLLM records an LLM call of the feature.
+func ObserveLLM(feature, model string, started time.Time, usage *openrouter.Usage, err error) {
+	llmDuration.WithLabelValues(feature, model).Observe(time.Since(started).Seconds())
+	if err != nil {
+		LLMFailed(feature, model)
+		return
+	}
+
+	if usage != nil {
+		llmTokens.WithLabelValues(feature, model, "prompt").Add(float64(usage.PromptTokens))
+		llmTokens.WithLabelValues(feature, model, "completion").Add(float64(usage.CompletionTokens))
+	}
+}
+
+// LLMFailed records an LLM answer that can't be used.
+func LLMFailed(feature, model string) {
+	llmErrors.WithLabelValues(feature, model).Inc()
+}
+
+// GiveawayTransition records a giveaway status change. Empty from means a new giveaway.
+func GiveawayTransition(from, to string) {
+	if from == "" {
+		from = "none"
+	}
+	giveawayTransitions.WithLabelValues(from, to).Inc()
+}
+
+// ParticipantJoined records a new giveaway participant.
+func ParticipantJoined() {
+	participantsJoined.Inc()
+}
+
+// FSMLookup records a lookup of the user state.
+func FSMLookup(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	fsmLookups.WithLabelValues(result).Inc()
+}
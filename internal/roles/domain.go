@@ -0,0 +1,137 @@
+package roles
+
+import (
+	"time"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/groups"
+)
+
+// InvitePrefix is the `/start` payload prefix of role invite deep links.
+const InvitePrefix = "role_"
+
+// Role is a bot-level role of a user in a group.
+type Role string
+
+const (
+	RoleOwner   Role = "owner"
+	RoleManager Role = "manager"
+	RoleEditor  Role = "editor"
+	RoleViewer  Role = "viewer"
+)
+
+// Invitable returns the roles granted by invite links. Owners come from Telegram only.
+func Invitable() []Role {
+	return []Role{RoleManager, RoleEditor, RoleViewer}
+}
+
+// IsValid reports whether the role is known.
+func (r Role) IsValid() bool {
+	return r.rank() > 0
+}
+
+// Title returns a human-readable name of the role.
+func (r Role) Title() string {
+	switch r {
+	case RoleOwner:
+		return "Owner"
+	case RoleManager:
+		return "Manager"
+	case RoleEditor:
+		return "Editor"
+	case RoleViewer:
+		return "Viewer"
+	default:
+		return "None"
+	}
+}
+
+// Can reports whether the role grants the permission.
+func (r Role) Can(p Permission) bool {
+	required, ok := minRoles[p]
+	return ok && r.IsValid() && r.rank() >= required.rank()
+}
+
+func (r Role) rank() int {
+	switch r {
+	case RoleOwner:
+		return 4 //nolint:mnd // ranks
+	case RoleManager:
+		return 3 //nolint:mnd // ranks
+	case RoleEditor:
+		return 2 //nolint:mnd // ranks
+	case RoleViewer:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// max returns the stronger of the roles.
+func (r Role) max(other Role) Role {
+	if other.rank() > r.rank() {
+		return other
+	}
+
+	return r
+}
+
+// fromAdmin returns the implicit role of a Telegram chat admin: admins who can promote others are owners.
+func fromAdmin(admin *groups.Admin) Role {
+	if admin.Role == groups.RoleOwner || admin.Rights.CanPromoteMembers {
+		return RoleOwner
+	}
+
+	return RoleManager
+}
+
+// Permission is an action guarded by roles.
+type Permission string
+
+const (
+	PermViewStats        Permission = "stats.view"
+	PermDraftGiveaway    Permission = "giveaways.draft"
+	PermScheduleGiveaway Permission = "giveaways.schedule"
+	PermCancelGiveaway   Permission = "giveaways.cancel"
+	PermReviewFraud      Permission = "fraud.review"
+	PermManageSettings   Permission = "settings.manage"
+	PermManageRoles      Permission = "roles.manage"
+)
+
+// minRoles maps permissions to the weakest role granting them.
+//
+//nolint:gochecknoglobals // static table
+var minRoles = map[Permission]Role{
+	PermViewStats:        RoleViewer,
+	PermDraftGiveaway:    RoleEditor,
+	PermScheduleGiveaway: RoleManager,
+	PermCancelGiveaway:   RoleManager,
+	PermReviewFraud:      RoleManager,
+	PermManageSettings:   RoleOwner,
+	PermManageRoles:      RoleOwner,
+}
+
+// Grant is a role explicitly granted to a user.
+type Grant struct {
+	GroupID   int64
+	UserID    int64
+	Role      Role
+	GrantedBy int64
+
+	CreatedAt time.Time
+}
+
+// Invite is a single-use link granting a role.
+type Invite struct {
+	ID        int64
+	GroupID   int64
+	Role      Role
+	Code      string
+	CreatedBy int64
+
+	ExpiresAt time.Time
+}
+
+// Payload returns the `/start` payload of the invite deep link.
+func (i Invite) Payload() string {
+	return InvitePrefix + i.Code
+}
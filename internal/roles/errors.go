@@ -0,0 +1,8 @@
+package roles
+
+import "errors"
+
+var (
+	ErrInvalidRole    = errors.New("invalid role")
+	ErrInviteNotFound = errors.New("invite not found or expired")
+)
@@ -0,0 +1,75 @@
+package roles
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type grantModel struct {
+	bun.BaseModel `bun:"table:group_roles,alias:gr"`
+
+	ID        int64     `bun:"id,pk,autoincrement"`
+	GroupID   int64     `bun:"group_id,notnull"`
+	UserID    int64     `bun:"user_id,notnull"`
+	Role      Role      `bun:"role,notnull"`
+	GrantedBy int64     `bun:"granted_by,nullzero"`
+	CreatedAt time.Time `bun:"created_at,scanonly"`
+	UpdatedAt time.Time `bun:"updated_at,scanonly"`
+}
+
+func newGrantModel(groupID, userID int64, role Role, grantedBy int64) *grantModel {
+	//nolint:exhaustruct // partial constructor
+	return &grantModel{
+		GroupID:   groupID,
+		UserID:    userID,
+		Role:      role,
+		GrantedBy: grantedBy,
+	}
+}
+
+func (m *grantModel) toGrant() Grant {
+	return Grant{
+		GroupID:   m.GroupID,
+		UserID:    m.UserID,
+		Role:      m.Role,
+		GrantedBy: m.GrantedBy,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+type inviteModel struct {
+	bun.BaseModel `bun:"table:role_invites,alias:ri"`
+
+	ID        int64     `bun:"id,pk,autoincrement"`
+	GroupID   int64     `bun:"group_id,notnull"`
+	Role      Role      `bun:"role,notnull"`
+	Code      string    `bun:"code,notnull"`
+	CreatedBy int64     `bun:"created_by,nullzero"`
+	ExpiresAt time.Time `bun:"expires_at,notnull"`
+	UsedBy    int64     `bun:"used_by,nullzero"`
+	UsedAt    time.Time `bun:"used_at,nullzero"`
+	CreatedAt time.Time `bun:"created_at,scanonly"`
+}
+
+func newInviteModel(groupID int64, role Role, code string, createdBy int64, expiresAt time.Time) *inviteModel {
+	//nolint:exhaustruct // partial constructor
+	return &inviteModel{
+		GroupID:   groupID,
+		Role:      role,
+		Code:      code,
+		CreatedBy: createdBy,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func (m *inviteModel) toInvite() Invite {
+	return Invite{
+		ID:        m.ID,
+		GroupID:   m.GroupID,
+		Role:      m.Role,
+		Code:      m.Code,
+		CreatedBy: m.CreatedBy,
+		ExpiresAt: m.ExpiresAt,
+	}
+}
@@ -0,0 +1,15 @@
+package roles
+
+import (
+	"github.com/go-core-fx/logger"
+	"go.uber.org/fx"
+)
+
+func Module() fx.Option {
+	return fx.Module(
+		"roles",
+		logger.WithNamedLogger("roles"),
+		fx.Provide(NewRepository, fx.Private),
+		fx.Provide(NewService),
+	)
+}
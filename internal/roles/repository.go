@@ -0,0 +1,164 @@
+package roles
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Repository provides persistence operations for roles and invites.
+type Repository struct {
+	db *bun.DB
+}
+
+// NewRepository creates a new instance of the repository.
+func NewRepository(db *bun.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Get returns the role explicitly granted to the user, empty if none.
+func (r *Repository) Get(ctx context.Context, groupID, userID int64) (Role, error) {
+	model := new(grantModel)
+	err := r.db.NewSelect().
+		Model(model).
+		Where("group_id = ?", groupID).
+		Where("user_id = ?", userID).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to get role: %w", err)
+	}
+
+	return model.Role, nil
+}
+
+// SelectByUser returns the roles granted to the user in all groups.
+func (r *Repository) SelectByUser(ctx context.Context, userID int64) ([]grantModel, error) {
+	var models []grantModel
+	if err := r.db.NewSelect().
+		Model(&models).
+		Where("user_id = ?", userID).
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to select user roles: %w", err)
+	}
+
+	return models, nil
+}
+
+// SelectByGroup returns the roles granted in the group.
+func (r *Repository) SelectByGroup(ctx context.Context, groupID int64) ([]grantModel, error) {
+	var models []grantModel
+	if err := r.db.NewSelect().
+		Model(&models).
+		Where("group_id = ?", groupID).
+		Order("id").
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to select group roles: %w", err)
+	}
+
+	return models, nil
+}
+
+// Set grants the role to the user, replacing the previous one.
+func (r *Repository) Set(ctx context.Context, grant *grantModel) error {
+	if _, err := r.db.NewInsert().
+		Model(grant).
+		On("DUPLICATE KEY UPDATE").
+		Set("role = VALUES(role)").
+		Set("granted_by = VALUES(granted_by)").
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to set role: %w", err)
+	}
+
+	return nil
+}
+
+// Delete revokes the role of the user.
+func (r *Repository) Delete(ctx context.Context, groupID, userID int64) error {
+	if _, err := r.db.NewDelete().
+		Model((*grantModel)(nil)).
+		Where("group_id = ?", groupID).
+		Where("user_id = ?", userID).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+
+	return nil
+}
+
+// InsertInvite stores a new invite.
+func (r *Repository) InsertInvite(ctx context.Context, invite *inviteModel) error {
+	if _, err := r.db.NewInsert().
+		Model(invite).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to insert invite: %w", err)
+	}
+
+	return nil
+}
+
+// UseInvite marks the invite as used by the user and grants its role, keeping a stronger role granted before.
+func (r *Repository) UseInvite(ctx context.Context, code string, userID int64, now time.Time) (*inviteModel, error) {
+	invite := new(inviteModel)
+
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		err := tx.NewSelect().
+			Model(invite).
+			Where("code = ?", code).
+			Where("used_at IS NULL").
+			Where("expires_at > ?", now).
+			For("UPDATE").
+			Scan(ctx)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInviteNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get invite: %w", err)
+		}
+
+		if _, err = tx.NewUpdate().
+			Model(invite).
+			Set("used_by = ?", userID).
+			Set("used_at = ?", now).
+			WherePK().
+			Exec(ctx); err != nil {
+			return fmt.Errorf("failed to mark invite used: %w", err)
+		}
+
+		current := new(grantModel)
+		err = tx.NewSelect().
+			Model(current).
+			Where("group_id = ?", invite.GroupID).
+			Where("user_id = ?", userID).
+			Scan(ctx)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to get role: %w", err)
+		}
+		if err == nil && current.Role.rank() >= invite.Role.rank() {
+			return nil
+		}
+
+		if _, err = tx.NewInsert().
+			Model(newGrantModel(invite.GroupID, userID, invite.Role, invite.CreatedBy)).
+			On("DUPLICATE KEY UPDATE").
+			Set("role = VALUES(role)").
+			Set("granted_by = VALUES(granted_by)").
+			Exec(ctx); err != nil {
+			return fmt.Errorf("failed to set role: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to use invite: %w", err)
+	}
+
+	return invite, nil
+}
@@ -0,0 +1,190 @@
+package roles
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/actions"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/groups"
+	"github.com/samber/lo"
+)
+
+// inviteTTL is how long an invite link stays valid.
+const inviteTTL = 7 * 24 * time.Hour
+
+// Service resolves bot roles of users and manages grants and invites.
+//
+// The effective role is the stronger of the role granted in the bot and the implicit role of a Telegram chat admin.
+type Service struct {
+	roles *Repository
+
+	groupsSvc  *groups.Service
+	actionsSvc *actions.Service
+}
+
+func NewService(roles *Repository, groupsSvc *groups.Service, actionsSvc *actions.Service) *Service {
+	return &Service{
+		roles: roles,
+
+		groupsSvc:  groupsSvc,
+		actionsSvc: actionsSvc,
+	}
+}
+
+// Role returns the effective role of the user in the group, empty if none.
+func (s *Service) Role(ctx context.Context, groupID, userID int64) (Role, error) {
+	role, err := s.roles.Get(ctx, groupID, userID)
+	if err != nil {
+		return "", err
+	}
+
+	admin, err := s.groupsSvc.GetAdmin(ctx, groupID, userID)
+	if errors.Is(err, groups.ErrAdminNotFound) {
+		return role, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get group admin: %w", err)
+	}
+
+	return role.max(fromAdmin(admin)), nil
+}
+
+// Can reports whether the user has the permission in the group.
+func (s *Service) Can(ctx context.Context, groupID, userID int64, perm Permission) (bool, error) {
+	role, err := s.Role(ctx, groupID, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return role.Can(perm), nil
+}
+
+// Groups returns the active groups where the user has the permission.
+func (s *Service) Groups(ctx context.Context, userID int64, perm Permission) ([]groups.GroupWithSettings, error) {
+	adminGroups, err := s.groupsSvc.GetUserAdminGroups(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user admin groups: %w", err)
+	}
+
+	grants, err := s.roles.SelectByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	granted := lo.SliceToMap(grants, func(item grantModel) (int64, Role) { return item.GroupID, item.Role })
+
+	result := make([]groups.GroupWithSettings, 0, len(adminGroups)+len(grants))
+	for _, group := range adminGroups {
+		admin, adminErr := s.groupsSvc.GetAdmin(ctx, group.ID, userID)
+		if adminErr != nil {
+			return nil, fmt.Errorf("failed to get group admin: %w", adminErr)
+		}
+
+		if granted[group.ID].max(fromAdmin(admin)).Can(perm) {
+			result = append(result, group)
+		}
+		delete(granted, group.ID)
+	}
+
+	ids := lo.Keys(lo.PickBy(granted, func(_ int64, role Role) bool { return role.Can(perm) }))
+	grantedGroups, err := s.groupsSvc.SelectByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select groups: %w", err)
+	}
+
+	result = append(result, lo.Filter(grantedGroups, func(item groups.GroupWithSettings, _ int) bool {
+		return item.IsActive
+	})...)
+
+	return result, nil
+}
+
+// Members returns the IDs of the users with the permission in the group.
+func (s *Service) Members(ctx context.Context, groupID int64, perm Permission) ([]int64, error) {
+	admins, err := s.groupsSvc.SelectAdmins(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select group admins: %w", err)
+	}
+
+	grants, err := s.roles.SelectByGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	effective := lo.SliceToMap(grants, func(item grantModel) (int64, Role) { return item.UserID, item.Role })
+	for _, admin := range admins {
+		effective[admin.UserID] = effective[admin.UserID].max(fromAdmin(&admin))
+	}
+
+	return lo.Keys(lo.PickBy(effective, func(_ int64, role Role) bool { return role.Can(perm) })), nil
+}
+
+// Grants returns the roles granted in the bot for the group.
+func (s *Service) Grants(ctx context.Context, groupID int64) ([]Grant, error) {
+	models, err := s.roles.SelectByGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(models, func(m grantModel, _ int) Grant { return m.toGrant() }), nil
+}
+
+// Revoke removes the role granted to the user in the bot. Implicit roles of chat admins stay.
+func (s *Service) Revoke(ctx context.Context, groupID, userID, revokedBy int64) error {
+	if err := s.roles.Delete(ctx, groupID, userID); err != nil {
+		return err
+	}
+
+	s.actionsSvc.Log(ctx, actions.Action{
+		Kind:        actions.KindRoleRevoked,
+		UserID:      revokedBy,
+		GroupID:     groupID,
+		Description: fmt.Sprintf("Revoke role of user %d", userID),
+		Payload:     &actions.Payload{TargetUserID: userID},
+	})
+
+	return nil
+}
+
+// CreateInvite creates a single-use invite link granting the role.
+func (s *Service) CreateInvite(ctx context.Context, groupID int64, role Role, createdBy int64) (*Invite, error) {
+	if !lo.Contains(Invitable(), role) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRole, role)
+	}
+
+	model := newInviteModel(groupID, role, rand.Text(), createdBy, time.Now().Add(inviteTTL))
+	if err := s.roles.InsertInvite(ctx, model); err != nil {
+		return nil, err
+	}
+
+	invite := model.toInvite()
+	return &invite, nil
+}
+
+// AcceptInvite grants the role of the invite to the user. The payload is the `/start` payload of the link.
+func (s *Service) AcceptInvite(ctx context.Context, payload string, userID int64) (*Invite, error) {
+	code, ok := strings.CutPrefix(payload, InvitePrefix)
+	if !ok || code == "" {
+		return nil, ErrInviteNotFound
+	}
+
+	model, err := s.roles.UseInvite(ctx, code, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	role := string(model.Role)
+	s.actionsSvc.Log(ctx, actions.Action{
+		Kind:        actions.KindRoleGranted,
+		UserID:      model.CreatedBy,
+		GroupID:     model.GroupID,
+		Description: fmt.Sprintf("Grant role %s to user %d by invite %d", model.Role, userID, model.ID),
+		Payload:     &actions.Payload{TargetUserID: userID, NewValue: &role},
+	})
+
+	invite := model.toInvite()
+	return &invite, nil
+}
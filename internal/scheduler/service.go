@@ -2,15 +2,21 @@ package scheduler
 
 import (
 	"context"
+	"sync/atomic"
 	"time"
 
+	"github.com/capcom6/lucky-pick-tg-bot/internal/metrics"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/scheduler/tasks"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
 type Service struct {
 	tasks []tasks.Task
 
+	lastTick atomic.Int64
+
 	logger *zap.Logger
 }
 
@@ -18,31 +24,41 @@ func NewService(tasks []tasks.Task, logger *zap.Logger) *Service {
 	return &Service{
 		tasks: tasks,
 
+		lastTick: atomic.Int64{},
+
 		logger: logger,
 	}
 }
 
+// LastTick returns the time the scheduler started or last completed a run where every task succeeded.
+// It returns zero time if the scheduler is not started.
+func (s *Service) LastTick() time.Time {
+	nanos := s.lastTick.Load()
+	if nanos == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, nanos)
+}
+
 func (s *Service) Run(ctx context.Context) {
 	ticker := time.NewTicker(time.Minute)
 	defer ticker.Stop()
 
+	s.lastTick.Store(time.Now().UnixNano())
+
 	for {
 		select {
 		case <-ticker.C:
 			s.logger.Debug("running tasks", zap.Int("count", len(s.tasks)))
+			failed := false
 			for _, task := range s.tasks {
-				start := time.Now()
-				if err := task.Run(ctx); err != nil {
-					s.logger.Error("failed to run task",
-						zap.String("task", task.Name()),
-						zap.Error(err),
-					)
+				if err := s.runTask(ctx, task); err != nil {
+					failed = true
 				}
-				duration := time.Since(start)
-				s.logger.Info("task finished",
-					zap.String("task", task.Name()),
-					zap.Duration("duration", duration),
-				)
+			}
+			if !failed {
+				s.lastTick.Store(time.Now().UnixNano())
 			}
 
 		case <-ctx.Done():
@@ -50,3 +66,22 @@ func (s *Service) Run(ctx context.Context) {
 		}
 	}
 }
+
+// runTask runs the task in its own trace and returns its error.
+func (s *Service) runTask(ctx context.Context, task tasks.Task) error {
+	ctx, span := tracing.StartRoot(ctx, "task "+task.Name(), attribute.String("task", task.Name()))
+	logger := s.logger.With(tracing.Fields(ctx)...).With(zap.String("task", task.Name()))
+
+	start := time.Now()
+	err := task.Run(ctx)
+	if err != nil {
+		logger.Error("failed to run task", zap.Error(err))
+	}
+	tracing.End(span, err)
+
+	duration := time.Since(start)
+	metrics.ObserveTask(task.Name(), duration, err)
+	logger.Info("task finished", zap.Duration("duration", duration))
+
+	return err
+}
@@ -0,0 +1,57 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/admins"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"go.uber.org/zap"
+)
+
+// adminsInterval limits how often group admins are synced with Telegram.
+const adminsInterval = time.Hour
+
+// Admins periodically syncs group admins with Telegram in case chat member updates were missed.
+type Admins struct {
+	base
+
+	adminsSvc *admins.Service
+
+	lastRun time.Time
+}
+
+func NewAdmins(
+	bot *gotelegrambotfx.Bot,
+	adminsSvc *admins.Service,
+	logger *zap.Logger,
+) Task {
+	return &Admins{
+		base: base{
+			bot:    bot,
+			logger: logger,
+		},
+
+		adminsSvc: adminsSvc,
+
+		lastRun: time.Time{},
+	}
+}
+
+func (t *Admins) Name() string {
+	return "Admins"
+}
+
+func (t *Admins) Run(ctx context.Context) error {
+	if time.Since(t.lastRun) < adminsInterval {
+		return nil
+	}
+	t.lastRun = time.Now()
+
+	if err := t.adminsSvc.SyncAll(ctx); err != nil {
+		return fmt.Errorf("failed to sync group admins: %w", err)
+	}
+
+	return nil
+}
@@ -1,6 +1,9 @@
 package tasks
 
 import (
+	"context"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/tracing"
 	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
 	"go.uber.org/zap"
 )
@@ -9,3 +12,8 @@ type base struct {
 	bot    *gotelegrambotfx.Bot
 	logger *zap.Logger
 }
+
+// log returns the logger with the trace of the task run.
+func (b *base) log(ctx context.Context) *zap.Logger {
+	return b.logger.With(tracing.Fields(ctx)...)
+}
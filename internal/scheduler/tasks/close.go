@@ -6,7 +6,6 @@ import (
 
 	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
 	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
-	"github.com/go-telegram/bot"
 	"go.uber.org/zap"
 )
 
@@ -39,7 +38,7 @@ func (c *Close) Run(ctx context.Context) error {
 
 	for _, giveaway := range active {
 		if pubErr := c.close(ctx, &giveaway); pubErr != nil {
-			c.logger.Error("failed to close giveaway",
+			c.log(ctx).Error("failed to close giveaway",
 				zap.Int64("giveaway_id", giveaway.ID),
 				zap.Error(pubErr),
 			)
@@ -50,18 +49,6 @@ func (c *Close) Run(ctx context.Context) error {
 }
 
 func (c *Close) close(ctx context.Context, giveaway *giveaways.Giveaway) error {
-	if _, err := c.bot.UnpinChatMessage(
-		ctx,
-		&bot.UnpinChatMessageParams{
-			ChatID:    giveaway.Group.TelegramID,
-			MessageID: int(giveaway.TelegramMessageID),
-		}); err != nil {
-		c.logger.Error("failed to unpin message",
-			zap.Int64("message_id", giveaway.TelegramMessageID),
-			zap.Error(err),
-		)
-	}
-
 	if err := c.giveawaysSvc.Close(ctx, giveaway.ID); err != nil {
 		return fmt.Errorf("failed to close giveaway: %w", err)
 	}
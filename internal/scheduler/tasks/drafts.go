@@ -0,0 +1,83 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/keyboards"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/fsm"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"go.uber.org/zap"
+)
+
+// Drafts expires inactive conversations and offers users to resume the saved drafts.
+type Drafts struct {
+	base
+
+	fsmSvc *fsm.Service
+}
+
+func NewDrafts(bot *gotelegrambotfx.Bot, fsmSvc *fsm.Service, logger *zap.Logger) Task {
+	return &Drafts{
+		base: base{
+			bot:    bot,
+			logger: logger,
+		},
+
+		fsmSvc: fsmSvc,
+	}
+}
+
+func (t *Drafts) Name() string {
+	return "Drafts"
+}
+
+func (t *Drafts) Run(ctx context.Context) error {
+	if err := t.fsmSvc.Expire(ctx); err != nil {
+		return fmt.Errorf("failed to expire states: %w", err)
+	}
+
+	drafts, err := t.fsmSvc.Unnotified(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list unnotified drafts: %w", err)
+	}
+
+	var errs []error
+	for _, draft := range drafts {
+		if notifyErr := t.notify(ctx, draft); notifyErr != nil {
+			errs = append(errs, notifyErr)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (t *Drafts) notify(ctx context.Context, draft fsm.Draft) error {
+	logger := t.log(ctx).With(zap.Int64("draft_id", draft.ID), zap.Int64("user_id", draft.UserID))
+
+	// Drafts exist only for private conversations, so the chat ID is the user ID
+	if _, err := t.bot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: draft.UserID,
+		Text: fmt.Sprintf(
+			"⌛ Your %s draft expired after a period of inactivity.\n\nYou can resume it now or later with /drafts.",
+			draft.Flow.Title,
+		),
+		ReplyMarkup: &models.InlineKeyboardMarkup{
+			InlineKeyboard: [][]models.InlineKeyboardButton{
+				keyboards.DraftRow(draft.ID, "Resume"),
+			},
+		},
+	}); err != nil {
+		// The user may have blocked the bot, the draft is still available with /drafts
+		logger.Warn("failed to notify about expired draft", zap.Error(err))
+	}
+
+	if err := t.fsmSvc.MarkNotified(ctx, draft.ID); err != nil {
+		return fmt.Errorf("failed to mark draft %d as notified: %w", draft.ID, err)
+	}
+
+	return nil
+}
@@ -11,7 +11,13 @@ func Module() fx.Option {
 		logger.WithNamedLogger("tasks"),
 		fx.Provide(fx.Annotate(NewPublish, fx.ResultTags(`group:"tasks"`))),
 		fx.Provide(fx.Annotate(NewClose, fx.ResultTags(`group:"tasks"`))),
+		fx.Provide(fx.Annotate(NewReminders, fx.ResultTags(`group:"tasks"`))),
 		fx.Provide(fx.Annotate(NewFinish, fx.ResultTags(`group:"tasks"`))),
 		fx.Provide(fx.Annotate(NewQuestions, fx.ResultTags(`group:"tasks"`))),
+		fx.Provide(fx.Annotate(NewRetention, fx.ResultTags(`group:"tasks"`))),
+		fx.Provide(fx.Annotate(NewWebhooks, fx.ResultTags(`group:"tasks"`))),
+		fx.Provide(fx.Annotate(NewDrafts, fx.ResultTags(`group:"tasks"`))),
+		fx.Provide(fx.Annotate(NewAdmins, fx.ResultTags(`group:"tasks"`))),
+		fx.Provide(fx.Annotate(NewResign, fx.ResultTags(`group:"tasks"`))),
 	)
 }
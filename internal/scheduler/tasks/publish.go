@@ -2,9 +2,10 @@ package tasks
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strconv"
 
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/posts"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
 	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
 	"github.com/go-telegram/bot"
@@ -39,46 +40,30 @@ func (p *Publish) Run(ctx context.Context) error {
 		return fmt.Errorf("failed to list ready to publish giveaways: %w", err)
 	}
 
+	// Failures are returned to let the scheduler count them
+	errs := make([]error, 0)
 	for _, giveaway := range scheduled {
 		if pubErr := p.publish(ctx, &giveaway); pubErr != nil {
-			p.logger.Error("failed to publish giveaway",
+			p.log(ctx).Error("failed to publish giveaway",
 				zap.Int64("giveaway_id", giveaway.ID),
 				zap.Error(pubErr),
 			)
+			errs = append(errs, fmt.Errorf("giveaway %d: %w", giveaway.ID, pubErr))
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 func (p *Publish) publish(ctx context.Context, giveaway *giveaways.Giveaway) error {
-	// Кнопки
-	markup := &models.InlineKeyboardMarkup{
-		InlineKeyboard: [][]models.InlineKeyboardButton{
-			{
-				{Text: "✅ Хочу!", CallbackData: "participate:" + strconv.FormatInt(giveaway.ID, 10)},
-				// {Text: "❌ Отменить", CallbackData: "cancel"},
-			},
-		},
-	}
-
-	caption := fmt.Sprintf(`%s
-
-*Завершение*: %s
-*Итоги*: %s`,
-		bot.EscapeMarkdown(giveaway.Description),
-		bot.EscapeMarkdown(giveaway.ApplicationEndDate.Format("02.01.2006 15:04")),
-		bot.EscapeMarkdown(giveaway.ResultsDate.Format("02.01.2006 15:04")),
-	)
-
 	params := &bot.SendPhotoParams{
 		ChatID: giveaway.Group.TelegramID,
 		Photo: &models.InputFileString{
 			Data: giveaway.PhotoFileID,
 		},
-		Caption:     caption,
+		Caption:     posts.Caption(giveaway, p.giveawaysSvc.DescribeDraw(giveaway.Draw)),
 		ParseMode:   models.ParseModeMarkdown,
-		ReplyMarkup: markup,
+		ReplyMarkup: posts.Keyboard(giveaway, p.bot.Username(), 0),
 	}
 	message, err := p.bot.SendPhoto(ctx, params)
 	if err != nil {
@@ -89,16 +74,5 @@ func (p *Publish) publish(ctx context.Context, giveaway *giveaways.Giveaway) err
 		return fmt.Errorf("failed to update giveaway: %w", updErr)
 	}
 
-	if _, pinErr := p.bot.PinChatMessage(ctx, &bot.PinChatMessageParams{
-		ChatID:              giveaway.Group.TelegramID,
-		MessageID:           message.ID,
-		DisableNotification: false,
-	}); pinErr != nil {
-		p.logger.Error("failed to pin message",
-			zap.Int("message_id", message.ID),
-			zap.Error(pinErr),
-		)
-	}
-
 	return nil
 }
@@ -85,21 +85,20 @@ func (t *Questions) send(ctx context.Context, d discussions.Discussion, ga givea
 		},
 	})
 	if err != nil {
-		t.logger.Error("failed to send message", zap.Error(err))
+		t.log(ctx).Error("failed to send message", zap.Error(err))
 		return fmt.Errorf("failed to send message: %w", err)
 	}
 
-	t.logger.Info("message sent", zap.Int("message_id", res.ID))
-	t.actionsSvc.LogAction(
-		ctx,
-		"discussion.started",
-		0,
-		ga.ID,
-		fmt.Sprintf("Start discussion with message ID %d", res.ID),
-	)
+	t.log(ctx).Info("message sent", zap.Int("message_id", res.ID))
+	t.actionsSvc.Log(ctx, actions.Action{
+		Kind:        actions.KindDiscussionStarted,
+		GiveawayID:  ga.ID,
+		Description: fmt.Sprintf("Start discussion with message ID %d", res.ID),
+		Payload:     &actions.Payload{MessageID: int64(res.ID)},
+	})
 
 	if setErr := t.discussionsSvc.SetTelegramID(ctx, d.ID, int64(res.ID)); setErr != nil {
-		t.logger.Error("failed to save discussion telegram ID", zap.Error(setErr))
+		t.log(ctx).Error("failed to save discussion telegram ID", zap.Error(setErr))
 		return fmt.Errorf("failed to save discussion telegram ID: %w", setErr)
 	}
 
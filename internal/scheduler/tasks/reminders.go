@@ -0,0 +1,108 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"go.uber.org/zap"
+)
+
+// Reminders replies to the giveaway posts when the end of applications or the results are near.
+type Reminders struct {
+	base
+
+	giveawaysSvc *giveaways.Service
+}
+
+func NewReminders(bot *gotelegrambotfx.Bot, giveawaysSvc *giveaways.Service, logger *zap.Logger) Task {
+	return &Reminders{
+		base: base{
+			bot:    bot,
+			logger: logger,
+		},
+
+		giveawaysSvc: giveawaysSvc,
+	}
+}
+
+func (r *Reminders) Name() string {
+	return "Reminders"
+}
+
+func (r *Reminders) Run(ctx context.Context) error {
+	now := time.Now()
+
+	reminders, err := r.giveawaysSvc.ClaimReminders(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to claim reminders: %w", err)
+	}
+
+	// Failed reminders are released to be retried on the next run
+	errs := make([]error, 0)
+	for _, reminder := range reminders {
+		if sendErr := r.send(ctx, reminder, now); sendErr != nil {
+			r.log(ctx).Error("failed to send reminder",
+				zap.Int64("giveaway_id", reminder.Giveaway.ID),
+				zap.String("kind", string(reminder.Kind)),
+				zap.Error(sendErr),
+			)
+			if relErr := r.giveawaysSvc.ReleaseReminder(ctx, reminder); relErr != nil {
+				r.log(ctx).Error("failed to release reminder",
+					zap.Int64("giveaway_id", reminder.Giveaway.ID),
+					zap.Error(relErr),
+				)
+			}
+			errs = append(errs, fmt.Errorf("giveaway %d: %w", reminder.Giveaway.ID, sendErr))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r *Reminders) send(ctx context.Context, reminder giveaways.Reminder, now time.Time) error {
+	left := formatTimeLeft(reminder.Deadline.Sub(now))
+
+	var text string
+	switch reminder.Kind {
+	case giveaways.ReminderApplicationEnd:
+		text = fmt.Sprintf("⏰ До окончания приёма заявок осталось %s. Успейте принять участие!", left)
+	case giveaways.ReminderResults:
+		text = fmt.Sprintf("🏆 Итоги розыгрыша через %s!", left)
+	}
+
+	if _, err := r.bot.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: reminder.Giveaway.Group.TelegramID,
+		Text:   text,
+		ReplyParameters: &models.ReplyParameters{
+			MessageID:                int(reminder.Giveaway.TelegramMessageID),
+			ChatID:                   reminder.Giveaway.Group.TelegramID,
+			AllowSendingWithoutReply: false,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+
+	return nil
+}
+
+// formatTimeLeft returns the duration rounded up to minutes, e.g. "1 ч. 5 мин.".
+func formatTimeLeft(d time.Duration) string {
+	minutes := int(math.Ceil(d.Minutes()))
+	hours, minutes := minutes/60, minutes%60 //nolint:mnd // minutes per hour
+
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%d мин.", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%d ч.", hours)
+	default:
+		return fmt.Sprintf("%d ч. %d мин.", hours, minutes)
+	}
+}
@@ -0,0 +1,99 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/bot/posts"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"github.com/go-telegram/bot"
+	"go.uber.org/zap"
+)
+
+// Resign signs the keyboards of active giveaways once after start, so buttons of the posts published
+// before the callback data was signed, or signed with another key, keep working.
+type Resign struct {
+	base
+
+	giveawaysSvc *giveaways.Service
+
+	done bool
+}
+
+func NewResign(bot *gotelegrambotfx.Bot, giveawaysSvc *giveaways.Service, logger *zap.Logger) Task {
+	return &Resign{
+		base: base{
+			bot:    bot,
+			logger: logger,
+		},
+
+		giveawaysSvc: giveawaysSvc,
+
+		done: false,
+	}
+}
+
+func (t *Resign) Name() string {
+	return "Resign"
+}
+
+func (t *Resign) Run(ctx context.Context) error {
+	if t.done {
+		return nil
+	}
+
+	active, err := t.giveawaysSvc.ListActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list active giveaways: %w", err)
+	}
+
+	// Failed giveaways are retried on the next run along with the rest
+	errs := make([]error, 0)
+	for _, giveaway := range active {
+		if signErr := t.resign(ctx, &giveaway); signErr != nil {
+			t.log(ctx).Error("failed to resign keyboard",
+				zap.Int64("giveaway_id", giveaway.ID),
+				zap.Error(signErr),
+			)
+			errs = append(errs, fmt.Errorf("giveaway %d: %w", giveaway.ID, signErr))
+		}
+	}
+
+	t.done = len(errs) == 0
+
+	return errors.Join(errs...)
+}
+
+func (t *Resign) resign(ctx context.Context, giveaway *giveaways.Giveaway) error {
+	// Quiz keyboards link to the private chat and carry no callback data
+	if giveaway.Quiz != nil || giveaway.TelegramMessageID == 0 {
+		return nil
+	}
+
+	// Anonymous giveaways don't reveal activity, see subscribers.Counter
+	count := 0
+	if !giveaway.IsAnonymous {
+		var err error
+		if count, err = t.giveawaysSvc.CountParticipants(ctx, giveaway.ID); err != nil {
+			return fmt.Errorf("failed to count participants: %w", err)
+		}
+	}
+
+	_, err := t.bot.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+		ChatID:      giveaway.Group.TelegramID,
+		MessageID:   int(giveaway.TelegramMessageID),
+		ReplyMarkup: posts.Keyboard(giveaway, t.bot.Username(), count),
+	})
+	// The keyboard is signed already, e.g. by the previous start
+	if errors.Is(err, bot.ErrorBadRequest) && strings.Contains(err.Error(), "message is not modified") {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to edit keyboard: %w", err)
+	}
+
+	return nil
+}
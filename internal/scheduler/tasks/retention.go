@@ -0,0 +1,64 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/actions"
+	"github.com/capcom6/lucky-pick-tg-bot/internal/giveaways"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"go.uber.org/zap"
+)
+
+// retentionInterval limits how often the action log and expired challenges are pruned.
+const retentionInterval = time.Hour
+
+type Retention struct {
+	base
+
+	actionsSvc   *actions.Service
+	giveawaysSvc *giveaways.Service
+
+	lastRun time.Time
+}
+
+func NewRetention(
+	bot *gotelegrambotfx.Bot,
+	actionsSvc *actions.Service,
+	giveawaysSvc *giveaways.Service,
+	logger *zap.Logger,
+) Task {
+	return &Retention{
+		base: base{
+			bot:    bot,
+			logger: logger,
+		},
+
+		actionsSvc:   actionsSvc,
+		giveawaysSvc: giveawaysSvc,
+
+		lastRun: time.Time{},
+	}
+}
+
+func (t *Retention) Name() string {
+	return "Retention"
+}
+
+func (t *Retention) Run(ctx context.Context) error {
+	if time.Since(t.lastRun) < retentionInterval {
+		return nil
+	}
+	t.lastRun = time.Now()
+
+	if _, err := t.actionsSvc.Prune(ctx); err != nil {
+		return fmt.Errorf("failed to prune actions: %w", err)
+	}
+
+	if _, err := t.giveawaysSvc.PruneChallenges(ctx); err != nil {
+		return fmt.Errorf("failed to prune challenges: %w", err)
+	}
+
+	return nil
+}
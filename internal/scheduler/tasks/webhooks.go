@@ -0,0 +1,44 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/webhooks"
+	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx"
+	"go.uber.org/zap"
+)
+
+type Webhooks struct {
+	base
+
+	webhooksSvc *webhooks.Service
+}
+
+func NewWebhooks(bot *gotelegrambotfx.Bot, webhooksSvc *webhooks.Service, logger *zap.Logger) Task {
+	return &Webhooks{
+		base: base{
+			bot:    bot,
+			logger: logger,
+		},
+
+		webhooksSvc: webhooksSvc,
+	}
+}
+
+func (t *Webhooks) Name() string {
+	return "Webhooks"
+}
+
+func (t *Webhooks) Run(ctx context.Context) error {
+	count, err := t.webhooksSvc.DeliverDue(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to deliver webhooks: %w", err)
+	}
+
+	if count > 0 {
+		t.log(ctx).Debug("webhook deliveries attempted", zap.Int("count", count))
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"github.com/capcom6/lucky-pick-tg-bot/internal/metrics"
 	"github.com/go-core-fx/fiberfx"
 	"github.com/go-core-fx/logger"
 	"go.uber.org/fx"
@@ -18,6 +19,8 @@ func Module() fx.Option {
 			return opts
 		}),
 
+		fx.Invoke(metrics.Register),
+
 		// fx.Provide(
 		// 	handlers.NewMessagesHandler,
 		// 	fx.Private,
@@ -4,20 +4,23 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/capcom6/lucky-pick-tg-bot/internal/actions"
 	"github.com/capcom6/lucky-pick-tg-bot/internal/groups"
 )
 
 // Service provides business logic for group settings management.
 type Service struct {
-	groupsSvc *groups.Service
-	registry  *SettingRegistry
+	groupsSvc  *groups.Service
+	actionsSvc *actions.Service
+	registry   *SettingRegistry
 }
 
 // NewService creates a new instance of the settings service.
-func NewService(groupsSvc *groups.Service, registry *SettingRegistry) *Service {
+func NewService(groupsSvc *groups.Service, actionsSvc *actions.Service, registry *SettingRegistry) *Service {
 	return &Service{
-		groupsSvc: groupsSvc,
-		registry:  registry,
+		groupsSvc:  groupsSvc,
+		actionsSvc: actionsSvc,
+		registry:   registry,
 	}
 }
 
@@ -88,8 +91,8 @@ func (s *Service) GetAllSettings(ctx context.Context, groupID int64) (map[string
 	return result, hasCustomValue, nil
 }
 
-// UpdateSetting updates a single setting for a group with validation.
-func (s *Service) UpdateSetting(ctx context.Context, groupID int64, key string, value string) error {
+// UpdateSetting updates a single setting for a group with validation on behalf of the user.
+func (s *Service) UpdateSetting(ctx context.Context, groupID, userID int64, key string, value string) error {
 	// Validate the setting exists
 	def, exists := s.registry.GetSetting(key)
 	if !exists {
@@ -101,13 +104,31 @@ func (s *Service) UpdateSetting(ctx context.Context, groupID int64, key string,
 		return fmt.Errorf("invalid setting value: %w", err)
 	}
 
+	oldValue, err := s.groupsSvc.GetSetting(ctx, groupID, key)
+	if err != nil {
+		return fmt.Errorf("failed to get setting %s: %w", key, err)
+	}
+
 	// Update in repository
-	if err := s.groupsSvc.UpdateSetting(ctx, groupID, key, value); err != nil {
-		return fmt.Errorf("failed to update setting %s: %w", key, err)
+	if updErr := s.groupsSvc.UpdateSetting(ctx, groupID, key, value); updErr != nil {
+		return fmt.Errorf("failed to update setting %s: %w", key, updErr)
+	}
+
+	payload := &actions.Payload{
+		SettingKey: key,
+		NewValue:   &value,
+	}
+	if oldValue != "" {
+		payload.OldValue = &oldValue
 	}
 
-	// Log the action (if actions service is available)
-	// Note: This would require injecting the actions service, keeping it simple for now
+	s.actionsSvc.Log(ctx, actions.Action{
+		Kind:        actions.KindSettingsUpdated,
+		UserID:      userID,
+		GroupID:     groupID,
+		Description: fmt.Sprintf("Update setting %s", key),
+		Payload:     payload,
+	})
 
 	return nil
 }
@@ -0,0 +1,58 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/actions"
+)
+
+// Periods are the supported lengths of the statistics window in days.
+//
+//nolint:gochecknoglobals // constant list
+var Periods = []int{7, 30, 90}
+
+// DefaultPeriod is the statistics window in days used when none is selected.
+const DefaultPeriod = 30
+
+// JoinDelay groups participations by the time passed since the giveaway was published.
+type JoinDelay string
+
+const (
+	JoinDelayFirstHour JoinDelay = "first_hour"
+	JoinDelaySameDay   JoinDelay = "same_day"
+	JoinDelayLater     JoinDelay = "later"
+)
+
+// JoinDelays lists join delay buckets in display order.
+//
+//nolint:gochecknoglobals // constant list
+var JoinDelays = []JoinDelay{JoinDelayFirstHour, JoinDelaySameDay, JoinDelayLater}
+
+type TopParticipant struct {
+	UserID         int64
+	TelegramUserID int64
+	Username       string
+	FirstName      string
+
+	Participations int
+	Wins           int
+}
+
+// GroupStats summarizes giveaway activity of a group over a period.
+type GroupStats struct {
+	GroupID int64
+	Period  int
+	Since   time.Time
+
+	GiveawaysByStatus   map[string]int
+	AverageParticipants float64
+	UniqueParticipants  int
+	RepeatParticipants  int
+	JoinDelays          map[JoinDelay]int
+	// DiscussionAnswers is the number of user answers in the giveaway discussions,
+	// DiscussionUsers is the number of users who answered.
+	DiscussionAnswers int
+	DiscussionUsers   int
+	Actions           map[actions.Kind]int
+	TopParticipants   []TopParticipant
+}
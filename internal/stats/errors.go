@@ -0,0 +1,7 @@
+package stats
+
+import "errors"
+
+var (
+	ErrInvalidPeriod = errors.New("invalid period")
+)
@@ -0,0 +1,15 @@
+package stats
+
+import (
+	"github.com/go-core-fx/logger"
+	"go.uber.org/fx"
+)
+
+func Module() fx.Option {
+	return fx.Module(
+		"stats",
+		logger.WithNamedLogger("stats"),
+		fx.Provide(NewRepository, fx.Private),
+		fx.Provide(NewService),
+	)
+}
@@ -0,0 +1,164 @@
+package stats
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type Repository struct {
+	db *bun.DB
+}
+
+func NewRepository(db *bun.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// participations selects joins to giveaways of the group since the given time.
+func (r *Repository) participations(groupID int64, since time.Time) *bun.SelectQuery {
+	return r.db.NewSelect().
+		TableExpr("giveaway_participants AS gap").
+		Join("JOIN giveaways AS ga ON ga.id = gap.giveaway_id").
+		Where("ga.group_id = ?", groupID).
+		Where("gap.joined_at >= ?", since)
+}
+
+func (r *Repository) CountGiveawaysByStatus(ctx context.Context, groupID int64, since time.Time) (map[string]int, error) {
+	rows := make([]struct {
+		Status string `bun:"status"`
+		Count  int    `bun:"count"`
+	}, 0)
+
+	if err := r.db.NewSelect().
+		TableExpr("giveaways AS ga").
+		ColumnExpr("ga.status, COUNT(*) AS count").
+		Where("ga.group_id = ?", groupID).
+		Where("ga.created_at >= ?", since).
+		Group("ga.status").
+		Scan(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to count giveaways: %w", err)
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+
+	return counts, nil
+}
+
+// AverageParticipants returns the average number of participants of published giveaways.
+func (r *Repository) AverageParticipants(ctx context.Context, groupID int64, since time.Time) (float64, error) {
+	perGiveaway := r.db.NewSelect().
+		TableExpr("giveaways AS ga").
+		ColumnExpr("COUNT(gap.id) AS participants").
+		Join("LEFT JOIN giveaway_participants AS gap ON gap.giveaway_id = ga.id").
+		Where("ga.group_id = ?", groupID).
+		Where("ga.created_at >= ?", since).
+		Where("ga.telegram_message_id IS NOT NULL").
+		Group("ga.id")
+
+	var avg sql.NullFloat64
+	if err := r.db.NewSelect().
+		TableExpr("(?) AS t", perGiveaway).
+		ColumnExpr("AVG(t.participants)").
+		Scan(ctx, &avg); err != nil {
+		return 0, fmt.Errorf("failed to get average participants: %w", err)
+	}
+
+	return avg.Float64, nil
+}
+
+// CountParticipants returns the number of unique participants and of those who joined more than one giveaway.
+func (r *Repository) CountParticipants(ctx context.Context, groupID int64, since time.Time) (int, int, error) {
+	perUser := r.participations(groupID, since).
+		ColumnExpr("gap.user_id, COUNT(*) AS participations").
+		Group("gap.user_id")
+
+	var unique, repeat sql.NullInt64
+	if err := r.db.NewSelect().
+		TableExpr("(?) AS t", perUser).
+		ColumnExpr("COUNT(*)").
+		ColumnExpr("SUM(t.participations > 1)").
+		Scan(ctx, &unique, &repeat); err != nil {
+		return 0, 0, fmt.Errorf("failed to count participants: %w", err)
+	}
+
+	return int(unique.Int64), int(repeat.Int64), nil
+}
+
+// CountJoinDelays groups participations by the time passed since the giveaway publication.
+func (r *Repository) CountJoinDelays(ctx context.Context, groupID int64, since time.Time) (map[JoinDelay]int, error) {
+	rows := make([]struct {
+		Bucket JoinDelay `bun:"bucket"`
+		Count  int       `bun:"count"`
+	}, 0)
+
+	if err := r.participations(groupID, since).
+		ColumnExpr(
+			"CASE"+
+				" WHEN TIMESTAMPDIFF(MINUTE, COALESCE(ga.published_at, ga.publish_date), gap.joined_at) < 60 THEN ?"+
+				" WHEN TIMESTAMPDIFF(HOUR, COALESCE(ga.published_at, ga.publish_date), gap.joined_at) < 24 THEN ?"+
+				" ELSE ? END AS bucket",
+			JoinDelayFirstHour, JoinDelaySameDay, JoinDelayLater,
+		).
+		ColumnExpr("COUNT(*) AS count").
+		Group("bucket").
+		Scan(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to count join delays: %w", err)
+	}
+
+	counts := make(map[JoinDelay]int, len(rows))
+	for _, row := range rows {
+		counts[row.Bucket] = row.Count
+	}
+
+	return counts, nil
+}
+
+// CountDiscussionAnswers returns the number of user answers in the discussions of the group giveaways
+// and the number of users who answered. The messages of the bot are not counted.
+func (r *Repository) CountDiscussionAnswers(ctx context.Context, groupID int64, since time.Time) (int, int, error) {
+	var answers, users int
+	if err := r.db.NewSelect().
+		TableExpr("giveaway_discussions AS gd").
+		Join("JOIN giveaways AS ga ON ga.id = gd.giveaway_id").
+		ColumnExpr("COUNT(*)").
+		ColumnExpr("COUNT(DISTINCT gd.user_id)").
+		Where("ga.group_id = ?", groupID).
+		Where("gd.user_id IS NOT NULL").
+		Where("gd.created_at >= ?", since).
+		Scan(ctx, &answers, &users); err != nil {
+		return 0, 0, fmt.Errorf("failed to count discussion answers: %w", err)
+	}
+
+	return answers, users, nil
+}
+
+func (r *Repository) ListTopParticipants(
+	ctx context.Context,
+	groupID int64,
+	since time.Time,
+	limit int,
+) ([]TopParticipant, error) {
+	rows := make([]TopParticipant, 0, limit)
+
+	if err := r.participations(groupID, since).
+		Join("JOIN users AS u ON u.id = gap.user_id").
+		ColumnExpr("u.id AS user_id, u.telegram_user_id, u.username, u.first_name").
+		ColumnExpr("COUNT(*) AS participations").
+		ColumnExpr("SUM(ga.winner_user_id = u.id) AS wins").
+		Group("u.id").
+		OrderExpr("participations DESC, wins DESC").
+		Limit(limit).
+		Scan(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to list top participants: %w", err)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,86 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/capcom6/lucky-pick-tg-bot/internal/actions"
+)
+
+const topParticipantsLimit = 5
+
+type Service struct {
+	stats *Repository
+
+	actionsSvc *actions.Service
+}
+
+func NewService(stats *Repository, actionsSvc *actions.Service) *Service {
+	return &Service{
+		stats: stats,
+
+		actionsSvc: actionsSvc,
+	}
+}
+
+// ForGroup computes statistics of the group for the last period days.
+func (s *Service) ForGroup(ctx context.Context, groupID int64, period int) (*GroupStats, error) {
+	if !slices.Contains(Periods, period) {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidPeriod, period)
+	}
+
+	since := time.Now().AddDate(0, 0, -period)
+
+	byStatus, err := s.stats.CountGiveawaysByStatus(ctx, groupID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	average, err := s.stats.AverageParticipants(ctx, groupID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	unique, repeat, err := s.stats.CountParticipants(ctx, groupID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	delays, err := s.stats.CountJoinDelays(ctx, groupID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	answers, answered, err := s.stats.CountDiscussionAnswers(ctx, groupID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	top, err := s.stats.ListTopParticipants(ctx, groupID, since, topParticipantsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	actionCounts, err := s.actionsSvc.CountByGroup(ctx, groupID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count actions: %w", err)
+	}
+
+	return &GroupStats{
+		GroupID: groupID,
+		Period:  period,
+		Since:   since,
+
+		GiveawaysByStatus:   byStatus,
+		AverageParticipants: average,
+		UniqueParticipants:  unique,
+		RepeatParticipants:  repeat,
+		JoinDelays:          delays,
+		DiscussionAnswers:   answers,
+		DiscussionUsers:     answered,
+		Actions:             actionCounts,
+		TopParticipants:     top,
+	}, nil
+}
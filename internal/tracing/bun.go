@@ -0,0 +1,47 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel/trace"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// queryHook creates a span for every bun query.
+type queryHook struct{}
+
+func (h queryHook) BeforeQuery(ctx context.Context, event *bun.QueryEvent) context.Context {
+	if !InTrace(ctx) {
+		// Queries outside of an update or a task, e.g. migrations, are not traced
+		return ctx
+	}
+
+	//nolint:spancheck // the span is ended in AfterQuery
+	ctx, _ = Start(
+		ctx,
+		"db "+event.Operation(),
+		semconv.DBSystemNameMySQL,
+		semconv.DBOperationName(event.Operation()),
+		semconv.DBQueryText(event.Query),
+	)
+
+	return ctx
+}
+
+func (h queryHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	err := event.Err
+	if errors.Is(err, sql.ErrNoRows) {
+		err = nil
+	}
+
+	End(span, err)
+}
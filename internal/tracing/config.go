@@ -0,0 +1,12 @@
+package tracing
+
+type Config struct {
+	// Enabled turns on export of spans. Tracing is a no-op when disabled.
+	Enabled bool
+	// Endpoint is the OTLP/HTTP collector URL, e.g. http://127.0.0.1:4318.
+	Endpoint string
+	// ServiceName is reported as the service.name resource attribute.
+	ServiceName string
+	// SampleRatio is the share of root spans to sample, from 0 to 1.
+	SampleRatio float64
+}
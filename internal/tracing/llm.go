@@ -0,0 +1,35 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/revrost/go-openrouter"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// StartLLM starts a span of a chat completion request.
+func StartLLM(ctx context.Context, feature, model string) (context.Context, trace.Span) {
+	//nolint:spancheck // the caller ends the span with EndLLM
+	return Start(
+		ctx,
+		"llm "+feature,
+		semconv.GenAIOperationNameChat,
+		semconv.GenAIRequestModel(model),
+		attribute.String("llm.feature", feature),
+	)
+}
+
+// EndLLM records token usage and err, if any, and ends the span.
+func EndLLM(span trace.Span, usage *openrouter.Usage, err error) {
+	if usage != nil {
+		span.SetAttributes(
+			semconv.GenAIUsageInputTokens(usage.PromptTokens),
+			semconv.GenAIUsageOutputTokens(usage.CompletionTokens),
+		)
+	}
+
+	End(span, err)
+}
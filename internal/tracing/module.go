@@ -0,0 +1,53 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-core-fx/logger"
+	"github.com/uptrace/bun"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+func Module() fx.Option {
+	return fx.Module(
+		"tracing",
+		logger.WithNamedLogger("tracing"),
+		fx.Invoke(func(config Config, db *bun.DB, lc fx.Lifecycle, log *zap.Logger) {
+			if !config.Enabled {
+				log.Debug("tracing is disabled")
+				return
+			}
+
+			//nolint:staticcheck // the DB is shared, so the hook can't be added with WithQueryHook
+			db.AddQueryHook(queryHook{})
+
+			var provider *sdktrace.TracerProvider
+			lc.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					var err error
+					if provider, err = newProvider(ctx, config); err != nil {
+						return fmt.Errorf("failed to start tracing: %w", err)
+					}
+
+					log.Info("tracing is enabled", zap.String("endpoint", config.Endpoint))
+
+					return nil
+				},
+				OnStop: func(ctx context.Context) error {
+					if provider == nil {
+						return nil
+					}
+
+					if err := provider.Shutdown(ctx); err != nil {
+						return fmt.Errorf("failed to shutdown tracing: %w", err)
+					}
+
+					return nil
+				},
+			})
+		}),
+	)
+}
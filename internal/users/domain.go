@@ -1,6 +1,14 @@
 package users
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReferralPrefix is the `/start` payload prefix of referral deep links.
+const ReferralPrefix = "ref_"
 
 // UserIn represents the data needed to create a new user.
 type UserIn struct {
@@ -17,4 +25,43 @@ type User struct {
 	ID           int64
 	RegisteredAt time.Time
 	IsActive     bool
+	ReferrerID   int64
+
+	// IsNew is true when the user was created by the RegisterUser call that returned it.
+	IsNew bool
+}
+
+// ReferralCode returns the `/start` payload that attributes new users to this user.
+func (u *User) ReferralCode() string {
+	return ReferralPrefix + strconv.FormatInt(u.ID, 36)
+}
+
+// ParseReferralCode returns the ID of the user who owns the referral code.
+func ParseReferralCode(code string) (int64, error) {
+	if !strings.HasPrefix(code, ReferralPrefix) {
+		return 0, fmt.Errorf("%w: missing prefix", ErrInvalidReferral)
+	}
+
+	id, err := strconv.ParseInt(strings.TrimPrefix(code, ReferralPrefix), 36, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%w: malformed code", ErrInvalidReferral)
+	}
+
+	return id, nil
+}
+
+func newUser(model *UserModel) *User {
+	return &User{
+		UserIn: UserIn{
+			TelegramUserID: model.TelegramUserID,
+			Username:       model.Username,
+			FirstName:      model.FirstName,
+			LastName:       model.LastName,
+		},
+		ID:           model.ID,
+		RegisteredAt: model.RegisteredAt,
+		IsActive:     model.IsActive,
+		ReferrerID:   model.ReferrerID,
+		IsNew:        false,
+	}
 }
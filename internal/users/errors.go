@@ -3,5 +3,6 @@ package users
 import "errors"
 
 var (
-	ErrNotFound = errors.New("user not found")
+	ErrNotFound        = errors.New("user not found")
+	ErrInvalidReferral = errors.New("invalid referral")
 )
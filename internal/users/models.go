@@ -17,6 +17,7 @@ type UserModel struct {
 	LastName       string    `bun:"last_name,nullzero"`
 	RegisteredAt   time.Time `bun:"registered_at,scanonly"`
 	IsActive       bool      `bun:"is_active"`
+	ReferrerID     int64     `bun:"referrer_id,nullzero"`
 }
 
 func NewUserModel(telegramUserID int64, username, firstName, lastName string) *UserModel {
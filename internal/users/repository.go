@@ -35,6 +35,7 @@ func (r *Repository) CreateOrUpdate(ctx context.Context, user *UserModel) (bool,
 
 		_, err := tx.NewInsert().
 			Model(user).
+			ExcludeColumn("referrer_id").
 			On("DUPLICATE KEY UPDATE").
 			Returning("*").
 			Exec(ctx)
@@ -56,3 +57,41 @@ func (r *Repository) CreateOrUpdate(ctx context.Context, user *UserModel) (bool,
 
 	return created, nil
 }
+
+// GetByID returns a user by its ID.
+func (r *Repository) GetByID(ctx context.Context, id int64) (*UserModel, error) {
+	user := new(UserModel)
+	err := r.db.NewSelect().
+		Model(user).
+		Where("id = ?", id).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by ID: %w", err)
+	}
+
+	return user, nil
+}
+
+// SetReferrer attributes the user to the referrer unless it is already attributed.
+func (r *Repository) SetReferrer(ctx context.Context, userID, referrerID int64) (bool, error) {
+	res, err := r.db.NewUpdate().
+		Model((*UserModel)(nil)).
+		Set("referrer_id = ?", referrerID).
+		Where("id = ?", userID).
+		Where("referrer_id IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to set referrer: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return affected > 0, nil
+}
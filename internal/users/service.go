@@ -59,18 +59,62 @@ func (s *Service) RegisterUser(ctx context.Context, user UserIn) (*User, error)
 		)
 
 		// Log action after successful DB operation
-		s.actionsSvc.LogAction(ctx, "user.registered", model.ID, 0, fmt.Sprintf("Registered user @%s", model.Username))
+		s.actionsSvc.Log(ctx, actions.Action{
+			Kind:        actions.KindUserRegistered,
+			UserID:      model.ID,
+			Description: fmt.Sprintf("Registered user @%s", model.Username),
+		})
 	}
 
-	return &User{
-		UserIn: UserIn{
-			TelegramUserID: model.TelegramUserID,
-			Username:       model.Username,
-			FirstName:      model.FirstName,
-			LastName:       model.LastName,
-		},
-		ID:           model.ID,
-		RegisteredAt: model.RegisteredAt,
-		IsActive:     model.IsActive,
-	}, nil
+	registered := newUser(model)
+	registered.IsNew = created
+
+	return registered, nil
+}
+
+// GetByID returns a user by its ID.
+func (s *Service) GetByID(ctx context.Context, id int64) (*User, error) {
+	model, err := s.users.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return newUser(model), nil
+}
+
+// ApplyReferral attributes the user to the owner of the referral code.
+// Only the users created by the same update are attributed, see User.IsNew, and they can't refer themselves.
+func (s *Service) ApplyReferral(ctx context.Context, user *User, code string) (bool, error) {
+	referrerID, err := ParseReferralCode(code)
+	if err != nil {
+		return false, err
+	}
+
+	if !user.IsNew {
+		return false, fmt.Errorf("%w: existing user", ErrInvalidReferral)
+	}
+
+	if referrerID == user.ID {
+		return false, fmt.Errorf("%w: self referral", ErrInvalidReferral)
+	}
+
+	if _, getErr := s.users.GetByID(ctx, referrerID); getErr != nil {
+		return false, fmt.Errorf("failed to get referrer: %w", getErr)
+	}
+
+	applied, err := s.users.SetReferrer(ctx, user.ID, referrerID)
+	if err != nil {
+		return false, fmt.Errorf("failed to apply referral: %w", err)
+	}
+
+	if applied {
+		s.actionsSvc.Log(ctx, actions.Action{
+			Kind:        actions.KindUserReferred,
+			UserID:      user.ID,
+			Description: fmt.Sprintf("Referred by user %d", referrerID),
+			Payload:     &actions.Payload{TargetUserID: referrerID},
+		})
+	}
+
+	return applied, nil
 }
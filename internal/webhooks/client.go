@@ -0,0 +1,93 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+const (
+	dialTimeout         = 10 * time.Second
+	tlsHandshakeTimeout = 10 * time.Second
+	idleConnTimeout     = 90 * time.Second
+)
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), not routable on the public internet.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10") //nolint:gochecknoglobals // constant prefix
+
+// newClient creates the client delivering webhooks. The address is checked once more when connecting,
+// since the host may resolve differently than when the webhook was added.
+func newClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{ //nolint:exhaustruct // defaults are fine
+		Timeout: dialTimeout,
+		Control: dialControl,
+	}
+
+	// No proxy, it would be dialed instead of the webhook host and bypass the check
+	transport := &http.Transport{ //nolint:exhaustruct // defaults are fine
+		DialContext:         dialer.DialContext,
+		ForceAttemptHTTP2:   true,
+		TLSHandshakeTimeout: tlsHandshakeTimeout,
+		IdleConnTimeout:     idleConnTimeout,
+	}
+
+	return &http.Client{Timeout: timeout, Transport: transport} //nolint:exhaustruct // defaults are fine
+}
+
+// validateURL checks the URL is an absolute HTTPS one and its host resolves to public addresses only,
+// so webhooks can't reach the internal network.
+func validateURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidURL, err)
+	}
+
+	if u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("%w: only absolute HTTPS URLs are allowed", ErrInvalidURL)
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", u.Hostname())
+	if err != nil {
+		return fmt.Errorf("%w: failed to resolve host: %w", ErrInvalidURL, err)
+	}
+
+	for _, addr := range addrs {
+		if !isPublic(addr) {
+			return fmt.Errorf("%w: host resolves to non-public address %s", ErrInvalidURL, addr)
+		}
+	}
+
+	return nil
+}
+
+// dialControl rejects connections to non-public addresses, see validateURL.
+func dialControl(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("failed to parse address: %w", err)
+	}
+
+	if !isPublic(addrPort.Addr()) {
+		return fmt.Errorf("%w: non-public address %s", ErrForbiddenAddress, addrPort.Addr())
+	}
+
+	return nil
+}
+
+func isPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	return addr.IsValid() &&
+		!addr.IsLoopback() &&
+		!addr.IsPrivate() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() &&
+		!addr.IsMulticast() &&
+		!addr.IsUnspecified() &&
+		!sharedAddressSpace.Contains(addr)
+}
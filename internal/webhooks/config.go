@@ -0,0 +1,10 @@
+package webhooks
+
+import "time"
+
+type Config struct {
+	// Timeout limits a single delivery attempt.
+	Timeout time.Duration
+	// MaxAttempts is the number of attempts after which a delivery is marked as failed.
+	MaxAttempts int
+}
@@ -0,0 +1,96 @@
+package webhooks
+
+import (
+	"slices"
+	"time"
+)
+
+// EventType is a giveaway lifecycle event that can be delivered to a webhook.
+type EventType string
+
+const (
+	EventGiveawayPublished EventType = "giveaway.published"
+	EventParticipantJoined EventType = "participant.joined"
+	EventGiveawayClosed    EventType = "giveaway.closed"
+	EventWinnerSelected    EventType = "winner.selected"
+	EventGiveawayCancelled EventType = "giveaway.cancelled"
+)
+
+// EventTypes returns all supported event types in lifecycle order.
+func EventTypes() []EventType {
+	return []EventType{
+		EventGiveawayPublished,
+		EventParticipantJoined,
+		EventGiveawayClosed,
+		EventWinnerSelected,
+		EventGiveawayCancelled,
+	}
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+type Webhook struct {
+	ID      int64
+	GroupID int64
+
+	URL    string
+	Secret string
+	Events []EventType
+
+	CreatedAt time.Time
+}
+
+// IsSubscribed returns true if the webhook receives events of the type.
+func (w *Webhook) IsSubscribed(event EventType) bool {
+	return slices.Contains(w.Events, event)
+}
+
+type Delivery struct {
+	ID        int64
+	WebhookID int64
+
+	Event        EventType
+	Status       DeliveryStatus
+	Attempts     int
+	ResponseCode int
+	Error        string
+
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+}
+
+// Payload is the JSON body sent to webhook endpoints.
+type Payload struct {
+	Event      EventType `json:"event"`
+	OccurredAt time.Time `json:"occurred_at"`
+
+	Giveaway    GiveawayData     `json:"giveaway"`
+	Participant *ParticipantData `json:"participant,omitempty"`
+	Reason      string           `json:"reason,omitempty"`
+}
+
+type GiveawayData struct {
+	ID                 int64     `json:"id"`
+	GroupTelegramID    int64     `json:"group_telegram_id"`
+	GroupTitle         string    `json:"group_title"`
+	MessageID          int64     `json:"message_id,omitempty"`
+	Description        string    `json:"description"`
+	IsAnonymous        bool      `json:"is_anonymous"`
+	ApplicationEndDate time.Time `json:"application_end_date"`
+	ResultsDate        time.Time `json:"results_date"`
+	ParticipantsCount  int       `json:"participants_count,omitempty"`
+}
+
+type ParticipantData struct {
+	TelegramID int64  `json:"telegram_id"`
+	Username   string `json:"username,omitempty"`
+	FirstName  string `json:"first_name,omitempty"`
+	Ticket     string `json:"ticket"`
+	Tickets    int    `json:"tickets"`
+}
@@ -0,0 +1,11 @@
+package webhooks
+
+import "errors"
+
+var (
+	ErrNotFound     = errors.New("webhook not found")
+	ErrInvalidURL   = errors.New("invalid webhook URL")
+	ErrInvalidEvent = errors.New("invalid webhook event")
+
+	ErrForbiddenAddress = errors.New("forbidden webhook address")
+)
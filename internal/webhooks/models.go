@@ -0,0 +1,101 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+)
+
+type webhookModel struct {
+	bun.BaseModel `bun:"table:webhooks,alias:wh"`
+
+	ID        int64     `bun:"id,pk,autoincrement"`
+	GroupID   int64     `bun:"group_id,notnull"`
+	URL       string    `bun:"url,notnull"`
+	Secret    string    `bun:"secret,notnull"`
+	Events    string    `bun:"events,notnull"`
+	CreatedBy int64     `bun:"created_by,nullzero"`
+	CreatedAt time.Time `bun:"created_at,scanonly"`
+	UpdatedAt time.Time `bun:"updated_at,scanonly"`
+}
+
+func newWebhookModel(groupID, userID int64, url, secret string, events []EventType) *webhookModel {
+	//nolint:exhaustruct // partial constructor
+	return &webhookModel{
+		GroupID:   groupID,
+		URL:       url,
+		Secret:    secret,
+		Events:    joinEvents(events),
+		CreatedBy: userID,
+	}
+}
+
+func (m *webhookModel) toWebhook() *Webhook {
+	events := make([]EventType, 0)
+	if m.Events != "" {
+		events = lo.Map(strings.Split(m.Events, ","), func(item string, _ int) EventType { return EventType(item) })
+	}
+
+	return &Webhook{
+		ID:      m.ID,
+		GroupID: m.GroupID,
+
+		URL:    m.URL,
+		Secret: m.Secret,
+		Events: events,
+
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func joinEvents(events []EventType) string {
+	return strings.Join(lo.Map(events, func(item EventType, _ int) string { return string(item) }), ",")
+}
+
+type deliveryModel struct {
+	bun.BaseModel `bun:"table:webhook_deliveries,alias:wd"`
+
+	ID            int64           `bun:"id,pk,autoincrement"`
+	WebhookID     int64           `bun:"webhook_id,notnull"`
+	Event         EventType       `bun:"event,notnull"`
+	Payload       json.RawMessage `bun:"payload,type:json,notnull"`
+	Status        DeliveryStatus  `bun:"status,notnull,default:'pending'"`
+	Attempts      int             `bun:"attempts,notnull"`
+	ResponseCode  int             `bun:"response_code,nullzero"`
+	Error         string          `bun:"error,nullzero"`
+	NextAttemptAt time.Time       `bun:"next_attempt_at,notnull"`
+	CreatedAt     time.Time       `bun:"created_at,scanonly"`
+	UpdatedAt     time.Time       `bun:"updated_at,scanonly"`
+
+	Webhook *webhookModel `bun:"wh,rel:belongs-to,join:webhook_id=id"`
+}
+
+func newDeliveryModel(webhookID int64, event EventType, payload json.RawMessage) *deliveryModel {
+	//nolint:exhaustruct // partial constructor
+	return &deliveryModel{
+		WebhookID:     webhookID,
+		Event:         event,
+		Payload:       payload,
+		Status:        DeliveryPending,
+		NextAttemptAt: time.Now(),
+	}
+}
+
+func (m *deliveryModel) toDelivery() *Delivery {
+	return &Delivery{
+		ID:        m.ID,
+		WebhookID: m.WebhookID,
+
+		Event:        m.Event,
+		Status:       m.Status,
+		Attempts:     m.Attempts,
+		ResponseCode: m.ResponseCode,
+		Error:        m.Error,
+
+		NextAttemptAt: m.NextAttemptAt,
+		CreatedAt:     m.CreatedAt,
+	}
+}
@@ -0,0 +1,15 @@
+package webhooks
+
+import (
+	"github.com/go-core-fx/logger"
+	"go.uber.org/fx"
+)
+
+func Module() fx.Option {
+	return fx.Module(
+		"webhooks",
+		logger.WithNamedLogger("webhooks"),
+		fx.Provide(NewRepository, fx.Private),
+		fx.Provide(NewService),
+	)
+}
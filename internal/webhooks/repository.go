@@ -0,0 +1,142 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/uptrace/bun"
+)
+
+type Repository struct {
+	db *bun.DB
+}
+
+func NewRepository(db *bun.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+func (r *Repository) Create(ctx context.Context, webhook *webhookModel) error {
+	if _, err := r.db.NewInsert().
+		Model(webhook).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetByID(ctx context.Context, id int64) (*Webhook, error) {
+	webhook := new(webhookModel)
+	if err := r.db.NewSelect().
+		Model(webhook).
+		Where("wh.id = ?", id).
+		Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	return webhook.toWebhook(), nil
+}
+
+func (r *Repository) ListByGroup(ctx context.Context, groupID int64) ([]Webhook, error) {
+	webhooks := make([]webhookModel, 0)
+	if err := r.db.NewSelect().
+		Model(&webhooks).
+		Where("wh.group_id = ?", groupID).
+		Order("wh.id ASC").
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	return lo.Map(webhooks, func(item webhookModel, _ int) Webhook { return *item.toWebhook() }), nil
+}
+
+func (r *Repository) UpdateEvents(ctx context.Context, id int64, events []EventType) error {
+	if _, err := r.db.NewUpdate().
+		Model((*webhookModel)(nil)).
+		Set("events = ?", joinEvents(events)).
+		Where("id = ?", id).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to update webhook events: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) Delete(ctx context.Context, id int64) error {
+	if _, err := r.db.NewDelete().
+		Model((*webhookModel)(nil)).
+		Where("id = ?", id).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) CreateDeliveries(ctx context.Context, deliveries []*deliveryModel) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	if _, err := r.db.NewInsert().
+		Model(&deliveries).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to create webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// ListDue returns pending deliveries whose next attempt is due, with their webhooks.
+func (r *Repository) ListDue(ctx context.Context, now time.Time, limit int) ([]deliveryModel, error) {
+	deliveries := make([]deliveryModel, 0)
+	if err := r.db.NewSelect().
+		Model(&deliveries).
+		Relation("Webhook").
+		Where("wd.status = ?", DeliveryPending).
+		Where("wd.next_attempt_at <= ?", now).
+		Order("wd.next_attempt_at ASC", "wd.id ASC").
+		Limit(limit).
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to list due webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// UpdateDelivery stores the result of a delivery attempt.
+func (r *Repository) UpdateDelivery(ctx context.Context, delivery *deliveryModel) error {
+	if _, err := r.db.NewUpdate().
+		Model(delivery).
+		Column("status", "attempts", "response_code", "error", "next_attempt_at").
+		WherePK().
+		Exec(ctx); err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// ListDeliveries returns the latest deliveries of the webhook, newest first.
+func (r *Repository) ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]Delivery, error) {
+	deliveries := make([]deliveryModel, 0)
+	if err := r.db.NewSelect().
+		Model(&deliveries).
+		Where("wd.webhook_id = ?", webhookID).
+		Order("wd.id DESC").
+		Limit(limit).
+		Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return lo.Map(deliveries, func(item deliveryModel, _ int) Delivery { return *item.toDelivery() }), nil
+}
@@ -0,0 +1,226 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	secretLength  = 32
+	deliveryBatch = 50
+	maxErrorLen   = 255
+	// retryBaseDelay is the delay before the first retry, doubled on each next one.
+	retryBaseDelay = time.Minute
+
+	headerEvent     = "X-Webhook-Event"
+	headerDelivery  = "X-Webhook-Delivery"
+	headerSignature = "X-Webhook-Signature-256"
+)
+
+type Service struct {
+	webhooks *Repository
+
+	config Config
+	client *http.Client
+
+	logger *zap.Logger
+}
+
+func NewService(webhooks *Repository, config Config, logger *zap.Logger) *Service {
+	return &Service{
+		webhooks: webhooks,
+
+		config: config,
+		client: newClient(config.Timeout),
+
+		logger: logger,
+	}
+}
+
+// Create registers a webhook of the group subscribed to all events and generates its secret.
+func (s *Service) Create(ctx context.Context, groupID, userID int64, rawURL string) (*Webhook, error) {
+	if err := validateURL(ctx, rawURL); err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, secretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate secret: %w", err)
+	}
+
+	model := newWebhookModel(groupID, userID, rawURL, hex.EncodeToString(secret), EventTypes())
+	if err := s.webhooks.Create(ctx, model); err != nil {
+		return nil, err
+	}
+
+	return model.toWebhook(), nil
+}
+
+func (s *Service) GetByID(ctx context.Context, id int64) (*Webhook, error) {
+	return s.webhooks.GetByID(ctx, id)
+}
+
+func (s *Service) ListByGroup(ctx context.Context, groupID int64) ([]Webhook, error) {
+	return s.webhooks.ListByGroup(ctx, groupID)
+}
+
+// ToggleEvent subscribes the webhook to the event or unsubscribes it.
+func (s *Service) ToggleEvent(ctx context.Context, id int64, event EventType) (*Webhook, error) {
+	if !slices.Contains(EventTypes(), event) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidEvent, event)
+	}
+
+	webhook, err := s.webhooks.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]EventType, 0, len(EventTypes()))
+	for _, item := range EventTypes() {
+		if webhook.IsSubscribed(item) != (item == event) {
+			events = append(events, item)
+		}
+	}
+
+	if updErr := s.webhooks.UpdateEvents(ctx, id, events); updErr != nil {
+		return nil, updErr
+	}
+	webhook.Events = events
+
+	return webhook, nil
+}
+
+func (s *Service) Delete(ctx context.Context, id int64) error {
+	return s.webhooks.Delete(ctx, id)
+}
+
+// ListDeliveries returns the latest deliveries of the webhook.
+func (s *Service) ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]Delivery, error) {
+	return s.webhooks.ListDeliveries(ctx, webhookID, limit)
+}
+
+// Enqueue schedules delivery of the payload to webhooks of the group subscribed to its event.
+func (s *Service) Enqueue(ctx context.Context, groupID int64, payload Payload) error {
+	webhooks, err := s.webhooks.ListByGroup(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	deliveries := make([]*deliveryModel, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		if webhook.IsSubscribed(payload.Event) {
+			deliveries = append(deliveries, newDeliveryModel(webhook.ID, payload.Event, body))
+		}
+	}
+
+	return s.webhooks.CreateDeliveries(ctx, deliveries)
+}
+
+// DeliverDue sends pending deliveries whose attempt is due and returns the number of attempts made.
+func (s *Service) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := s.webhooks.ListDue(ctx, time.Now(), deliveryBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		s.attempt(ctx, delivery)
+
+		if updErr := s.webhooks.UpdateDelivery(ctx, delivery); updErr != nil {
+			s.logger.Error("failed to save delivery attempt", zap.Int64("delivery_id", delivery.ID), zap.Error(updErr))
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// attempt sends the delivery and updates its status, scheduling a retry with exponential backoff on failure.
+func (s *Service) attempt(ctx context.Context, delivery *deliveryModel) {
+	logger := s.logger.With(
+		zap.Int64("delivery_id", delivery.ID),
+		zap.Int64("webhook_id", delivery.WebhookID),
+	)
+
+	delivery.Attempts++
+	delivery.ResponseCode = 0
+	delivery.Error = ""
+
+	code, err := s.send(ctx, delivery)
+	delivery.ResponseCode = code
+	if err == nil {
+		delivery.Status = DeliveryDelivered
+		logger.Debug("webhook delivered", zap.Int("response_code", code))
+		return
+	}
+
+	delivery.Error = truncate(err.Error(), maxErrorLen)
+	if delivery.Attempts >= s.config.MaxAttempts {
+		delivery.Status = DeliveryFailed
+		logger.Warn("webhook delivery failed", zap.Int("attempts", delivery.Attempts), zap.Error(err))
+		return
+	}
+
+	delivery.NextAttemptAt = time.Now().Add(retryBaseDelay << (delivery.Attempts - 1))
+	logger.Debug("webhook delivery will be retried", zap.Time("next_attempt_at", delivery.NextAttemptAt), zap.Error(err))
+}
+
+func (s *Service) send(ctx context.Context, delivery *deliveryModel) (int, error) {
+	if delivery.Webhook == nil {
+		return 0, ErrNotFound
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(headerEvent, string(delivery.Event))
+	req.Header.Set(headerDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(headerSignature, "sha256="+Sign(delivery.Webhook.Secret, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("unexpected response status: %s", resp.Status) //nolint:err113 // not matched by callers
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 of the body with the secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	return s[:n]
+}
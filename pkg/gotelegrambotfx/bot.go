@@ -3,6 +3,7 @@ package gotelegrambotfx
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/capcom6/lucky-pick-tg-bot/pkg/gotelegrambotfx/extractors"
 	"github.com/go-telegram/bot"
@@ -10,20 +11,49 @@ import (
 	"go.uber.org/zap"
 )
 
+// getMeTimeout limits the request of the bot info on start, it matches the default of the underlying bot.
+const getMeTimeout = 5 * time.Second
+
+// Bot is a Telegram bot matching handlers after the middlewares.
+//
+// The underlying bot matches handlers before the middlewares run, so filters can't see anything
+// the middlewares prepare, e.g. the state of the user. Handlers are registered in a separate router
+// instead, which is invoked by the bot as the default handler, i.e. inside the middleware chain.
 type Bot struct {
 	*bot.Bot
+
+	router *bot.Bot
+	signer *Signer
+	logger *zap.Logger
+
+	username string
 }
 
-func New(config Config, options []bot.Option, logger *zap.Logger) (*Bot, error) {
+func New(config Config, signer *Signer, options []bot.Option, logger *zap.Logger) (*Bot, error) {
+	// The router is never started, it only matches and runs handlers synchronously
+	router, err := bot.New(
+		config.Token,
+		bot.WithSkipGetMe(),
+		bot.WithNotAsyncHandlers(),
+		bot.WithDefaultHandler(func(_ context.Context, _ *bot.Bot, update *models.Update) {
+			logger.Debug("update is not handled", zap.Any("update", update))
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create router: %w", err)
+	}
+
 	opts := []bot.Option{
+		// The bot info is requested below to keep the username
+		bot.WithSkipGetMe(),
 		bot.WithErrorsHandler(func(err error) {
 			logger.Error("something went wrong", zap.Error(err))
 		}),
 		bot.WithDebugHandler(func(format string, args ...any) {
 			logger.Debug(fmt.Sprintf(format, args...), zap.String("format", format), zap.Any("args", args))
 		}),
-		bot.WithDefaultHandler(func(_ context.Context, _ *bot.Bot, update *models.Update) {
-			logger.Debug("update is not handled", zap.Any("update", update))
+		bot.WithDefaultHandler(func(ctx context.Context, _ *bot.Bot, update *models.Update) {
+			router.ProcessUpdate(ctx, update)
 		}),
 	}
 
@@ -34,7 +64,41 @@ func New(config Config, options []bot.Option, logger *zap.Logger) (*Bot, error)
 		return nil, fmt.Errorf("create bot: %w", err)
 	}
 
-	return &Bot{b}, nil
+	ctx, cancel := context.WithTimeout(context.Background(), getMeTimeout)
+	defer cancel()
+
+	me, err := b.GetMe(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get bot info: %w", err)
+	}
+
+	return &Bot{Bot: b, router: router, signer: signer, logger: logger, username: me.Username}, nil
+}
+
+// Username returns the username of the bot, e.g. for deep links. It is fetched once on start.
+func (b *Bot) Username() string {
+	return b.username
+}
+
+// RegisterHandler registers the handler in the router, see Bot.
+func (b *Bot) RegisterHandler(
+	handlerType bot.HandlerType,
+	pattern string,
+	matchType bot.MatchType,
+	f bot.HandlerFunc,
+	m ...bot.Middleware,
+) string {
+	return b.router.RegisterHandler(handlerType, pattern, matchType, f, m...)
+}
+
+// RegisterHandlerMatchFunc registers the handler in the router, see Bot.
+func (b *Bot) RegisterHandlerMatchFunc(matchFunc bot.MatchFunc, f bot.HandlerFunc, m ...bot.Middleware) string {
+	return b.router.RegisterHandlerMatchFunc(matchFunc, f, m...)
+}
+
+// UnregisterHandler removes the handler from the router.
+func (b *Bot) UnregisterHandler(id string) {
+	b.router.UnregisterHandler(id)
 }
 
 func (b *Bot) SendReply(
@@ -59,3 +123,96 @@ func (b *Bot) SendReply(
 
 	return b.SendMessage(ctx, &p) //nolint:wrapcheck // pass upstream
 }
+
+// SendMessage sends the message with the callback data of the inline keyboard signed, see Signer.
+func (b *Bot) SendMessage(ctx context.Context, params *bot.SendMessageParams) (*models.Message, error) {
+	if !hasCallbackData(params.ReplyMarkup) {
+		return b.Bot.SendMessage(ctx, params) //nolint:wrapcheck // pass upstream
+	}
+
+	p := *params
+	p.ReplyMarkup = nil
+
+	message, err := b.Bot.SendMessage(ctx, &p)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // pass upstream
+	}
+
+	b.attachKeyboard(ctx, message, params.ReplyMarkup)
+
+	return message, nil
+}
+
+// SendPhoto sends the photo with the callback data of the inline keyboard signed, see Signer.
+func (b *Bot) SendPhoto(ctx context.Context, params *bot.SendPhotoParams) (*models.Message, error) {
+	if !hasCallbackData(params.ReplyMarkup) {
+		return b.Bot.SendPhoto(ctx, params) //nolint:wrapcheck // pass upstream
+	}
+
+	p := *params
+	p.ReplyMarkup = nil
+
+	message, err := b.Bot.SendPhoto(ctx, &p)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // pass upstream
+	}
+
+	b.attachKeyboard(ctx, message, params.ReplyMarkup)
+
+	return message, nil
+}
+
+// attachKeyboard sets the keyboard of the sent message, signed for its ID. The message is already delivered,
+// so a failure is only logged to keep callers from sending it again.
+func (b *Bot) attachKeyboard(ctx context.Context, message *models.Message, markup models.ReplyMarkup) {
+	if _, err := b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+		ChatID:      message.Chat.ID,
+		MessageID:   message.ID,
+		ReplyMarkup: markup,
+	}); err != nil {
+		b.logger.Error(
+			"failed to attach keyboard",
+			zap.Int64("chat_id", message.Chat.ID),
+			zap.Int("message_id", message.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+// EditMessageReplyMarkup replaces the inline keyboard with its callback data signed for the message, see Signer.
+func (b *Bot) EditMessageReplyMarkup(
+	ctx context.Context,
+	params *bot.EditMessageReplyMarkupParams,
+) (*models.Message, error) {
+	p := *params
+	p.ReplyMarkup = b.signer.SignKeyboard(p.ChatID, p.MessageID, p.ReplyMarkup)
+
+	return b.Bot.EditMessageReplyMarkup(ctx, &p) //nolint:wrapcheck // pass upstream
+}
+
+// EditMessageCaption edits the caption with the callback data of the inline keyboard signed for the message,
+// see Signer.
+func (b *Bot) EditMessageCaption(ctx context.Context, params *bot.EditMessageCaptionParams) (*models.Message, error) {
+	p := *params
+	p.ReplyMarkup = b.signer.SignKeyboard(p.ChatID, p.MessageID, p.ReplyMarkup)
+
+	return b.Bot.EditMessageCaption(ctx, &p) //nolint:wrapcheck // pass upstream
+}
+
+// hasCallbackData reports whether the markup is an inline keyboard with callback buttons, i.e. it needs signing.
+func hasCallbackData(markup models.ReplyMarkup) bool {
+	keyboard, ok := markup.(*models.InlineKeyboardMarkup)
+	if !ok || keyboard == nil {
+		return false
+	}
+
+	for _, row := range keyboard.InlineKeyboard {
+		for _, button := range row {
+			if button.CallbackData != "" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
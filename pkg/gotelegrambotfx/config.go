@@ -2,4 +2,6 @@ package gotelegrambotfx
 
 type Config struct {
 	Token string
+	// CallbackSecret is the key for signing callback data, derived from the token when empty.
+	CallbackSecret string
 }
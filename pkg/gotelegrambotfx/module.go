@@ -12,6 +12,7 @@ func Module() fx.Option {
 	return fx.Module(
 		"gotelegrambotfx",
 		logger.WithNamedLogger("gotelegrambotfx"),
+		fx.Provide(NewSigner),
 		fx.Provide(New),
 		// fx.Provide(func(b *Bot) *bot.Bot { return b.Bot }),
 		fx.Invoke(func(b *Bot, log *zap.Logger, lc fx.Lifecycle) {
@@ -0,0 +1,90 @@
+package gotelegrambotfx
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	signatureSeparator = "|"
+	signatureLength    = 8
+)
+
+var ErrInvalidSignature = errors.New("invalid callback data signature")
+
+// Signer signs callback data with a short HMAC tied to the chat and the message of the button,
+// so a crafted callback query can't reach the handlers.
+//
+// The message ID isn't known until the message is sent, so Bot sends new messages without the keyboard
+// and attaches it right after.
+// The signature takes 9 bytes of the 64 bytes Telegram allows for callback data.
+type Signer struct {
+	key []byte
+}
+
+func NewSigner(config Config) *Signer {
+	secret := config.CallbackSecret
+	if secret == "" {
+		secret = "callback:" + config.Token
+	}
+
+	key := sha256.Sum256([]byte(secret))
+
+	return &Signer{key: key[:]}
+}
+
+// Sign returns the data with the signature appended.
+func (s *Signer) Sign(chatID any, messageID int, data string) string {
+	return data + signatureSeparator + s.signature(chatID, messageID, data)
+}
+
+// Verify checks the signature of the data and returns the data without it.
+func (s *Signer) Verify(chatID int64, messageID int, signed string) (string, error) {
+	idx := strings.LastIndex(signed, signatureSeparator)
+	if idx < 0 {
+		return "", ErrInvalidSignature
+	}
+
+	data, signature := signed[:idx], signed[idx+len(signatureSeparator):]
+	if !hmac.Equal([]byte(signature), []byte(s.signature(chatID, messageID, data))) {
+		return "", ErrInvalidSignature
+	}
+
+	return data, nil
+}
+
+// SignKeyboard returns a copy of the markup with the callback data of the inline keyboard signed.
+// Other markups are returned as is.
+func (s *Signer) SignKeyboard(chatID any, messageID int, markup models.ReplyMarkup) models.ReplyMarkup {
+	keyboard, ok := markup.(*models.InlineKeyboardMarkup)
+	if !ok || keyboard == nil {
+		return markup
+	}
+
+	rows := make([][]models.InlineKeyboardButton, 0, len(keyboard.InlineKeyboard))
+	for _, row := range keyboard.InlineKeyboard {
+		buttons := make([]models.InlineKeyboardButton, 0, len(row))
+		for _, button := range row {
+			if button.CallbackData != "" {
+				button.CallbackData = s.Sign(chatID, messageID, button.CallbackData)
+			}
+			buttons = append(buttons, button)
+		}
+		rows = append(rows, buttons)
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func (s *Signer) signature(chatID any, messageID int, data string) string {
+	mac := hmac.New(sha256.New, s.key)
+	_, _ = fmt.Fprintf(mac, "%v:%d:%s", chatID, messageID, data)
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))[:signatureLength]
+}